@@ -15,12 +15,17 @@ func dataSourceAwsAutoscalingGroup() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"arn", "name"},
 			},
 			"arn": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"arn", "name"},
+				ValidateFunc: validateArn,
 			},
 			"availability_zones": {
 				Type:     schema.TypeSet,
@@ -126,6 +131,17 @@ func dataSourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{})
 	conn := meta.(*AWSClient).autoscalingconn
 
 	groupName := d.Get("name").(string)
+	groupARN := d.Get("arn").(string)
+
+	if groupARN != "" {
+		name, err := autoScalingGroupNameFromARN(groupARN)
+
+		if err != nil {
+			return err
+		}
+
+		groupName = name
+	}
 
 	input := &autoscaling.DescribeAutoScalingGroupsInput{
 		AutoScalingGroupNames: []*string{
@@ -158,6 +174,11 @@ func dataSourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{})
 	// and this is a safe operation
 	group := result.AutoScalingGroups[0]
 
+	// A group recreated with the same name has a new ARN.
+	if groupARN != "" && aws.StringValue(group.AutoScalingGroupARN) != groupARN {
+		return fmt.Errorf("Auto Scaling Group (%s) not found: found ARN %s", groupARN, aws.StringValue(group.AutoScalingGroupARN))
+	}
+
 	log.Printf("[DEBUG] aws_autoscaling_group - Single Auto Scaling Group found: %s", *group.AutoScalingGroupName)
 
 	d.SetId(aws.StringValue(group.AutoScalingGroupName))
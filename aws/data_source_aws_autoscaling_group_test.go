@@ -41,6 +41,28 @@ func TestAccAwsAutoScalingGroupDataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccAwsAutoScalingGroupDataSource_arn(t *testing.T) {
+	datasourceName := "data.aws_autoscaling_group.test"
+	resourceName := "aws_autoscaling_group.match"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoScalingGroupDataResourceConfigARN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(datasourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(datasourceName, "max_size", resourceName, "max_size"),
+					resource.TestCheckResourceAttrPair(datasourceName, "min_size", resourceName, "min_size"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAwsAutoScalingGroupDataSource_launchTemplate(t *testing.T) {
 	datasourceName := "data.aws_autoscaling_group.test"
 	resourceName := "aws_autoscaling_group.test"
@@ -119,6 +141,35 @@ resource "aws_launch_configuration" "data_source_aws_autoscaling_group_test" {
 `, rName))
 }
 
+// Lookup based on AutoScalingGroupARN
+func testAccAutoScalingGroupDataResourceConfigARN(rName string) string {
+	return composeConfig(
+		testAccLatestAmazonLinuxHvmEbsAmiConfig(),
+		testAccAvailableAZsNoOptInConfig(),
+		testAccAvailableEc2InstanceTypeForAvailabilityZone("data.aws_availability_zones.available.names[0]", "t3.micro", "t2.micro"),
+		fmt.Sprintf(`
+data "aws_autoscaling_group" "test" {
+  arn = aws_autoscaling_group.match.arn
+}
+
+resource "aws_autoscaling_group" "match" {
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  desired_capacity     = 0
+  force_delete         = true
+  launch_configuration = aws_launch_configuration.test.name
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+}
+
+resource "aws_launch_configuration" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = data.aws_ec2_instance_type_offering.available.instance_type
+}
+`, rName))
+}
+
 func testAccAutoScalingGroupDataResourceConfig_launchTemplate() string {
 	return composeConfig(
 		testAccLatestAmazonLinuxHvmEbsAmiConfig(),
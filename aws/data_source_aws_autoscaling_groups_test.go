@@ -99,7 +99,7 @@ resource "aws_launch_configuration" "foobar" {
 
 resource "aws_autoscaling_group" "bar" {
   availability_zones = [data.aws_availability_zones.available.names[0]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -117,7 +117,7 @@ resource "aws_autoscaling_group" "bar" {
 
 resource "aws_autoscaling_group" "foo" {
   availability_zones = [data.aws_availability_zones.available.names[1]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -135,7 +135,7 @@ resource "aws_autoscaling_group" "foo" {
 
 resource "aws_autoscaling_group" "barbaz" {
   availability_zones = [data.aws_availability_zones.available.names[2]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -172,7 +172,7 @@ resource "aws_launch_configuration" "foobar" {
 
 resource "aws_autoscaling_group" "bar" {
   availability_zones = [data.aws_availability_zones.available.names[0]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -190,7 +190,7 @@ resource "aws_autoscaling_group" "bar" {
 
 resource "aws_autoscaling_group" "foo" {
   availability_zones = [data.aws_availability_zones.available.names[1]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -208,7 +208,7 @@ resource "aws_autoscaling_group" "foo" {
 
 resource "aws_autoscaling_group" "barbaz" {
   availability_zones = [data.aws_availability_zones.available.names[2]]
-  name               = "test-asg-%d"
+  name               = "tf-acc-test-asg-%d"
   max_size           = 1
   min_size           = 0
   health_check_type  = "EC2"
@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func dataSourceAwsFsxWindowsFileSystem() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsFsxWindowsFileSystemRead,
+
+		Schema: map[string]*schema.Schema{
+			"aliases": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"automatic_backup_retention_days": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"deployment_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_interface_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"preferred_file_server_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"preferred_subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_administration_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"storage_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"storage_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subnet_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": tagsSchemaComputed(),
+			"throughput_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsFsxWindowsFileSystemRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).fsxconn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	id := d.Get("id").(string)
+	filesystem, err := describeFsxFileSystem(conn, id)
+
+	if err != nil {
+		return fmt.Errorf("error reading FSx Windows File System (%s): %w", id, err)
+	}
+
+	if filesystem == nil {
+		return fmt.Errorf("error reading FSx Windows File System (%s): not found", id)
+	}
+
+	if filesystem.WindowsConfiguration == nil {
+		return fmt.Errorf("expected FSx Windows File System, found FSx %s File System: %s", aws.StringValue(filesystem.FileSystemType), id)
+	}
+
+	d.SetId(aws.StringValue(filesystem.FileSystemId))
+
+	if err := d.Set("aliases", flattenFsxAliases(filesystem.WindowsConfiguration.Aliases)); err != nil {
+		return fmt.Errorf("error setting aliases: %s", err)
+	}
+
+	d.Set("arn", filesystem.ResourceARN)
+	d.Set("automatic_backup_retention_days", filesystem.WindowsConfiguration.AutomaticBackupRetentionDays)
+	d.Set("deployment_type", filesystem.WindowsConfiguration.DeploymentType)
+	d.Set("dns_name", filesystem.DNSName)
+	d.Set("kms_key_id", filesystem.KmsKeyId)
+
+	if err := d.Set("network_interface_ids", aws.StringValueSlice(filesystem.NetworkInterfaceIds)); err != nil {
+		return fmt.Errorf("error setting network_interface_ids: %s", err)
+	}
+
+	d.Set("owner_id", filesystem.OwnerId)
+	d.Set("preferred_file_server_ip", filesystem.WindowsConfiguration.PreferredFileServerIp)
+	d.Set("preferred_subnet_id", filesystem.WindowsConfiguration.PreferredSubnetId)
+	d.Set("remote_administration_endpoint", filesystem.WindowsConfiguration.RemoteAdministrationEndpoint)
+	d.Set("storage_capacity", filesystem.StorageCapacity)
+	d.Set("storage_type", filesystem.StorageType)
+
+	if err := d.Set("subnet_ids", aws.StringValueSlice(filesystem.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.FsxKeyValueTags(filesystem.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("throughput_capacity", filesystem.WindowsConfiguration.ThroughputCapacity)
+	d.Set("vpc_id", filesystem.VpcId)
+
+	return nil
+}
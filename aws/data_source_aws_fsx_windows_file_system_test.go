@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAWSFsxWindowsFileSystem_basic(t *testing.T) {
+	dataSourceName := "data.aws_fsx_windows_file_system.test"
+	resourceName := "aws_fsx_windows_file_system.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(fsx.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFsxWindowsFileSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsFsxWindowsFileSystemConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "aliases.#", resourceName, "aliases.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "automatic_backup_retention_days", resourceName, "automatic_backup_retention_days"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "deployment_type", resourceName, "deployment_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "dns_name", resourceName, "dns_name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "kms_key_id", resourceName, "kms_key_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "network_interface_ids.#", resourceName, "network_interface_ids.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owner_id", resourceName, "owner_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "preferred_file_server_ip", resourceName, "preferred_file_server_ip"),
+					// The only subnet of a single-AZ file system is reported as its preferred subnet.
+					resource.TestCheckResourceAttrPair(dataSourceName, "preferred_subnet_id", resourceName, "subnet_ids.0"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "remote_administration_endpoint", resourceName, "remote_administration_endpoint"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "storage_capacity", resourceName, "storage_capacity"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "storage_type", resourceName, "storage_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "subnet_ids.#", resourceName, "subnet_ids.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "tags.%", resourceName, "tags.%"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "throughput_capacity", resourceName, "throughput_capacity"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_id", resourceName, "vpc_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsFsxWindowsFileSystemConfig() string {
+	return composeConfig(testAccAwsFsxWindowsFileSystemConfigTags1("key1", "value1"), `
+data "aws_fsx_windows_file_system" "test" {
+  id = aws_fsx_windows_file_system.test.id
+}
+`)
+}
@@ -0,0 +1,170 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func dataSourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsGlobalAcceleratorEndpointGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"endpoint_configuration": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_ip_preservation_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"endpoint_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"endpoint_group_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"health_check_interval_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"health_check_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_check_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"health_check_protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"listener_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"port_override": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"listener_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"threshold_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"traffic_dial_percentage": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsGlobalAcceleratorEndpointGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+	listenerArn := d.Get("listener_arn").(string)
+	region := d.Get("endpoint_group_region").(string)
+
+	endpointGroups, err := finder.EndpointGroupsByListenerARN(conn, listenerArn)
+
+	if err != nil {
+		return fmt.Errorf("error listing Global Accelerator endpoint groups for listener (%s): %w", listenerArn, err)
+	}
+
+	endpointGroups = dataSourceAwsGlobalAcceleratorEndpointGroupsFilter(endpointGroups, region)
+
+	if len(endpointGroups) == 0 {
+		return fmt.Errorf("no Global Accelerator endpoint group matched; change your search criteria and try again")
+	}
+
+	if len(endpointGroups) > 1 {
+		return fmt.Errorf("%d Global Accelerator endpoint groups matched; use additional constraints, e.g. endpoint_group_region, to reduce matches to a single endpoint group", len(endpointGroups))
+	}
+
+	endpointGroupArn := aws.StringValue(endpointGroups[0].EndpointGroupArn)
+
+	endpointGroup, err := finder.EndpointGroupByARN(conn, endpointGroupArn)
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator endpoint group (%s): %w", endpointGroupArn, err)
+	}
+
+	if endpointGroup == nil {
+		return fmt.Errorf("error reading Global Accelerator endpoint group (%s): not found", endpointGroupArn)
+	}
+
+	d.SetId(endpointGroupArn)
+	d.Set("arn", endpointGroup.EndpointGroupArn)
+	if err := d.Set("endpoint_configuration", flattenGlobalAcceleratorEndpointDescriptions(endpointGroup.EndpointDescriptions)); err != nil {
+		return fmt.Errorf("error setting endpoint_configuration: %w", err)
+	}
+	d.Set("endpoint_group_region", endpointGroup.EndpointGroupRegion)
+	d.Set("health_check_interval_seconds", endpointGroup.HealthCheckIntervalSeconds)
+	d.Set("health_check_path", endpointGroup.HealthCheckPath)
+	d.Set("health_check_port", endpointGroup.HealthCheckPort)
+	d.Set("health_check_protocol", endpointGroup.HealthCheckProtocol)
+	d.Set("listener_arn", listenerArn)
+	if err := d.Set("port_override", flattenGlobalAcceleratorPortOverrides(endpointGroup.PortOverrides)); err != nil {
+		return fmt.Errorf("error setting port_override: %w", err)
+	}
+	d.Set("threshold_count", endpointGroup.ThresholdCount)
+	d.Set("traffic_dial_percentage", endpointGroup.TrafficDialPercentage)
+
+	return nil
+}
+
+// dataSourceAwsGlobalAcceleratorEndpointGroupsFilter returns the endpoint groups in the
+// specified region, or all endpoint groups if no region is specified.
+func dataSourceAwsGlobalAcceleratorEndpointGroupsFilter(endpointGroups []*globalaccelerator.EndpointGroup, region string) []*globalaccelerator.EndpointGroup {
+	var filtered []*globalaccelerator.EndpointGroup
+
+	for _, endpointGroup := range endpointGroups {
+		if endpointGroup == nil {
+			continue
+		}
+
+		if region != "" && aws.StringValue(endpointGroup.EndpointGroupRegion) != region {
+			continue
+		}
+
+		filtered = append(filtered, endpointGroup)
+	}
+
+	return filtered
+}
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceAwsGlobalAcceleratorEndpointGroupsFilter(t *testing.T) {
+	usEast1 := &globalaccelerator.EndpointGroup{
+		EndpointGroupArn:    aws.String("arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz/endpoint-group/098765zyxwvu"),
+		EndpointGroupRegion: aws.String("us-east-1"),
+	}
+	usWest2 := &globalaccelerator.EndpointGroup{
+		EndpointGroupArn:    aws.String("arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz/endpoint-group/123456abcdef"),
+		EndpointGroupRegion: aws.String("us-west-2"),
+	}
+
+	testCases := []struct {
+		Name           string
+		EndpointGroups []*globalaccelerator.EndpointGroup
+		Region         string
+		Expected       int
+	}{
+		{
+			Name:     "no endpoint groups",
+			Expected: 0,
+		},
+		{
+			Name:           "no region",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{usEast1, nil, usWest2},
+			Expected:       2,
+		},
+		{
+			Name:           "matching region",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{usEast1, usWest2},
+			Region:         "us-west-2",
+			Expected:       1,
+		},
+		{
+			Name:           "no matching region",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{usEast1, usWest2},
+			Region:         "eu-west-1",
+			Expected:       0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := dataSourceAwsGlobalAcceleratorEndpointGroupsFilter(testCase.EndpointGroups, testCase.Region)
+
+			if len(got) != testCase.Expected {
+				t.Errorf("got %d endpoint groups, expected %d", len(got), testCase.Expected)
+			}
+
+			for _, endpointGroup := range got {
+				if testCase.Region != "" && aws.StringValue(endpointGroup.EndpointGroupRegion) != testCase.Region {
+					t.Errorf("got endpoint group in region %s, expected %s", aws.StringValue(endpointGroup.EndpointGroupRegion), testCase.Region)
+				}
+			}
+		})
+	}
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroupDataSource_basic(t *testing.T) {
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	dataSourceName := "data.aws_globalaccelerator_endpoint_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "endpoint_configuration.#", resourceName, "endpoint_configuration.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "endpoint_group_region", resourceName, "endpoint_group_region"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "health_check_interval_seconds", resourceName, "health_check_interval_seconds"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "health_check_path", resourceName, "health_check_path"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "health_check_port", resourceName, "health_check_port"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "health_check_protocol", resourceName, "health_check_protocol"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "listener_arn", resourceName, "listener_arn"),
+					resource.TestCheckResourceAttr(dataSourceName, "port_override.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "port_override.*", map[string]string{
+						"endpoint_port": "8081",
+						"listener_port": "81",
+					}),
+					resource.TestCheckResourceAttrPair(dataSourceName, "threshold_count", resourceName, "threshold_count"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "traffic_dial_percentage", resourceName, "traffic_dial_percentage"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGlobalAcceleratorEndpointGroupDataSourceConfig(rName string) string {
+	return composeConfig(testAccGlobalAcceleratorEndpointGroupConfigPortOverrides(rName), `
+data "aws_region" "current" {}
+
+data "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn          = aws_globalaccelerator_endpoint_group.test.listener_arn
+  endpoint_group_region = data.aws_region.current.name
+}
+`)
+}
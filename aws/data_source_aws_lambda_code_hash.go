@@ -0,0 +1,195 @@
+package aws
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// lambdaDeterministicZipModified is the modification time of all entries in
+// archives created by lambdaDeterministicZip, the earliest time a zip file supports.
+var lambdaDeterministicZipModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func dataSourceAwsLambdaCodeHash() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsLambdaCodeHashRead,
+
+		Schema: map[string]*schema.Schema{
+			"filename": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"filename", "source_dir"},
+			},
+			"source_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"filename", "source_dir"},
+				RequiredWith: []string{"output_path"},
+			},
+			"output_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"source_dir"},
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsLambdaCodeHashRead(d *schema.ResourceData, meta interface{}) error {
+	var content []byte
+	var id string
+
+	if v, ok := d.GetOk("filename"); ok {
+		var err error
+		content, err = loadFileContent(v.(string))
+
+		if err != nil {
+			return fmt.Errorf("error reading Lambda code file (%s): %w", v.(string), err)
+		}
+
+		id = v.(string)
+	} else {
+		v := d.Get("source_dir").(string)
+
+		sourceDir, err := homedir.Expand(v)
+
+		if err != nil {
+			return fmt.Errorf("error expanding Lambda code source directory (%s): %w", v, err)
+		}
+
+		content, err = lambdaDeterministicZip(sourceDir)
+
+		if err != nil {
+			return fmt.Errorf("error archiving Lambda code source directory (%s): %w", v, err)
+		}
+
+		// Write the archive that was hashed so that it can be used as the
+		// filename of an aws_lambda_function.
+		o := d.Get("output_path").(string)
+
+		outputPath, err := homedir.Expand(o)
+
+		if err != nil {
+			return fmt.Errorf("error expanding Lambda code output path (%s): %w", o, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return fmt.Errorf("error creating Lambda code output directory (%s): %w", o, err)
+		}
+
+		if err := ioutil.WriteFile(outputPath, content, 0644); err != nil {
+			return fmt.Errorf("error writing Lambda code archive (%s): %w", o, err)
+		}
+
+		id = v
+	}
+
+	hash := lambdaCodeSha256(content)
+
+	d.SetId(fmt.Sprintf("%s:%s", id, hash))
+	d.Set("hash", hash)
+	d.Set("size", len(content))
+
+	return nil
+}
+
+// lambdaCodeSha256 returns the base64-encoded SHA256 hash of a deployment package,
+// matching the CodeSha256 reported by Lambda for Zip package types.
+func lambdaCodeSha256(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// lambdaDeterministicZip returns a zip archive of the regular files in the specified
+// directory. The archive only depends on the relative paths and contents of the
+// files: entries are sorted, use forward slashes, and have a fixed modification time
+// and permissions, so the same directory produces identical bytes on every platform.
+func lambdaDeterministicZip(sourceDir string) ([]byte, error) {
+	var paths []string
+
+	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string, len(paths))
+	names := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(sourceDir, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		name := filepath.ToSlash(rel)
+		entries[name] = path
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, name := range names {
+		content, err := ioutil.ReadFile(entries[name])
+
+		if err != nil {
+			return nil, err
+		}
+
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: lambdaDeterministicZipModified,
+		}
+		header.SetMode(0755)
+
+		f, err := w.CreateHeader(header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := f.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
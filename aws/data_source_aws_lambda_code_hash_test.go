@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLambdaCodeSha256(t *testing.T) {
+	content, err := ioutil.ReadFile("test-fixtures/lambdatest.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, expected := lambdaCodeSha256(content), "Ux/n9CP8l+7Ht0tICw0QPs0yLdC1b+1nJ9K5MZR9ENw="; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
+
+func TestLambdaDeterministicZip(t *testing.T) {
+	sourceDir, err := testAccLambdaCodeHashSourceDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sourceDir)
+
+	first, err := lambdaDeterministicZip(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modification times and permissions must not affect the archive.
+	modified := time.Now().Add(-24 * time.Hour)
+	if err := os.Chtimes(filepath.Join(sourceDir, "index.js"), modified, modified); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(sourceDir, "index.js"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := lambdaDeterministicZip(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Error("expected identical archives")
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+
+		if got, expected := f.Mode().Perm(), os.FileMode(0755); got != expected {
+			t.Errorf("%s: got mode %s, expected %s", f.Name, got, expected)
+		}
+
+		if !f.Modified.Equal(lambdaDeterministicZipModified) {
+			t.Errorf("%s: got modification time %s, expected %s", f.Name, f.Modified, lambdaDeterministicZipModified)
+		}
+	}
+
+	if expected := []string{"index.js", "lib/a.js", "lib/b.js"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got entries %v, expected %v", names, expected)
+	}
+}
+
+func TestAccDataSourceAWSLambdaCodeHash_filename(t *testing.T) {
+	dataSourceName := "data.aws_lambda_code_hash.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAWSLambdaCodeHashConfigFilename("test-fixtures/lambdatest.zip"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "hash", "Ux/n9CP8l+7Ht0tICw0QPs0yLdC1b+1nJ9K5MZR9ENw="),
+					resource.TestCheckResourceAttr(dataSourceName, "size", "342"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAWSLambdaCodeHash_sourceDir(t *testing.T) {
+	dataSourceName := "data.aws_lambda_code_hash.test"
+
+	sourceDir, err := testAccLambdaCodeHashSourceDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sourceDir)
+
+	content, err := lambdaDeterministicZip(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir, err := ioutil.TempDir("", "tf-acc-lambda-code-hash-output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outputDir)
+
+	outputPath := filepath.Join(outputDir, "lambda", "function.zip")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAWSLambdaCodeHashConfigSourceDir(sourceDir, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "hash", lambdaCodeSha256(content)),
+					resource.TestCheckResourceAttr(dataSourceName, "size", strconv.Itoa(len(content))),
+					resource.TestCheckResourceAttr(dataSourceName, "output_path", outputPath),
+					testAccCheckLambdaCodeHashOutputPath(outputPath, content),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLambdaCodeHashOutputPath(outputPath string, expected []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		content, err := ioutil.ReadFile(outputPath)
+
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(content, expected) {
+			return fmt.Errorf("Lambda code archive (%s) does not match the hashed archive", outputPath)
+		}
+
+		return nil
+	}
+}
+
+// testAccLambdaCodeHashSourceDir creates a temporary directory of Lambda source files.
+// The caller is responsible for removing it.
+func testAccLambdaCodeHashSourceDir() (string, error) {
+	sourceDir, err := ioutil.TempDir("", "tf-acc-lambda-code-hash")
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string]string{
+		"index.js":                   "exports.handler = require('./lib/a').handler;\n",
+		filepath.Join("lib", "a.js"): "exports.handler = async () => require('./b').value;\n",
+		filepath.Join("lib", "b.js"): "exports.value = 42;\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(sourceDir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	// Empty directories are not archived.
+	if err := os.MkdirAll(filepath.Join(sourceDir, "empty"), 0755); err != nil {
+		return "", err
+	}
+
+	return sourceDir, nil
+}
+
+func testAccDataSourceAWSLambdaCodeHashConfigFilename(filename string) string {
+	return fmt.Sprintf(`
+data "aws_lambda_code_hash" "test" {
+  filename = %[1]q
+}
+`, filename)
+}
+
+func testAccDataSourceAWSLambdaCodeHashConfigSourceDir(sourceDir, outputPath string) string {
+	return fmt.Sprintf(`
+data "aws_lambda_code_hash" "test" {
+  source_dir  = %[1]q
+  output_path = %[2]q
+}
+`, sourceDir, outputPath)
+}
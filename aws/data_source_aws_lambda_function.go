@@ -124,6 +124,10 @@ func dataSourceAwsLambdaFunction() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"qualified_code_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"invoke_arn": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -182,6 +186,22 @@ func dataSourceAwsLambdaFunction() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"image_digest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"package_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resolved_image_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -226,15 +246,7 @@ func dataSourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) e
 
 	d.Set("arn", unqualifiedARN)
 
-	deadLetterConfig := []interface{}{}
-	if function.DeadLetterConfig != nil {
-		deadLetterConfig = []interface{}{
-			map[string]interface{}{
-				"target_arn": aws.StringValue(function.DeadLetterConfig.TargetArn),
-			},
-		}
-	}
-	if err := d.Set("dead_letter_config", deadLetterConfig); err != nil {
+	if err := d.Set("dead_letter_config", flattenLambdaDeadLetterConfig(function.DeadLetterConfig)); err != nil {
 		return fmt.Errorf("error setting dead_letter_config: %s", err)
 	}
 
@@ -254,7 +266,10 @@ func dataSourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.Set("memory_size", function.MemorySize)
+	d.Set("package_type", function.PackageType)
 	d.Set("qualified_arn", qualifiedARN)
+	// The configuration returned for a qualifier is that of the qualified version.
+	d.Set("qualified_code_sha256", function.CodeSha256)
 
 	// Add Signing Profile Version ARN
 	if err := d.Set("signing_profile_version_arn", function.SigningProfileVersionArn); err != nil {
@@ -296,6 +311,17 @@ func dataSourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("timeout", function.Timeout)
 	d.Set("version", function.Version)
 
+	// The code returned for a qualifier is that of the qualified version, so the
+	// resolved image can be used to pin an alias to the digest it runs.
+	var imageURI, resolvedImageURI string
+	if aws.StringValue(function.PackageType) == lambda.PackageTypeImage && output.Code != nil {
+		imageURI = aws.StringValue(output.Code.ImageUri)
+		resolvedImageURI = aws.StringValue(output.Code.ResolvedImageUri)
+	}
+	d.Set("image_uri", imageURI)
+	d.Set("resolved_image_uri", resolvedImageURI)
+	d.Set("image_digest", lambdaFunctionImageDigest(resolvedImageURI))
+
 	if err := d.Set("vpc_config", flattenLambdaVpcConfigResponse(function.VpcConfig)); err != nil {
 		return fmt.Errorf("error setting vpc_config: %s", err)
 	}
@@ -334,3 +360,13 @@ func dataSourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) e
 
 	return nil
 }
+
+// lambdaFunctionImageDigest returns the digest of a resolved image URI,
+// e.g. sha256:... from 123456789012.dkr.ecr.us-west-2.amazonaws.com/repository@sha256:...
+func lambdaFunctionImageDigest(resolvedImageURI string) string {
+	if i := strings.LastIndex(resolvedImageURI, "@"); i >= 0 {
+		return resolvedImageURI[i+1:]
+	}
+
+	return ""
+}
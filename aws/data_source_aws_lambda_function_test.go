@@ -2,6 +2,8 @@ package aws
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -64,6 +66,7 @@ func TestAccDataSourceAWSLambdaFunction_version(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceName, "qualified_arn", resourceName, "qualified_arn"),
 					resource.TestCheckResourceAttr(dataSourceName, "qualifier", "1"),
 					resource.TestCheckResourceAttr(dataSourceName, "version", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "qualified_code_sha256", resourceName, "qualified_code_sha256"),
 				),
 			},
 		},
@@ -180,6 +183,57 @@ func TestAccDataSourceAWSLambdaFunction_fileSystemConfig(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceAWSLambdaFunction_image(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_lambda_function.test"
+	resourceName := "aws_lambda_function.test"
+	imageID := os.Getenv("AWS_LAMBDA_IMAGE_V1_ID")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccLambdaImagePreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAWSLambdaFunctionConfigImage(rName, imageID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "qualifier", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "package_type", "Image"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "image_uri", resourceName, "image_uri"),
+					resource.TestMatchResourceAttr(dataSourceName, "image_digest", regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "resolved_image_uri", regexp.MustCompile(`@sha256:[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestLambdaFunctionImageDigest(t *testing.T) {
+	testCases := []struct {
+		resolvedImageURI string
+		expected         string
+	}{
+		{
+			resolvedImageURI: "123456789012.dkr.ecr.us-west-2.amazonaws.com/repository@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expected:         "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		{
+			resolvedImageURI: "123456789012.dkr.ecr.us-west-2.amazonaws.com/repository:latest",
+		},
+		{
+			resolvedImageURI: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		if got := lambdaFunctionImageDigest(testCase.resolvedImageURI); got != testCase.expected {
+			t.Errorf("lambdaFunctionImageDigest(%q) = %q, expected %q", testCase.resolvedImageURI, got, testCase.expected)
+		}
+	}
+}
+
 func testAccDataSourceAWSLambdaFunctionConfigBase(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_iam_role" "lambda" {
@@ -273,6 +327,23 @@ data "aws_lambda_function" "test" {
 `, rName)
 }
 
+func testAccDataSourceAWSLambdaFunctionConfigImage(rName, imageID string) string {
+	return testAccDataSourceAWSLambdaFunctionConfigBase(rName) + fmt.Sprintf(`
+resource "aws_lambda_function" "test" {
+  function_name = %[1]q
+  image_uri     = %[2]q
+  package_type  = "Image"
+  publish       = true
+  role          = aws_iam_role.lambda.arn
+}
+
+data "aws_lambda_function" "test" {
+  function_name = aws_lambda_function.test.function_name
+  qualifier     = aws_lambda_function.test.version
+}
+`, rName, imageID)
+}
+
 func testAccDataSourceAWSLambdaFunctionConfigAlias(rName string) string {
 	return testAccDataSourceAWSLambdaFunctionConfigBase(rName) + fmt.Sprintf(`
 resource "aws_lambda_function" "test" {
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -28,6 +30,72 @@ func describeFsxFileSystem(conn *fsx.FSx, id string) (*fsx.FileSystem, error) {
 	return filesystem, err
 }
 
+func describeFsxBackup(conn *fsx.FSx, id string) (*fsx.Backup, error) {
+	output, err := conn.DescribeBackups(&fsx.DescribeBackupsInput{
+		BackupIds: []*string{aws.String(id)},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range output.Backups {
+		if aws.StringValue(backup.BackupId) == id {
+			return backup, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func describeFsxFileSystemAliases(conn *fsx.FSx, id string) ([]*fsx.Alias, error) {
+	input := &fsx.DescribeFileSystemAliasesInput{
+		FileSystemId: aws.String(id),
+	}
+	var aliases []*fsx.Alias
+
+	for {
+		output, err := conn.DescribeFileSystemAliases(input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		aliases = append(aliases, output.Aliases...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return aliases, nil
+}
+
+func refreshFsxFileSystemAliasLifecycle(conn *fsx.FSx, id, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		aliases, err := describeFsxFileSystemAliases(conn, id)
+
+		if isAWSErr(err, fsx.ErrCodeFileSystemNotFound, "") {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, alias := range aliases {
+			// Aliases are stored in lowercase.
+			if alias != nil && strings.EqualFold(aws.StringValue(alias.Name), name) {
+				return alias, aws.StringValue(alias.Lifecycle), nil
+			}
+		}
+
+		return nil, "", nil
+	}
+}
+
 func refreshFsxFileSystemLifecycle(conn *fsx.FSx, id string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		filesystem, err := describeFsxFileSystem(conn, id)
@@ -64,20 +132,60 @@ func refreshFsxFileSystemAdministrativeActionsStatusFileSystemUpdate(conn *fsx.F
 			return nil, "", nil
 		}
 
-		for _, administrativeAction := range filesystem.AdministrativeActions {
-			if administrativeAction == nil {
-				continue
-			}
-
-			if aws.StringValue(administrativeAction.AdministrativeActionType) == fsx.AdministrativeActionTypeFileSystemUpdate {
-				return filesystem, aws.StringValue(administrativeAction.Status), nil
-			}
+		if administrativeAction := fsxLatestAdministrativeAction(filesystem.AdministrativeActions, fsx.AdministrativeActionTypeFileSystemUpdate); administrativeAction != nil {
+			return filesystem, aws.StringValue(administrativeAction.Status), nil
 		}
 
 		return filesystem, fsx.StatusCompleted, nil
 	}
 }
 
+// fsxLatestAdministrativeAction returns the most recently requested administrative action of the given type.
+// Earlier actions of the same type may still be listed after they complete.
+func fsxLatestAdministrativeAction(actions []*fsx.AdministrativeAction, actionType string) *fsx.AdministrativeAction {
+	var latest *fsx.AdministrativeAction
+
+	for _, action := range actions {
+		if action == nil || aws.StringValue(action.AdministrativeActionType) != actionType {
+			continue
+		}
+
+		if latest == nil || aws.TimeValue(action.RequestTime).After(aws.TimeValue(latest.RequestTime)) {
+			latest = action
+		}
+	}
+
+	return latest
+}
+
+func waitForFsxFileSystemAliasAvailable(conn *fsx.FSx, id, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{fsx.AliasLifecycleCreating},
+		Target:  []string{fsx.AliasLifecycleAvailable},
+		Refresh: refreshFsxFileSystemAliasLifecycle(conn, id, name),
+		Timeout: timeout,
+		Delay:   30 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+
+	return err
+}
+
+func waitForFsxFileSystemAliasDeletion(conn *fsx.FSx, id, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{fsx.AliasLifecycleAvailable, fsx.AliasLifecycleDeleting},
+		Target:  []string{},
+		Refresh: refreshFsxFileSystemAliasLifecycle(conn, id, name),
+		Timeout: timeout,
+		Delay:   30 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+
+	return err
+}
+
 func waitForFsxFileSystemCreation(conn *fsx.FSx, id string, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{fsx.FileSystemLifecycleCreating},
@@ -94,7 +202,7 @@ func waitForFsxFileSystemCreation(conn *fsx.FSx, id string, timeout time.Duratio
 
 func waitForFsxFileSystemDeletion(conn *fsx.FSx, id string, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
-		Pending: []string{fsx.FileSystemLifecycleAvailable, fsx.FileSystemLifecycleDeleting},
+		Pending: []string{fsx.FileSystemLifecycleAvailable, fsx.FileSystemLifecycleDeleting, fsx.FileSystemLifecycleMisconfigured},
 		Target:  []string{},
 		Refresh: refreshFsxFileSystemLifecycle(conn, id),
 		Timeout: timeout,
@@ -135,7 +243,13 @@ func waitForFsxFileSystemUpdateAdministrativeActionsStatusFileSystemUpdate(conn
 		Delay:   30 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	outputRaw, err := stateConf.WaitForState()
+
+	if filesystem, ok := outputRaw.(*fsx.FileSystem); ok && err != nil {
+		if administrativeAction := fsxLatestAdministrativeAction(filesystem.AdministrativeActions, fsx.AdministrativeActionTypeFileSystemUpdate); administrativeAction != nil && administrativeAction.FailureDetails != nil {
+			return fmt.Errorf("%s: %s", aws.StringValue(administrativeAction.Status), aws.StringValue(administrativeAction.FailureDetails.Message))
+		}
+	}
 
 	return err
 }
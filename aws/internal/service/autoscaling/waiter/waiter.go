@@ -1,10 +1,13 @@
 package waiter
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/tfresource"
 )
 
 const (
@@ -42,3 +45,62 @@ func InstanceRefreshCancelled(conn *autoscaling.AutoScaling, asgName, instanceRe
 
 	return nil, err
 }
+
+// InstanceRefreshFinished waits for an Instance Refresh to reach an end state.
+// Reaching Cancelled or Failed returns the Instance Refresh along with an error.
+func InstanceRefreshFinished(conn *autoscaling.AutoScaling, asgName, instanceRefreshId string, timeout time.Duration) (*autoscaling.InstanceRefresh, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			autoscaling.InstanceRefreshStatusPending,
+			autoscaling.InstanceRefreshStatusInProgress,
+			autoscaling.InstanceRefreshStatusCancelling,
+		},
+		Target: []string{
+			autoscaling.InstanceRefreshStatusSuccessful,
+		},
+		Refresh: InstanceRefreshStatus(conn, asgName, instanceRefreshId),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if v, ok := outputRaw.(*autoscaling.InstanceRefresh); ok {
+		return v, err
+	}
+
+	return nil, err
+}
+
+// InstanceRefreshNotFailed watches a newly started Instance Refresh for the specified duration
+// and returns an error if it fails in that time, e.g. because its launch template is invalid.
+// An Instance Refresh that is still Pending or InProgress when the duration elapses is not an error.
+func InstanceRefreshNotFailed(conn *autoscaling.AutoScaling, asgName, instanceRefreshId string, timeout time.Duration) (*autoscaling.InstanceRefresh, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			autoscaling.InstanceRefreshStatusPending,
+			autoscaling.InstanceRefreshStatusInProgress,
+		},
+		Target: []string{
+			autoscaling.InstanceRefreshStatusCancelling,
+			autoscaling.InstanceRefreshStatusCancelled,
+			autoscaling.InstanceRefreshStatusFailed,
+			autoscaling.InstanceRefreshStatusSuccessful,
+		},
+		Refresh: InstanceRefreshStatus(conn, asgName, instanceRefreshId),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if tfresource.TimedOut(err) {
+		err = nil
+	}
+
+	instanceRefresh, _ := outputRaw.(*autoscaling.InstanceRefresh)
+
+	if err == nil && instanceRefresh != nil && aws.StringValue(instanceRefresh.Status) == autoscaling.InstanceRefreshStatusFailed {
+		err = fmt.Errorf("Instance Refresh failed: %s", aws.StringValue(instanceRefresh.StatusReason))
+	}
+
+	return instanceRefresh, err
+}
@@ -166,3 +166,76 @@ func ManagedPrefixListByID(conn *ec2.EC2, id string) (*ec2.ManagedPrefixList, er
 
 	return output.PrefixLists[0], nil
 }
+
+// LaunchTemplateByID returns the launch template corresponding to the specified identifier.
+// Returns nil and potentially an error if no launch template is found.
+func LaunchTemplateByID(conn *ec2.EC2, id string) (*ec2.LaunchTemplate, error) {
+	input := &ec2.DescribeLaunchTemplatesInput{
+		LaunchTemplateIds: aws.StringSlice([]string{id}),
+	}
+
+	output, err := conn.DescribeLaunchTemplates(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.LaunchTemplates) == 0 {
+		return nil, nil
+	}
+
+	return output.LaunchTemplates[0], nil
+}
+
+// LaunchTemplateByName returns the launch template corresponding to the specified name.
+// Returns nil and potentially an error if no launch template is found.
+func LaunchTemplateByName(conn *ec2.EC2, name string) (*ec2.LaunchTemplate, error) {
+	input := &ec2.DescribeLaunchTemplatesInput{
+		LaunchTemplateNames: aws.StringSlice([]string{name}),
+	}
+
+	output, err := conn.DescribeLaunchTemplates(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.LaunchTemplates) == 0 {
+		return nil, nil
+	}
+
+	return output.LaunchTemplates[0], nil
+}
+
+// InstanceTypeOfferingsByAvailabilityZones returns the offerings of the specified
+// instance types in the specified Availability Zones.
+func InstanceTypeOfferingsByAvailabilityZones(conn *ec2.EC2, instanceTypes, availabilityZones []string) ([]*ec2.InstanceTypeOffering, error) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-type"),
+				Values: aws.StringSlice(instanceTypes),
+			},
+			{
+				Name:   aws.String("location"),
+				Values: aws.StringSlice(availabilityZones),
+			},
+		},
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+	}
+	var offerings []*ec2.InstanceTypeOffering
+
+	err := conn.DescribeInstanceTypeOfferingsPages(input, func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		offerings = append(offerings, page.InstanceTypeOfferings...)
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return offerings, nil
+}
@@ -18,3 +18,215 @@ func EndpointGroupByARN(conn *globalaccelerator.GlobalAccelerator, arn string) (
 
 	return output.EndpointGroup, nil
 }
+
+// EndpointGroupsByListenerARN returns all endpoint groups for the specified listener ARN.
+func EndpointGroupsByListenerARN(conn *globalaccelerator.GlobalAccelerator, listenerArn string) ([]*globalaccelerator.EndpointGroup, error) {
+	input := &globalaccelerator.ListEndpointGroupsInput{
+		ListenerArn: aws.String(listenerArn),
+	}
+	var endpointGroups []*globalaccelerator.EndpointGroup
+
+	for {
+		output, err := conn.ListEndpointGroups(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		endpointGroups = append(endpointGroups, output.EndpointGroups...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return endpointGroups, nil
+}
+
+// Accelerators returns all standard accelerators.
+func Accelerators(conn *globalaccelerator.GlobalAccelerator) ([]*globalaccelerator.Accelerator, error) {
+	input := &globalaccelerator.ListAcceleratorsInput{}
+	var accelerators []*globalaccelerator.Accelerator
+
+	for {
+		output, err := conn.ListAccelerators(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		accelerators = append(accelerators, output.Accelerators...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return accelerators, nil
+}
+
+// ListenersByAcceleratorARN returns all listeners for the specified accelerator ARN.
+func ListenersByAcceleratorARN(conn *globalaccelerator.GlobalAccelerator, acceleratorArn string) ([]*globalaccelerator.Listener, error) {
+	input := &globalaccelerator.ListListenersInput{
+		AcceleratorArn: aws.String(acceleratorArn),
+	}
+	var listeners []*globalaccelerator.Listener
+
+	for {
+		output, err := conn.ListListeners(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		listeners = append(listeners, output.Listeners...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return listeners, nil
+}
+
+// CustomRoutingAcceleratorByARN returns the custom routing accelerator corresponding to the specified ARN.
+func CustomRoutingAcceleratorByARN(conn *globalaccelerator.GlobalAccelerator, arn string) (*globalaccelerator.CustomRoutingAccelerator, error) {
+	input := &globalaccelerator.DescribeCustomRoutingAcceleratorInput{
+		AcceleratorArn: aws.String(arn),
+	}
+
+	output, err := conn.DescribeCustomRoutingAccelerator(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Accelerator, nil
+}
+
+// CustomRoutingListenerByARN returns the custom routing listener corresponding to the specified ARN.
+func CustomRoutingListenerByARN(conn *globalaccelerator.GlobalAccelerator, arn string) (*globalaccelerator.CustomRoutingListener, error) {
+	input := &globalaccelerator.DescribeCustomRoutingListenerInput{
+		ListenerArn: aws.String(arn),
+	}
+
+	output, err := conn.DescribeCustomRoutingListener(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Listener, nil
+}
+
+// CustomRoutingEndpointGroupByARN returns the custom routing endpoint group corresponding to the specified ARN.
+func CustomRoutingEndpointGroupByARN(conn *globalaccelerator.GlobalAccelerator, arn string) (*globalaccelerator.CustomRoutingEndpointGroup, error) {
+	input := &globalaccelerator.DescribeCustomRoutingEndpointGroupInput{
+		EndpointGroupArn: aws.String(arn),
+	}
+
+	output, err := conn.DescribeCustomRoutingEndpointGroup(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.EndpointGroup, nil
+}
+
+// CustomRoutingAccelerators returns all custom routing accelerators.
+func CustomRoutingAccelerators(conn *globalaccelerator.GlobalAccelerator) ([]*globalaccelerator.CustomRoutingAccelerator, error) {
+	input := &globalaccelerator.ListCustomRoutingAcceleratorsInput{}
+	var accelerators []*globalaccelerator.CustomRoutingAccelerator
+
+	for {
+		output, err := conn.ListCustomRoutingAccelerators(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		accelerators = append(accelerators, output.Accelerators...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return accelerators, nil
+}
+
+// CustomRoutingListenersByAcceleratorARN returns all custom routing listeners for the specified accelerator ARN.
+func CustomRoutingListenersByAcceleratorARN(conn *globalaccelerator.GlobalAccelerator, acceleratorArn string) ([]*globalaccelerator.CustomRoutingListener, error) {
+	input := &globalaccelerator.ListCustomRoutingListenersInput{
+		AcceleratorArn: aws.String(acceleratorArn),
+	}
+	var listeners []*globalaccelerator.CustomRoutingListener
+
+	for {
+		output, err := conn.ListCustomRoutingListeners(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		listeners = append(listeners, output.Listeners...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return listeners, nil
+}
+
+// CustomRoutingEndpointGroupsByListenerARN returns all custom routing endpoint groups for the specified listener ARN.
+func CustomRoutingEndpointGroupsByListenerARN(conn *globalaccelerator.GlobalAccelerator, listenerArn string) ([]*globalaccelerator.CustomRoutingEndpointGroup, error) {
+	input := &globalaccelerator.ListCustomRoutingEndpointGroupsInput{
+		ListenerArn: aws.String(listenerArn),
+	}
+	var endpointGroups []*globalaccelerator.CustomRoutingEndpointGroup
+
+	for {
+		output, err := conn.ListCustomRoutingEndpointGroups(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			break
+		}
+
+		endpointGroups = append(endpointGroups, output.EndpointGroups...)
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return endpointGroups, nil
+}
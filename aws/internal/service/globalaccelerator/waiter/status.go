@@ -0,0 +1,95 @@
+package waiter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+// EndpointGroupHealthState fetches the endpoint group and its aggregated endpoint health state.
+func EndpointGroupHealthState(conn *globalaccelerator.GlobalAccelerator, arn string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		endpointGroup, err := finder.EndpointGroupByARN(conn, arn)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if endpointGroup == nil {
+			return nil, "", nil
+		}
+
+		return endpointGroup, EndpointsHealthState(endpointGroup.EndpointDescriptions), nil
+	}
+}
+
+// CustomRoutingAcceleratorStatus fetches the custom routing accelerator and its status.
+func CustomRoutingAcceleratorStatus(conn *globalaccelerator.GlobalAccelerator, arn string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		accelerator, err := finder.CustomRoutingAcceleratorByARN(conn, arn)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if accelerator == nil {
+			return nil, "", nil
+		}
+
+		return accelerator, aws.StringValue(accelerator.Status), nil
+	}
+}
+
+// EndpointsHealthState aggregates the health of the specified endpoints.
+// UNHEALTHY takes precedence over INITIAL, which takes precedence over HEALTHY.
+// Endpoints that do not report a health state, e.g. Elastic IP addresses and
+// Network Load Balancers without health checks, are treated as healthy.
+func EndpointsHealthState(endpoints []*globalaccelerator.EndpointDescription) string {
+	state := globalaccelerator.HealthStateHealthy
+
+	for _, endpoint := range endpoints {
+		if endpoint == nil {
+			continue
+		}
+
+		switch aws.StringValue(endpoint.HealthState) {
+		case globalaccelerator.HealthStateInitial:
+			if state == globalaccelerator.HealthStateHealthy {
+				state = globalaccelerator.HealthStateInitial
+			}
+		case globalaccelerator.HealthStateUnhealthy:
+			state = globalaccelerator.HealthStateUnhealthy
+		}
+	}
+
+	return state
+}
+
+// EndpointsNotHealthyReasons returns the health state and reason of each endpoint
+// that has not reported healthy, or an empty string if there are none.
+func EndpointsNotHealthyReasons(endpoints []*globalaccelerator.EndpointDescription) string {
+	var reasons []string
+
+	for _, endpoint := range endpoints {
+		if endpoint == nil {
+			continue
+		}
+
+		switch state := aws.StringValue(endpoint.HealthState); state {
+		case globalaccelerator.HealthStateInitial, globalaccelerator.HealthStateUnhealthy:
+			reason := fmt.Sprintf("%s (%s)", aws.StringValue(endpoint.EndpointId), state)
+
+			if v := aws.StringValue(endpoint.HealthReason); v != "" {
+				reason += ": " + v
+			}
+
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return strings.Join(reasons, ", ")
+}
@@ -0,0 +1,78 @@
+package waiter
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+)
+
+func TestEndpointsHealthState(t *testing.T) {
+	testCases := []struct {
+		name            string
+		endpoints       []*globalaccelerator.EndpointDescription
+		expectedState   string
+		expectedReasons string
+	}{
+		{
+			name:          "no endpoints",
+			expectedState: globalaccelerator.HealthStateHealthy,
+		},
+		{
+			name: "all healthy",
+			endpoints: []*globalaccelerator.EndpointDescription{
+				{EndpointId: aws.String("i-1"), HealthState: aws.String(globalaccelerator.HealthStateHealthy)},
+				{EndpointId: aws.String("i-2"), HealthState: aws.String(globalaccelerator.HealthStateHealthy)},
+			},
+			expectedState: globalaccelerator.HealthStateHealthy,
+		},
+		{
+			name: "no health state reported",
+			endpoints: []*globalaccelerator.EndpointDescription{
+				{EndpointId: aws.String("eipalloc-1")},
+				{EndpointId: aws.String("i-1"), HealthState: aws.String(globalaccelerator.HealthStateHealthy)},
+			},
+			expectedState: globalaccelerator.HealthStateHealthy,
+		},
+		{
+			name: "initial",
+			endpoints: []*globalaccelerator.EndpointDescription{
+				{EndpointId: aws.String("i-1"), HealthState: aws.String(globalaccelerator.HealthStateHealthy)},
+				{EndpointId: aws.String("i-2"), HealthState: aws.String(globalaccelerator.HealthStateInitial)},
+			},
+			expectedState:   globalaccelerator.HealthStateInitial,
+			expectedReasons: "i-2 (INITIAL)",
+		},
+		{
+			name: "unhealthy",
+			endpoints: []*globalaccelerator.EndpointDescription{
+				{EndpointId: aws.String("i-1"), HealthState: aws.String(globalaccelerator.HealthStateUnhealthy), HealthReason: aws.String("Health checks failed")},
+				{EndpointId: aws.String("i-2"), HealthState: aws.String(globalaccelerator.HealthStateInitial)},
+				{EndpointId: aws.String("i-3"), HealthState: aws.String(globalaccelerator.HealthStateUnhealthy), HealthReason: aws.String("Connection refused")},
+			},
+			expectedState:   globalaccelerator.HealthStateUnhealthy,
+			expectedReasons: "i-1 (UNHEALTHY): Health checks failed, i-2 (INITIAL), i-3 (UNHEALTHY): Connection refused",
+		},
+		{
+			name: "unhealthy listed before initial",
+			endpoints: []*globalaccelerator.EndpointDescription{
+				{EndpointId: aws.String("i-1"), HealthState: aws.String(globalaccelerator.HealthStateUnhealthy)},
+				{EndpointId: aws.String("i-2"), HealthState: aws.String(globalaccelerator.HealthStateInitial)},
+			},
+			expectedState:   globalaccelerator.HealthStateUnhealthy,
+			expectedReasons: "i-1 (UNHEALTHY), i-2 (INITIAL)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := EndpointsHealthState(testCase.endpoints); got != testCase.expectedState {
+				t.Errorf("got state %s, expected %s", got, testCase.expectedState)
+			}
+
+			if got := EndpointsNotHealthyReasons(testCase.endpoints); got != testCase.expectedReasons {
+				t.Errorf("got reasons %q, expected %q", got, testCase.expectedReasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package waiter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/tfresource"
+)
+
+// EndpointGroupHealthy waits for all endpoints in an endpoint group to report healthy.
+// Unhealthy endpoints may still recover, e.g. while targets register, so they are
+// only reported, with their health reasons, once the timeout is reached.
+func EndpointGroupHealthy(conn *globalaccelerator.GlobalAccelerator, arn string, timeout time.Duration) (*globalaccelerator.EndpointGroup, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			globalaccelerator.HealthStateInitial,
+			globalaccelerator.HealthStateUnhealthy,
+		},
+		Target:  []string{globalaccelerator.HealthStateHealthy},
+		Refresh: EndpointGroupHealthState(conn, arn),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if tfresource.TimedOut(err) {
+		if endpointGroup, findErr := finder.EndpointGroupByARN(conn, arn); findErr == nil && endpointGroup != nil {
+			if reasons := EndpointsNotHealthyReasons(endpointGroup.EndpointDescriptions); reasons != "" {
+				return endpointGroup, fmt.Errorf("%w: endpoints not healthy: %s", err, reasons)
+			}
+		}
+	}
+
+	if v, ok := outputRaw.(*globalaccelerator.EndpointGroup); ok {
+		return v, err
+	}
+
+	return nil, err
+}
+
+// CustomRoutingAcceleratorDeployed waits for a custom routing accelerator to finish deploying.
+// Changes to the accelerator, its listeners and endpoint groups all put it back in progress.
+func CustomRoutingAcceleratorDeployed(conn *globalaccelerator.GlobalAccelerator, arn string, timeout time.Duration) (*globalaccelerator.CustomRoutingAccelerator, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{globalaccelerator.CustomRoutingAcceleratorStatusInProgress},
+		Target:  []string{globalaccelerator.CustomRoutingAcceleratorStatusDeployed},
+		Refresh: CustomRoutingAcceleratorStatus(conn, arn),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if v, ok := outputRaw.(*globalaccelerator.CustomRoutingAccelerator); ok {
+		return v, err
+	}
+
+	return nil, err
+}
@@ -0,0 +1,24 @@
+package finder
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/shield"
+)
+
+// ProtectionByResourceARN returns the Shield Advanced protection for the specified protected resource ARN.
+func ProtectionByResourceARN(conn *shield.Shield, resourceArn string) (*shield.Protection, error) {
+	input := &shield.DescribeProtectionInput{
+		ResourceArn: aws.String(resourceArn),
+	}
+
+	output, err := conn.DescribeProtection(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return output.Protection, nil
+}
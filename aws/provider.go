@@ -258,6 +258,8 @@ func Provider() *schema.Provider {
 			"aws_elasticache_replication_group":              dataSourceAwsElasticacheReplicationGroup(),
 			"aws_elb_hosted_zone_id":                         dataSourceAwsElbHostedZoneId(),
 			"aws_elb_service_account":                        dataSourceAwsElbServiceAccount(),
+			"aws_fsx_windows_file_system":                    dataSourceAwsFsxWindowsFileSystem(),
+			"aws_globalaccelerator_endpoint_group":           dataSourceAwsGlobalAcceleratorEndpointGroup(),
 			"aws_glue_script":                                dataSourceAwsGlueScript(),
 			"aws_guardduty_detector":                         dataSourceAwsGuarddutyDetector(),
 			"aws_iam_account_alias":                          dataSourceAwsIamAccountAlias(),
@@ -289,6 +291,7 @@ func Provider() *schema.Provider {
 			"aws_lakeformation_permissions":                  dataSourceAwsLakeFormationPermissions(),
 			"aws_lakeformation_resource":                     dataSourceAwsLakeFormationResource(),
 			"aws_lambda_alias":                               dataSourceAwsLambdaAlias(),
+			"aws_lambda_code_hash":                           dataSourceAwsLambdaCodeHash(),
 			"aws_lambda_code_signing_config":                 dataSourceAwsLambdaCodeSigningConfig(),
 			"aws_lambda_function":                            dataSourceAwsLambdaFunction(),
 			"aws_lambda_invocation":                          dataSourceAwsLambdaInvocation(),
@@ -464,6 +467,7 @@ func Provider() *schema.Provider {
 			"aws_athena_workgroup":                                    resourceAwsAthenaWorkgroup(),
 			"aws_autoscaling_attachment":                              resourceAwsAutoscalingAttachment(),
 			"aws_autoscaling_group":                                   resourceAwsAutoscalingGroup(),
+			"aws_autoscaling_group_tag":                               resourceAwsAutoscalingGroupTag(),
 			"aws_autoscaling_lifecycle_hook":                          resourceAwsAutoscalingLifecycleHook(),
 			"aws_autoscaling_notification":                            resourceAwsAutoscalingNotification(),
 			"aws_autoscaling_policy":                                  resourceAwsAutoscalingPolicy(),
@@ -673,7 +677,11 @@ func Provider() *schema.Provider {
 			"aws_glacier_vault":                                       resourceAwsGlacierVault(),
 			"aws_glacier_vault_lock":                                  resourceAwsGlacierVaultLock(),
 			"aws_globalaccelerator_accelerator":                       resourceAwsGlobalAcceleratorAccelerator(),
+			"aws_globalaccelerator_custom_routing_accelerator":        resourceAwsGlobalAcceleratorCustomRoutingAccelerator(),
+			"aws_globalaccelerator_custom_routing_endpoint_group":     resourceAwsGlobalAcceleratorCustomRoutingEndpointGroup(),
+			"aws_globalaccelerator_custom_routing_listener":           resourceAwsGlobalAcceleratorCustomRoutingListener(),
 			"aws_globalaccelerator_endpoint_group":                    resourceAwsGlobalAcceleratorEndpointGroup(),
+			"aws_globalaccelerator_endpoint_group_attachment":         resourceAwsGlobalAcceleratorEndpointGroupAttachment(),
 			"aws_globalaccelerator_listener":                          resourceAwsGlobalAcceleratorListener(),
 			"aws_glue_catalog_database":                               resourceAwsGlueCatalogDatabase(),
 			"aws_glue_catalog_table":                                  resourceAwsGlueCatalogTable(),
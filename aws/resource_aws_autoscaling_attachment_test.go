@@ -246,7 +246,7 @@ resource "aws_lb_target_group" "another_test" {
 
 resource "aws_autoscaling_group" "asg" {
   availability_zones        = data.aws_availability_zones.available.names
-  name                      = "asg-lb-assoc-terraform-test_%d"
+  name                      = "terraform-test-asg-lb-assoc-%d"
   max_size                  = 1
   min_size                  = 0
   desired_capacity          = 0
@@ -322,7 +322,7 @@ resource "aws_launch_configuration" "as_conf" {
 
 resource "aws_autoscaling_group" "asg" {
   availability_zones        = data.aws_availability_zones.available.names
-  name                      = "asg-lb-assoc-terraform-test_%d"
+  name                      = "terraform-test-asg-lb-assoc-%d"
   max_size                  = 1
   min_size                  = 0
   desired_capacity          = 0
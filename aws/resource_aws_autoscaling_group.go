@@ -3,16 +3,21 @@ package aws
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
@@ -26,10 +31,17 @@ import (
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/hashcode"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/autoscaling/waiter"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
 )
 
 const (
 	autoscalingTagResourceTypeAutoScalingGroup = `auto-scaling-group`
+
+	autoScalingGroupLaunchTemplateVersionDefault = "$Default"
+	autoScalingGroupLaunchTemplateVersionLatest  = "$Latest"
+
+	// Maximum amount of time to wait for DescribeAutoScalingGroups to reflect an update
+	autoscalingGroupUpdateConsistencyTimeout = 2 * time.Minute
 )
 
 func resourceAwsAutoscalingGroup() *schema.Resource {
@@ -39,10 +51,11 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 		Update: resourceAwsAutoscalingGroupUpdate,
 		Delete: resourceAwsAutoscalingGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAwsAutoscalingGroupImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -98,6 +111,11 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 				},
 			},
 
+			"launch_template_resolved_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"mixed_instances_policy": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -194,6 +212,29 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 													Type:     schema.TypeString,
 													Optional: true,
 												},
+												"launch_template_specification": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"launch_template_id": {
+																Type:     schema.TypeString,
+																Optional: true,
+																Computed: true,
+															},
+															"launch_template_name": {
+																Type:     schema.TypeString,
+																Optional: true,
+																Computed: true,
+															},
+															"version": {
+																Type:     schema.TypeString,
+																Optional: true,
+															},
+														},
+													},
+												},
 												"weighted_capacity": {
 													Type:         schema.TypeString,
 													Optional:     true,
@@ -215,9 +256,31 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			},
 
 			"desired_capacity": {
-				Type:     schema.TypeInt,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"initial_desired_capacity"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != "" && d.Get("ignore_desired_capacity_changes").(bool)
+				},
+			},
+
+			"ignore_desired_capacity_changes": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Computed: true,
+				Default:  false,
+			},
+
+			// initial_desired_capacity is only used when the group is created.
+			// Afterwards the desired capacity is managed outside of Terraform.
+			"initial_desired_capacity": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"desired_capacity"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
 			},
 
 			"min_elb_capacity": {
@@ -264,6 +327,12 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 				Computed: true,
 			},
 
+			"allow_az_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"availability_zones": {
 				Type:          schema.TypeSet,
 				Optional:      true,
@@ -300,22 +369,16 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			},
 
 			"wait_for_capacity_timeout": {
-				Type:     schema.TypeString,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "10m",
+				ValidateFunc: validateAutoScalingGroupWaitForCapacityTimeout,
+			},
+
+			"ignore_failed_scaling_activities": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "10m",
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					value := v.(string)
-					duration, err := time.ParseDuration(value)
-					if err != nil {
-						errors = append(errors, fmt.Errorf(
-							"%q cannot be parsed as a duration: %s", k, err))
-					}
-					if duration < 0 {
-						errors = append(errors, fmt.Errorf(
-							"%q must be greater than zero", k))
-					}
-					return
-				},
+				Default:  false,
 			},
 
 			"wait_for_elb_capacity": {
@@ -331,8 +394,23 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			},
 
 			"suspended_processes": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"paused"},
+			},
+
+			"paused": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"suspended_processes"},
+			},
+
+			"paused_processes": {
 				Type:     schema.TypeSet,
-				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -361,9 +439,73 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 				Computed: true,
 			},
 
+			"instances_per_availability_zone": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"launch_configuration_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"launch_template": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"version": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"lifecycle_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protected_from_scale_in": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"initial_lifecycle_hook": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				// Hooks are identified by name, so that attributes defaulted by the API
+				// do not show as a replaced hook.
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(map[string]interface{})["name"].(string))
+				},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -378,6 +520,7 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 						"heartbeat_timeout": {
 							Type:     schema.TypeInt,
 							Optional: true,
+							Computed: true,
 						},
 						"lifecycle_transition": {
 							Type:     schema.TypeString,
@@ -523,6 +666,17 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 								ValidateDiagFunc: validateAutoScalingGroupInstanceRefreshTriggerFields,
 							},
 						},
+						"wait_for_completion": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"failure_check_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "2m",
+							ValidateFunc: validateAutoScalingGroupInstanceRefreshFailureCheckTimeout,
+						},
 					},
 				},
 			},
@@ -535,201 +689,690 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			customdiff.ComputedIf("launch_template.0.name", func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
 				return diff.HasChange("launch_template.0.id")
 			}),
+			customdiff.ComputedIf("paused_processes", func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
+				return diff.HasChange("paused")
+			}),
+			resourceAwsAutoscalingGroupHealthCheckTypeCustomizeDiff,
+			resourceAwsAutoscalingGroupPlacementGroupCustomizeDiff,
+			resourceAwsAutoscalingGroupLaunchTemplateResolvedVersionCustomizeDiff,
+			resourceAwsAutoscalingGroupZoneSwitchCustomizeDiff,
+			resourceAwsAutoscalingGroupTagsCustomizeDiff,
 		),
 	}
 }
 
-func generatePutLifecycleHookInputs(asgName string, cfgs []interface{}) []autoscaling.PutLifecycleHookInput {
-	res := make([]autoscaling.PutLifecycleHookInput, 0, len(cfgs))
+// resourceAwsAutoscalingGroupTagsCustomizeDiff rejects tags that would otherwise be silently
+// merged or have propagate_at_launch silently treated as false.
+func resourceAwsAutoscalingGroupTagsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tag") || !diff.NewValueKnown("tags") {
+		return nil
+	}
 
-	for _, raw := range cfgs {
-		cfg := raw.(map[string]interface{})
+	tags := append(diff.Get("tag").(*schema.Set).List(), diff.Get("tags").(*schema.Set).List()...)
 
-		input := autoscaling.PutLifecycleHookInput{
-			AutoScalingGroupName: &asgName,
-			LifecycleHookName:    aws.String(cfg["name"].(string)),
-		}
+	return validateAutoScalingGroupTags(tags)
+}
 
-		if v, ok := cfg["default_result"]; ok && v.(string) != "" {
-			input.DefaultResult = aws.String(v.(string))
-		}
+// validateAutoScalingGroupTags checks that no tag key is defined more than once and that
+// each propagate_at_launch is a boolean. The legacy tags attribute holds propagate_at_launch
+// as a string, which is parsed like strconv.ParseBool, e.g. "true", "True" or "TRUE".
+// Empty keys and values are skipped as they may not be known yet.
+func validateAutoScalingGroupTags(tags []interface{}) error {
+	keys := make(map[string]bool)
 
-		if v, ok := cfg["heartbeat_timeout"]; ok && v.(int) > 0 {
-			input.HeartbeatTimeout = aws.Int64(int64(v.(int)))
-		}
+	for _, tagRaw := range tags {
+		tag, ok := tagRaw.(map[string]interface{})
 
-		if v, ok := cfg["lifecycle_transition"]; ok && v.(string) != "" {
-			input.LifecycleTransition = aws.String(v.(string))
+		if !ok {
+			continue
 		}
 
-		if v, ok := cfg["notification_metadata"]; ok && v.(string) != "" {
-			input.NotificationMetadata = aws.String(v.(string))
-		}
+		key, _ := tag["key"].(string)
 
-		if v, ok := cfg["notification_target_arn"]; ok && v.(string) != "" {
-			input.NotificationTargetARN = aws.String(v.(string))
+		if key == "" {
+			continue
 		}
 
-		if v, ok := cfg["role_arn"]; ok && v.(string) != "" {
-			input.RoleARN = aws.String(v.(string))
+		if keys[key] {
+			return fmt.Errorf("tag key (%s) is defined more than once", key)
 		}
 
-		res = append(res, input)
+		keys[key] = true
+
+		if v, ok := tag["propagate_at_launch"].(string); ok && v != "" {
+			if _, err := strconv.ParseBool(v); err != nil {
+				return fmt.Errorf("propagate_at_launch (%s) of tag key (%s) must be true or false", v, key)
+			}
+		}
 	}
 
-	return res
+	return nil
 }
 
-func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).autoscalingconn
+// flattenAutoScalingGroupTagsPropagateAtLaunch keeps the configured spelling of each propagate_at_launch
+// string in the legacy tags attribute, e.g. "TRUE", when it matches the boolean returned by the API.
+func flattenAutoScalingGroupTagsPropagateAtLaunch(tags, configured []interface{}) []interface{} {
+	configuredValues := make(map[string]string)
 
-	var asgName string
-	if v, ok := d.GetOk("name"); ok {
-		asgName = v.(string)
-	} else {
-		if v, ok := d.GetOk("name_prefix"); ok {
-			asgName = resource.PrefixedUniqueId(v.(string))
-		} else {
-			asgName = resource.PrefixedUniqueId("tf-asg-")
+	for _, tagRaw := range configured {
+		tag, ok := tagRaw.(map[string]interface{})
+
+		if !ok {
+			continue
 		}
-		d.Set("name", asgName)
-	}
 
-	createOpts := autoscaling.CreateAutoScalingGroupInput{
-		AutoScalingGroupName:             aws.String(asgName),
-		MixedInstancesPolicy:             expandAutoScalingMixedInstancesPolicy(d.Get("mixed_instances_policy").([]interface{})),
-		NewInstancesProtectedFromScaleIn: aws.Bool(d.Get("protect_from_scale_in").(bool)),
-	}
-	updateOpts := autoscaling.UpdateAutoScalingGroupInput{
-		AutoScalingGroupName: aws.String(asgName),
+		key, _ := tag["key"].(string)
+		v, _ := tag["propagate_at_launch"].(string)
+		configuredValues[key] = v
 	}
 
-	initialLifecycleHooks := d.Get("initial_lifecycle_hook").(*schema.Set).List()
-	twoPhases := len(initialLifecycleHooks) > 0
-
-	minSize := aws.Int64(int64(d.Get("min_size").(int)))
-	maxSize := aws.Int64(int64(d.Get("max_size").(int)))
+	for _, tagRaw := range tags {
+		tag, ok := tagRaw.(map[string]string)
 
-	if twoPhases {
-		createOpts.MinSize = aws.Int64(int64(0))
-		createOpts.MaxSize = aws.Int64(int64(0))
+		if !ok {
+			continue
+		}
 
-		updateOpts.MinSize = minSize
-		updateOpts.MaxSize = maxSize
+		v, ok := configuredValues[tag["key"]]
 
-		if v, ok := d.GetOk("desired_capacity"); ok {
-			updateOpts.DesiredCapacity = aws.Int64(int64(v.(int)))
+		if !ok {
+			continue
 		}
-	} else {
-		createOpts.MinSize = minSize
-		createOpts.MaxSize = maxSize
 
-		if v, ok := d.GetOk("desired_capacity"); ok {
-			createOpts.DesiredCapacity = aws.Int64(int64(v.(int)))
+		if b, err := strconv.ParseBool(v); err == nil && strconv.FormatBool(b) == tag["propagate_at_launch"] {
+			tag["propagate_at_launch"] = v
 		}
 	}
 
-	launchConfigurationValue, launchConfigurationOk := d.GetOk("launch_configuration")
-	launchTemplateValue, launchTemplateOk := d.GetOk("launch_template")
+	return tags
+}
 
-	if createOpts.MixedInstancesPolicy == nil && !launchConfigurationOk && !launchTemplateOk {
-		return fmt.Errorf("One of `launch_configuration`, `launch_template`, or `mixed_instances_policy` must be set for an Auto Scaling Group")
+// resourceAwsAutoscalingGroupHealthCheckTypeCustomizeDiff logs a warning when ELB
+// health checks are configured without any load balancer or target group. This is
+// not an error since attachments may be managed by aws_autoscaling_attachment.
+func resourceAwsAutoscalingGroupHealthCheckTypeCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// Attachments that are not yet known may well be load balancers or target groups.
+	if !diff.NewValueKnown("health_check_type") || !diff.NewValueKnown("load_balancers") || !diff.NewValueKnown("target_group_arns") {
+		return nil
 	}
 
-	if launchConfigurationOk {
-		createOpts.LaunchConfigurationName = aws.String(launchConfigurationValue.(string))
+	if warning := autoScalingGroupHealthCheckTypeWarning(
+		diff.Get("health_check_type").(string),
+		diff.Get("load_balancers").(*schema.Set).Len(),
+		diff.Get("target_group_arns").(*schema.Set).Len(),
+	); warning != "" {
+		log.Printf("[WARN] Auto Scaling Group (%s): %s", diff.Get("name").(string), warning)
 	}
 
-	if launchTemplateOk {
-		var err error
-		createOpts.LaunchTemplate, err = expandLaunchTemplateSpecification(launchTemplateValue.([]interface{}))
-		if err != nil {
-			return err
-		}
+	return nil
+}
+
+// resourceAwsAutoscalingGroupPlacementGroupCustomizeDiff logs a warning when the placement
+// group of an existing group changes. The change is applied in place and only affects
+// instances launched afterwards, unless placement_group triggers an instance refresh.
+func resourceAwsAutoscalingGroupPlacementGroupCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("placement_group") {
+		return nil
 	}
 
-	// Availability Zones are optional if VPC Zone Identifier(s) are specified
-	if v, ok := d.GetOk("availability_zones"); ok && v.(*schema.Set).Len() > 0 {
-		createOpts.AvailabilityZones = expandStringList(v.(*schema.Set).List())
+	if warning := autoScalingGroupPlacementGroupWarning(
+		autoScalingGroupInstanceRefreshTriggeredBy(diff.Get("instance_refresh").([]interface{}), "placement_group"),
+	); warning != "" {
+		log.Printf("[WARN] Auto Scaling Group (%s): %s", diff.Id(), warning)
 	}
 
-	resourceID := d.Get("name").(string)
+	return nil
+}
 
-	if v, ok := d.GetOk("tag"); ok {
-		createOpts.Tags = keyvaluetags.AutoscalingKeyValueTags(v, resourceID, autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().AutoscalingTags()
+// resourceAwsAutoscalingGroupLaunchTemplateResolvedVersionCustomizeDiff plans a change of
+// launch_template_resolved_version when the launch template version that launch_template
+// resolves to has changed, so that a launch_template instance refresh trigger starts a refresh.
+func resourceAwsAutoscalingGroupLaunchTemplateResolvedVersionCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !autoScalingGroupInstanceRefreshTriggeredBy(diff.Get("instance_refresh").([]interface{}), "launch_template") {
+		return nil
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
-		createOpts.Tags = keyvaluetags.AutoscalingKeyValueTags(v, resourceID, autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().AutoscalingTags()
+	if !diff.NewValueKnown("launch_template") {
+		return diff.SetNewComputed("launch_template_resolved_version")
 	}
 
-	if v, ok := d.GetOk("capacity_rebalance"); ok {
-		createOpts.CapacityRebalance = aws.Bool(v.(bool))
-	}
+	version, err := autoScalingGroupLaunchTemplateResolvedVersion(meta.(*AWSClient).ec2conn, diff.Get("launch_template").([]interface{}))
 
-	if v, ok := d.GetOk("default_cooldown"); ok {
-		createOpts.DefaultCooldown = aws.Int64(int64(v.(int)))
+	if err != nil {
+		return fmt.Errorf("error resolving launch template version: %w", err)
 	}
 
-	if v, ok := d.GetOk("health_check_type"); ok {
-		createOpts.HealthCheckType = aws.String(v.(string))
+	if version == diff.Get("launch_template_resolved_version").(string) {
+		return nil
 	}
 
-	if v, ok := d.GetOk("health_check_grace_period"); ok {
-		createOpts.HealthCheckGracePeriod = aws.Int64(int64(v.(int)))
-	}
+	return diff.SetNew("launch_template_resolved_version", version)
+}
 
-	if v, ok := d.GetOk("placement_group"); ok {
-		createOpts.PlacementGroup = aws.String(v.(string))
+// resourceAwsAutoscalingGroupZoneSwitchCustomizeDiff rejects switching an existing group
+// between availability_zones and vpc_zone_identifier, or changing its subnets to ones in a
+// different set of Availability Zones, or changing its availability_zones, unless allow_az_change
+// is set. The change is an in-place update, but AWS replaces the instances and the update can fail
+// part way through. Configuring both attributes at once is already rejected by ConflictsWith.
+func resourceAwsAutoscalingGroupZoneSwitchCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
 	}
 
-	if v, ok := d.GetOk("load_balancers"); ok && v.(*schema.Set).Len() > 0 {
-		createOpts.LoadBalancerNames = expandStringList(
-			v.(*schema.Set).List())
-	}
+	if !diff.HasChange("vpc_zone_identifier") {
+		if !diff.HasChange("availability_zones") || !diff.NewValueKnown("availability_zones") {
+			return nil
+		}
 
-	if v, ok := d.GetOk("vpc_zone_identifier"); ok && v.(*schema.Set).Len() > 0 {
-		createOpts.VPCZoneIdentifier = expandVpcZoneIdentifiers(v.(*schema.Set).List())
-	}
+		o, n := diff.GetChange("availability_zones")
+		newAvailabilityZones := aws.StringValueSlice(expandStringSet(n.(*schema.Set)))
 
-	if v, ok := d.GetOk("termination_policies"); ok && len(v.([]interface{})) > 0 {
-		createOpts.TerminationPolicies = expandStringList(v.([]interface{}))
-	}
+		// availability_zones is Computed, so an empty value is not configured.
+		if len(newAvailabilityZones) == 0 || !autoScalingGroupAvailabilityZonesChanged(aws.StringValueSlice(expandStringSet(o.(*schema.Set))), newAvailabilityZones) {
+			return nil
+		}
 
-	if v, ok := d.GetOk("target_group_arns"); ok && len(v.(*schema.Set).List()) > 0 {
-		createOpts.TargetGroupARNs = expandStringList(v.(*schema.Set).List())
-	}
+		if !diff.Get("allow_az_change").(bool) {
+			return fmt.Errorf("changing the availability_zones of Auto Scaling Group (%s) to (%s) replaces the instances in other Availability Zones: "+
+				"set allow_az_change to true to confirm", diff.Id(), strings.Join(newAvailabilityZones, ", "))
+		}
 
-	if v, ok := d.GetOk("service_linked_role_arn"); ok {
-		createOpts.ServiceLinkedRoleARN = aws.String(v.(string))
+		return nil
 	}
 
-	if v, ok := d.GetOk("max_instance_lifetime"); ok {
-		createOpts.MaxInstanceLifetime = aws.Int64(int64(v.(int)))
-	}
+	o, n := diff.GetChange("vpc_zone_identifier")
+	oldLen := o.(*schema.Set).Len()
+	newLen := n.(*schema.Set).Len()
 
-	log.Printf("[DEBUG] Auto Scaling Group create configuration: %#v", createOpts)
+	// Subnet IDs that are not yet known are still configured, but their
+	// Availability Zones cannot be looked up.
+	if !diff.NewValueKnown("vpc_zone_identifier") {
+		if oldLen > 0 {
+			return diff.SetNewComputed("availability_zones")
+		}
 
-	// Retry for IAM eventual consistency
-	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-		_, err := conn.CreateAutoScalingGroup(&createOpts)
+		newLen = 1
+	}
 
-		// ValidationError: You must use a valid fully-formed launch template. Value (tf-acc-test-6643732652421074386) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name
-		if isAWSErr(err, "ValidationError", "Invalid IAM Instance Profile") {
-			return resource.RetryableError(err)
+	if autoScalingGroupSwitchesZoneAttribute(oldLen, newLen) {
+		if !diff.Get("allow_az_change").(bool) {
+			return fmt.Errorf("switching Auto Scaling Group (%s) between availability_zones and vpc_zone_identifier replaces all of its instances: "+
+				"set allow_az_change to true to confirm, or first change the group's zones so that they match the new configuration", diff.Id())
 		}
 
-		if err != nil {
-			return resource.NonRetryableError(err)
+		// The Availability Zones follow from the subnets, or from configuration when subnets are removed.
+		if newLen > 0 {
+			return diff.SetNewComputed("availability_zones")
 		}
 
 		return nil
-	})
-	if isResourceTimeoutError(err) {
-		_, err = conn.CreateAutoScalingGroup(&createOpts)
 	}
+
+	// availability_zones in state are those of the current subnets.
+	oldAvailabilityZones, _ := diff.GetChange("availability_zones")
+
+	newAvailabilityZones, err := autoScalingGroupSubnetAvailabilityZones(meta.(*AWSClient).ec2conn, expandStringSet(n.(*schema.Set)))
+
+	if err != nil {
+		return fmt.Errorf("error reading Auto Scaling Group (%s) subnets: %w", diff.Id(), err)
+	}
+
+	if !autoScalingGroupAvailabilityZonesChanged(aws.StringValueSlice(expandStringSet(oldAvailabilityZones.(*schema.Set))), newAvailabilityZones) {
+		return nil
+	}
+
+	if !diff.Get("allow_az_change").(bool) {
+		return fmt.Errorf("changing the subnets of Auto Scaling Group (%s) moves it to different Availability Zones (%s) and replaces their instances: "+
+			"set allow_az_change to true to confirm", diff.Id(), strings.Join(newAvailabilityZones, ", "))
+	}
+
+	return diff.SetNewComputed("availability_zones")
+}
+
+// autoScalingGroupSwitchesZoneAttribute returns whether a change in the number of
+// subnets in vpc_zone_identifier moves a group between availability_zones and subnets.
+func autoScalingGroupSwitchesZoneAttribute(oldSubnets, newSubnets int) bool {
+	return (oldSubnets == 0) != (newSubnets == 0)
+}
+
+// autoScalingGroupAvailabilityZonesChanged returns whether two sets of Availability Zones differ.
+func autoScalingGroupAvailabilityZonesChanged(oldAvailabilityZones, newAvailabilityZones []string) bool {
+	old := make(map[string]bool, len(oldAvailabilityZones))
+
+	for _, availabilityZone := range oldAvailabilityZones {
+		old[availabilityZone] = true
+	}
+
+	seen := make(map[string]bool, len(newAvailabilityZones))
+
+	for _, availabilityZone := range newAvailabilityZones {
+		if !old[availabilityZone] {
+			return true
+		}
+
+		seen[availabilityZone] = true
+	}
+
+	return len(old) != len(seen)
+}
+
+// autoScalingGroupInstanceRefreshTriggeredBy returns whether the instance_refresh triggers contain the specified attribute.
+func autoScalingGroupInstanceRefreshTriggeredBy(instanceRefresh []interface{}, attr string) bool {
+	if len(instanceRefresh) == 0 || instanceRefresh[0] == nil {
+		return false
+	}
+
+	triggers, ok := instanceRefresh[0].(map[string]interface{})["triggers"].(*schema.Set)
+
+	return ok && triggers.Contains(attr)
+}
+
+// autoScalingGroupLaunchTemplateResolvedVersion returns the version number that the
+// launch_template block resolves to, or an empty string if the launch template is not found.
+func autoScalingGroupLaunchTemplateResolvedVersion(conn *ec2.EC2, l []interface{}) (string, error) {
+	if len(l) == 0 || l[0] == nil {
+		return "", nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	var launchTemplate *ec2.LaunchTemplate
+	var err error
+
+	if v, ok := m["id"].(string); ok && v != "" {
+		launchTemplate, err = finder.LaunchTemplateByID(conn, v)
+	} else if v, ok := m["name"].(string); ok && v != "" {
+		launchTemplate, err = finder.LaunchTemplateByName(conn, v)
+	}
+
+	if tfawserr.ErrCodeEquals(err, "InvalidLaunchTemplateId.NotFound") || tfawserr.ErrCodeEquals(err, "InvalidLaunchTemplateName.NotFoundException") {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return resolveLaunchTemplateVersion(m["version"].(string), launchTemplate), nil
+}
+
+// resolveLaunchTemplateVersion returns the version number that a launch template version resolves to.
+// A version that is not specified resolves to the default version.
+func resolveLaunchTemplateVersion(version string, launchTemplate *ec2.LaunchTemplate) string {
+	if launchTemplate == nil {
+		return ""
+	}
+
+	switch version {
+	case "", autoScalingGroupLaunchTemplateVersionDefault:
+		return strconv.FormatInt(aws.Int64Value(launchTemplate.DefaultVersionNumber), 10)
+	case autoScalingGroupLaunchTemplateVersionLatest:
+		return strconv.FormatInt(aws.Int64Value(launchTemplate.LatestVersionNumber), 10)
+	}
+
+	return version
+}
+
+// autoScalingGroupHealthCheckTypeWarning returns a warning message if the health
+// check type is ELB and no load balancers or target groups are configured.
+func autoScalingGroupHealthCheckTypeWarning(healthCheckType string, loadBalancers, targetGroups int) string {
+	if healthCheckType != "ELB" || loadBalancers > 0 || targetGroups > 0 {
+		return ""
+	}
+
+	return "health_check_type is ELB but no load_balancers or target_group_arns are configured, " +
+		"so instances are not health checked against any load balancer; attach a load balancer or " +
+		"target group (directly or with aws_autoscaling_attachment) or use EC2 health checks"
+}
+
+// autoScalingGroupPlacementGroupWarning returns a warning for a placement group change, or an
+// empty string if the change triggers an instance refresh that replaces the existing instances.
+func autoScalingGroupPlacementGroupWarning(instanceRefreshTriggered bool) string {
+	if instanceRefreshTriggered {
+		return ""
+	}
+
+	return "placement_group only applies to instances launched after the change, existing instances " +
+		"stay where they are until they are replaced; add placement_group to instance_refresh triggers " +
+		"to replace them as part of the update"
+}
+
+func getAwsAutoscalingGroupLifecycleHooks(conn *autoscaling.AutoScaling, asgName string) ([]*autoscaling.LifecycleHook, error) {
+	output, err := conn.DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(asgName),
+	})
+
 	if err != nil {
-		return fmt.Errorf("Error creating Auto Scaling Group: %s", err)
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return output.LifecycleHooks, nil
+}
+
+// flattenAutoScalingGroupInitialLifecycleHooks returns the initial_lifecycle_hook blocks for
+// the lifecycle hooks with the specified names.
+func flattenAutoScalingGroupInitialLifecycleHooks(hooks []*autoscaling.LifecycleHook, names map[string]bool) []interface{} {
+	l := make([]interface{}, 0, len(hooks))
+
+	for _, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+
+		name := aws.StringValue(hook.LifecycleHookName)
+
+		if !names[name] {
+			continue
+		}
+
+		l = append(l, map[string]interface{}{
+			"default_result":          aws.StringValue(hook.DefaultResult),
+			"heartbeat_timeout":       int(aws.Int64Value(hook.HeartbeatTimeout)),
+			"lifecycle_transition":    aws.StringValue(hook.LifecycleTransition),
+			"name":                    name,
+			"notification_metadata":   aws.StringValue(hook.NotificationMetadata),
+			"notification_target_arn": aws.StringValue(hook.NotificationTargetARN),
+			"role_arn":                aws.StringValue(hook.RoleARN),
+		})
+	}
+
+	return l
+}
+
+// autoScalingGroupInitialLifecycleHooksDelta returns the names of the initial lifecycle hooks
+// to delete and the hooks to put to go from the old to the new initial_lifecycle_hook blocks.
+// Only hooks that were previously configured are deleted.
+func autoScalingGroupInitialLifecycleHooksDelta(o, n []interface{}) ([]string, []interface{}) {
+	oldHooks := make(map[string]map[string]interface{}, len(o))
+
+	for _, v := range o {
+		hook := v.(map[string]interface{})
+		oldHooks[hook["name"].(string)] = hook
+	}
+
+	var put []interface{}
+
+	for _, v := range n {
+		hook := v.(map[string]interface{})
+		name := hook["name"].(string)
+
+		if oldHook, ok := oldHooks[name]; !ok || !reflect.DeepEqual(oldHook, hook) {
+			put = append(put, hook)
+		}
+
+		delete(oldHooks, name)
+	}
+
+	var remove []string
+
+	for name := range oldHooks {
+		remove = append(remove, name)
+	}
+
+	sort.Strings(remove)
+
+	return remove, put
+}
+
+func updateASGInitialLifecycleHooks(d *schema.ResourceData, conn *autoscaling.AutoScaling) error {
+	o, n := d.GetChange("initial_lifecycle_hook")
+	remove, put := autoScalingGroupInitialLifecycleHooksDelta(o.(*schema.Set).List(), n.(*schema.Set).List())
+
+	for _, name := range remove {
+		log.Printf("[DEBUG] Deleting Auto Scaling Group (%s) lifecycle hook (%s)", d.Id(), name)
+		_, err := conn.DeleteLifecycleHook(&autoscaling.DeleteLifecycleHookInput{
+			AutoScalingGroupName: aws.String(d.Id()),
+			LifecycleHookName:    aws.String(name),
+		})
+
+		if isAWSErr(err, "ValidationError", "No Lifecycle Hook found") {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("error deleting lifecycle hook (%s): %w", name, err)
+		}
+	}
+
+	for _, hook := range generatePutLifecycleHookInputs(d.Id(), put) {
+		if err := resourceAwsAutoscalingLifecycleHookPutOp(conn, &hook); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generatePutLifecycleHookInputs(asgName string, cfgs []interface{}) []autoscaling.PutLifecycleHookInput {
+	res := make([]autoscaling.PutLifecycleHookInput, 0, len(cfgs))
+
+	for _, raw := range cfgs {
+		cfg := raw.(map[string]interface{})
+
+		input := autoscaling.PutLifecycleHookInput{
+			AutoScalingGroupName: &asgName,
+			LifecycleHookName:    aws.String(cfg["name"].(string)),
+		}
+
+		if v, ok := cfg["default_result"]; ok && v.(string) != "" {
+			input.DefaultResult = aws.String(v.(string))
+		}
+
+		if v, ok := cfg["heartbeat_timeout"]; ok && v.(int) > 0 {
+			input.HeartbeatTimeout = aws.Int64(int64(v.(int)))
+		}
+
+		if v, ok := cfg["lifecycle_transition"]; ok && v.(string) != "" {
+			input.LifecycleTransition = aws.String(v.(string))
+		}
+
+		if v, ok := cfg["notification_metadata"]; ok && v.(string) != "" {
+			input.NotificationMetadata = aws.String(v.(string))
+		}
+
+		if v, ok := cfg["notification_target_arn"]; ok && v.(string) != "" {
+			input.NotificationTargetARN = aws.String(v.(string))
+		}
+
+		if v, ok := cfg["role_arn"]; ok && v.(string) != "" {
+			input.RoleARN = aws.String(v.(string))
+		}
+
+		res = append(res, input)
+	}
+
+	return res
+}
+
+// autoScalingGroupImportName returns the group name for an import ID, which is
+// either the group name or ARN.
+func autoScalingGroupImportName(id string) (string, error) {
+	if arn.IsARN(id) {
+		return autoScalingGroupNameFromARN(id)
+	}
+
+	return id, nil
+}
+
+func resourceAwsAutoscalingGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name, err := autoScalingGroupImportName(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(name)
+
+	d.Set("allow_az_change", false)
+	d.Set("ignore_desired_capacity_changes", false)
+	d.Set("ignore_failed_scaling_activities", false)
+
+	// Lifecycle hooks are not adopted into initial_lifecycle_hook: they may be
+	// managed by aws_autoscaling_lifecycle_hook, and hooks that are in state but
+	// not in configuration are deleted on the next update.
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingconn
+
+	// Parse the capacity timeout before any API mutation so that an invalid
+	// value cannot leave a partially created group behind.
+	waitForCapacityTimeout, err := parseAutoScalingGroupWaitForCapacityTimeout(d.Get("wait_for_capacity_timeout").(string))
+	if err != nil {
+		return err
+	}
+
+	var asgName string
+	if v, ok := d.GetOk("name"); ok {
+		asgName = v.(string)
+	} else {
+		if v, ok := d.GetOk("name_prefix"); ok {
+			asgName = resource.PrefixedUniqueId(v.(string))
+		} else {
+			asgName = resource.PrefixedUniqueId("tf-asg-")
+		}
+		d.Set("name", asgName)
+	}
+
+	createOpts := autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName:             aws.String(asgName),
+		MixedInstancesPolicy:             expandAutoScalingMixedInstancesPolicy(d.Get("mixed_instances_policy").([]interface{})),
+		NewInstancesProtectedFromScaleIn: aws.Bool(d.Get("protect_from_scale_in").(bool)),
+	}
+	updateOpts := autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}
+
+	initialLifecycleHooks := d.Get("initial_lifecycle_hook").(*schema.Set).List()
+	twoPhases := len(initialLifecycleHooks) > 0
+
+	minSize := aws.Int64(int64(d.Get("min_size").(int)))
+	maxSize := aws.Int64(int64(d.Get("max_size").(int)))
+
+	if twoPhases {
+		createOpts.MinSize = aws.Int64(int64(0))
+		createOpts.MaxSize = aws.Int64(int64(0))
+
+		updateOpts.MinSize = minSize
+		updateOpts.MaxSize = maxSize
+
+		updateOpts.DesiredCapacity = expandAutoScalingGroupCreateDesiredCapacity(d)
+	} else {
+		createOpts.MinSize = minSize
+		createOpts.MaxSize = maxSize
+
+		createOpts.DesiredCapacity = expandAutoScalingGroupCreateDesiredCapacity(d)
+	}
+
+	launchConfigurationValue, launchConfigurationOk := d.GetOk("launch_configuration")
+	launchTemplateValue, launchTemplateOk := d.GetOk("launch_template")
+
+	if createOpts.MixedInstancesPolicy == nil && !launchConfigurationOk && !launchTemplateOk {
+		return fmt.Errorf("One of `launch_configuration`, `launch_template`, or `mixed_instances_policy` must be set for an Auto Scaling Group")
+	}
+
+	if launchConfigurationOk {
+		createOpts.LaunchConfigurationName = aws.String(launchConfigurationValue.(string))
+	}
+
+	if launchTemplateOk {
+		var err error
+		createOpts.LaunchTemplate, err = expandLaunchTemplateSpecification(launchTemplateValue.([]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Availability Zones are optional if VPC Zone Identifier(s) are specified
+	if v, ok := d.GetOk("availability_zones"); ok && v.(*schema.Set).Len() > 0 {
+		createOpts.AvailabilityZones = expandStringList(v.(*schema.Set).List())
+	}
+
+	resourceID := d.Get("name").(string)
+
+	if v, ok := d.GetOk("tag"); ok {
+		createOpts.Tags = keyvaluetags.AutoscalingKeyValueTags(v, resourceID, autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().AutoscalingTags()
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = keyvaluetags.AutoscalingKeyValueTags(v, resourceID, autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().AutoscalingTags()
+	}
+
+	if v, ok := d.GetOk("capacity_rebalance"); ok {
+		createOpts.CapacityRebalance = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("default_cooldown"); ok {
+		createOpts.DefaultCooldown = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("health_check_type"); ok {
+		createOpts.HealthCheckType = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("health_check_grace_period"); ok {
+		createOpts.HealthCheckGracePeriod = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("placement_group"); ok {
+		createOpts.PlacementGroup = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("load_balancers"); ok && v.(*schema.Set).Len() > 0 {
+		createOpts.LoadBalancerNames = expandStringList(
+			v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("vpc_zone_identifier"); ok && v.(*schema.Set).Len() > 0 {
+		createOpts.VPCZoneIdentifier = expandVpcZoneIdentifiers(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("termination_policies"); ok && len(v.([]interface{})) > 0 {
+		createOpts.TerminationPolicies = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("target_group_arns"); ok && len(v.(*schema.Set).List()) > 0 {
+		createOpts.TargetGroupARNs = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("service_linked_role_arn"); ok {
+		createOpts.ServiceLinkedRoleARN = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("max_instance_lifetime"); ok {
+		createOpts.MaxInstanceLifetime = aws.Int64(int64(v.(int)))
+	}
+
+	log.Printf("[DEBUG] Auto Scaling Group create configuration: %#v", createOpts)
+
+	startTime := time.Now()
+
+	// Retry for IAM eventual consistency
+	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+		_, err := conn.CreateAutoScalingGroup(&createOpts)
+
+		// ValidationError: You must use a valid fully-formed launch template. Value (tf-acc-test-6643732652421074386) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name
+		if isAWSErr(err, "ValidationError", "Invalid IAM Instance Profile") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.CreateAutoScalingGroup(&createOpts)
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating Auto Scaling Group: %s", autoScalingGroupInstanceTypeOfferingsError(d, meta, err))
 	}
 
 	d.SetId(d.Get("name").(string))
@@ -748,7 +1391,7 @@ func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	if err := waitForASGCapacity(d, meta, capacitySatisfiedCreate); err != nil {
+	if err := waitForASGCapacity(d, meta, waitForCapacityTimeout, startTime, capacitySatisfiedCreate); err != nil {
 		return err
 	}
 
@@ -759,6 +1402,12 @@ func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.Get("paused").(bool) {
+		if err := pauseASG(d, conn); err != nil {
+			return fmt.Errorf("error pausing Auto Scaling Group (%s): %w", d.Id(), err)
+		}
+	}
+
 	if _, ok := d.GetOk("enabled_metrics"); ok {
 		metricsErr := enableASGMetricsCollection(d, conn)
 		if metricsErr != nil {
@@ -805,16 +1454,47 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("health_check_grace_period", g.HealthCheckGracePeriod)
 	d.Set("health_check_type", g.HealthCheckType)
 
+	if err := d.Set("instances_per_availability_zone", flattenAutoScalingGroupInstancesPerAvailabilityZone(g.Instances)); err != nil {
+		return fmt.Errorf("error setting instances_per_availability_zone: %s", err)
+	}
+
+	if err := d.Set("instances", flattenAutoScalingGroupInstances(g.Instances)); err != nil {
+		return fmt.Errorf("error setting instances: %s", err)
+	}
+
 	if err := d.Set("load_balancers", flattenStringList(g.LoadBalancerNames)); err != nil {
 		return fmt.Errorf("error setting load_balancers: %s", err)
 	}
 
 	d.Set("launch_configuration", g.LaunchConfigurationName)
 
-	if err := d.Set("launch_template", flattenLaunchTemplateSpecification(g.LaunchTemplate)); err != nil {
+	launchTemplate := flattenLaunchTemplateSpecification(g.LaunchTemplate)
+
+	if len(launchTemplate) > 0 {
+		version, err := autoScalingGroupLaunchTemplateVersion(meta.(*AWSClient).ec2conn, g.LaunchTemplate, d.Get("launch_template.0.version").(string))
+
+		if err != nil {
+			return fmt.Errorf("error reading Auto Scaling Group (%s) launch template version: %w", d.Id(), err)
+		}
+
+		launchTemplate[0]["version"] = version
+	}
+
+	if err := d.Set("launch_template", launchTemplate); err != nil {
 		return fmt.Errorf("error setting launch_template: %s", err)
 	}
 
+	d.Set("launch_template_resolved_version", "")
+	if autoScalingGroupInstanceRefreshTriggeredBy(d.Get("instance_refresh").([]interface{}), "launch_template") {
+		version, err := autoScalingGroupLaunchTemplateResolvedVersion(meta.(*AWSClient).ec2conn, d.Get("launch_template").([]interface{}))
+
+		if err != nil {
+			return fmt.Errorf("error resolving Auto Scaling Group (%s) launch template version: %w", d.Id(), err)
+		}
+
+		d.Set("launch_template_resolved_version", version)
+	}
+
 	d.Set("max_size", g.MaxSize)
 	d.Set("min_size", g.MinSize)
 
@@ -828,15 +1508,39 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("service_linked_role_arn", g.ServiceLinkedRoleARN)
 	d.Set("max_instance_lifetime", g.MaxInstanceLifetime)
 
-	if err := d.Set("suspended_processes", flattenAsgSuspendedProcesses(g.SuspendedProcesses)); err != nil {
+	// Processes suspended by pausing the group are not reported as suspended_processes.
+	suspendedProcesses := flattenStringSet(aws.StringSlice(flattenAsgSuspendedProcesses(g.SuspendedProcesses)))
+	suspendedProcesses = suspendedProcesses.Difference(d.Get("paused_processes").(*schema.Set))
+	if err := d.Set("suspended_processes", suspendedProcesses); err != nil {
 		return fmt.Errorf("error setting suspended_processes: %s", err)
 	}
 
+	if v := d.Get("initial_lifecycle_hook").(*schema.Set); v.Len() > 0 {
+		hooks, err := getAwsAutoscalingGroupLifecycleHooks(conn, d.Id())
+
+		if err != nil {
+			return fmt.Errorf("error reading Auto Scaling Group (%s) lifecycle hooks: %w", d.Id(), err)
+		}
+
+		names := make(map[string]bool, v.Len())
+
+		for _, hook := range v.List() {
+			names[hook.(map[string]interface{})["name"].(string)] = true
+		}
+
+		if err := d.Set("initial_lifecycle_hook", flattenAutoScalingGroupInitialLifecycleHooks(hooks, names)); err != nil {
+			return fmt.Errorf("error setting initial_lifecycle_hook: %w", err)
+		}
+	}
+
 	var tagOk, tagsOk bool
 	var v interface{}
 
 	// Deprecated: In a future major version, this should always set all tags except those ignored.
 	//             Remove d.GetOk() and Only() handling.
+	// Until then, only configured keys are refreshed, so that tags managed by
+	// aws_autoscaling_group_tag are not reported as drift and removed. Import,
+	// where neither attribute is configured, sets all tags in tag.
 	if v, tagOk = d.GetOk("tag"); tagOk {
 		proposedStateTags := keyvaluetags.AutoscalingKeyValueTags(v, d.Id(), autoscalingTagResourceTypeAutoScalingGroup)
 
@@ -848,7 +1552,9 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 	if v, tagsOk = d.GetOk("tags"); tagsOk {
 		proposedStateTags := keyvaluetags.AutoscalingKeyValueTags(v, d.Id(), autoscalingTagResourceTypeAutoScalingGroup)
 
-		if err := d.Set("tags", keyvaluetags.AutoscalingKeyValueTags(g.Tags, d.Id(), autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Only(proposedStateTags).AutoscalingListOfStringMap()); err != nil {
+		tags := keyvaluetags.AutoscalingKeyValueTags(g.Tags, d.Id(), autoscalingTagResourceTypeAutoScalingGroup).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Only(proposedStateTags).AutoscalingListOfStringMap()
+
+		if err := d.Set("tags", flattenAutoScalingGroupTagsPropagateAtLaunch(tags, v.(*schema.Set).List())); err != nil {
 			return fmt.Errorf("error setting tags: %w", err)
 		}
 	}
@@ -959,8 +1665,24 @@ func waitUntilAutoscalingGroupLoadBalancerTargetGroupsAdded(conn *autoscaling.Au
 
 func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).autoscalingconn
+
+	// Parse the capacity timeout before any API mutation so that an invalid
+	// value cannot leave a partially updated group behind.
+	waitForCapacityTimeout, err := parseAutoScalingGroupWaitForCapacityTimeout(d.Get("wait_for_capacity_timeout").(string))
+	if err != nil {
+		return err
+	}
+
 	shouldWaitForCapacity := false
 	shouldRefreshInstances := false
+	instanceRefreshID := ""
+
+	// Update the lifecycle hooks first so that they apply to instances launched by this update.
+	if d.HasChange("initial_lifecycle_hook") {
+		if err := updateASGInitialLifecycleHooks(d, conn); err != nil {
+			return fmt.Errorf("error updating Auto Scaling Group (%s) initial lifecycle hooks: %w", d.Id(), err)
+		}
+	}
 
 	opts := autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(d.Id()),
@@ -985,6 +1707,11 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 	if d.HasChange("desired_capacity") {
 		opts.DesiredCapacity = aws.Int64(int64(d.Get("desired_capacity").(int)))
 		shouldWaitForCapacity = true
+	} else if d.Get("ignore_desired_capacity_changes").(bool) && d.HasChanges("min_size", "max_size") {
+		if v := autoScalingGroupDesiredCapacityWithinSize(d.Get("desired_capacity").(int), d.Get("min_size").(int), d.Get("max_size").(int)); v != nil {
+			opts.DesiredCapacity = v
+			shouldWaitForCapacity = true
+		}
 	}
 
 	if d.HasChange("launch_configuration") {
@@ -994,11 +1721,31 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 		shouldRefreshInstances = true
 	}
 
+	launchTemplateEquivalent := false
 	if d.HasChange("launch_template") {
 		if v, ok := d.GetOk("launch_template"); ok && len(v.([]interface{})) > 0 {
 			opts.LaunchTemplate, _ = expandLaunchTemplateSpecification(v.([]interface{}))
+
+			// When only name is configured, the planned id is still that of the previous launch template.
+			if d.HasChange("launch_template.0.name") && !d.HasChange("launch_template.0.id") {
+				opts.LaunchTemplate.LaunchTemplateId = nil
+				opts.LaunchTemplate.LaunchTemplateName = aws.String(d.Get("launch_template.0.name").(string))
+			}
+		}
+
+		// The group is always updated so that it follows the configured version, but switching
+		// between a symbolic version and the version number it currently resolves to launches
+		// the same instances, so they are not refreshed.
+		o, n := d.GetChange("launch_template")
+		launchTemplateEquivalent, err = autoScalingGroupLaunchTemplateChangeEquivalent(meta.(*AWSClient).ec2conn, o.([]interface{}), n.([]interface{}))
+
+		if err != nil {
+			return fmt.Errorf("error resolving Auto Scaling Group (%s) launch template version: %w", d.Id(), err)
+		}
+
+		if !launchTemplateEquivalent {
+			shouldRefreshInstances = true
 		}
-		shouldRefreshInstances = true
 	}
 
 	if d.HasChange("mixed_instances_policy") {
@@ -1028,13 +1775,16 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 		opts.HealthCheckType = aws.String(d.Get("health_check_type").(string))
 	}
 
+	// Changing zones relaunches instances, so wait for the group to return to capacity.
 	if d.HasChange("vpc_zone_identifier") {
 		opts.VPCZoneIdentifier = expandVpcZoneIdentifiers(d.Get("vpc_zone_identifier").(*schema.Set).List())
+		shouldWaitForCapacity = true
 	}
 
 	if d.HasChange("availability_zones") {
 		if v, ok := d.GetOk("availability_zones"); ok && v.(*schema.Set).Len() > 0 {
 			opts.AvailabilityZones = expandStringList(v.(*schema.Set).List())
+			shouldWaitForCapacity = true
 		}
 	}
 
@@ -1075,9 +1825,10 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	log.Printf("[DEBUG] Auto Scaling Group update configuration: %#v", opts)
-	_, err := conn.UpdateAutoScalingGroup(&opts)
+	startTime := time.Now()
+	_, err = conn.UpdateAutoScalingGroup(&opts)
 	if err != nil {
-		return fmt.Errorf("Error updating Auto Scaling Group: %s", err)
+		return fmt.Errorf("Error updating Auto Scaling Group: %s", autoScalingGroupInstanceTypeOfferingsError(d, meta, err))
 	}
 
 	if d.HasChange("load_balancers") {
@@ -1226,27 +1977,35 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 				m := instanceRefresh[0].(map[string]interface{})
 				attrsSet := m["triggers"].(*schema.Set)
 				attrs := attrsSet.List()
-				strs := make([]string, len(attrs))
-				for i, a := range attrs {
-					strs[i] = a.(string)
+				strs := make([]string, 0, len(attrs))
+				for _, a := range attrs {
+					// A launch template version that resolves to the same version doesn't change the instances.
+					if a.(string) == "launch_template" && launchTemplateEquivalent {
+						continue
+					}
+					strs = append(strs, a.(string))
 				}
 				if attrsSet.Contains("tag") && !attrsSet.Contains("tags") {
 					strs = append(strs, "tags")
 				} else if !attrsSet.Contains("tag") && attrsSet.Contains("tags") {
 					strs = append(strs, "tag")
 				}
+				if attrsSet.Contains("launch_template") {
+					strs = append(strs, "launch_template_resolved_version")
+				}
 				shouldRefreshInstances = d.HasChanges(strs...)
 			}
 		}
 		if shouldRefreshInstances {
-			if err := autoScalingGroupRefreshInstances(conn, d.Id(), instanceRefresh); err != nil {
+			instanceRefreshID, err = autoScalingGroupRefreshInstances(conn, d.Id(), instanceRefresh)
+			if err != nil {
 				return fmt.Errorf("failed to start instance refresh of Auto Scaling Group %s: %w", d.Id(), err)
 			}
 		}
 	}
 
 	if shouldWaitForCapacity {
-		if err := waitForASGCapacity(d, meta, capacitySatisfiedUpdate); err != nil {
+		if err := waitForASGCapacity(d, meta, waitForCapacityTimeout, startTime, capacitySatisfiedUpdate); err != nil {
 			return fmt.Errorf("error waiting for Auto Scaling Group Capacity: %w", err)
 		}
 	}
@@ -1257,15 +2016,156 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	// Resume the processes suspended by pausing the group before any
+	// suspended_processes change, and pause only after it, so that
+	// the two never act on the same processes.
+	if d.HasChange("paused") && !d.Get("paused").(bool) {
+		if err := resumePausedASG(d, conn); err != nil {
+			return fmt.Errorf("error resuming Auto Scaling Group (%s): %w", d.Id(), err)
+		}
+	}
+
 	if d.HasChange("suspended_processes") {
 		if err := updateASGSuspendedProcesses(d, conn); err != nil {
 			return fmt.Errorf("Error updating Auto Scaling Group Suspended Processes: %s", err)
 		}
 	}
 
+	if d.HasChange("paused") && d.Get("paused").(bool) {
+		if err := pauseASG(d, conn); err != nil {
+			return fmt.Errorf("error pausing Auto Scaling Group (%s): %w", d.Id(), err)
+		}
+	}
+
+	if instanceRefreshID != "" && d.Get("instance_refresh.0.wait_for_completion").(bool) {
+		if err := waitForASGInstanceRefresh(conn, d.Id(), instanceRefreshID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for instance refresh (%s) of Auto Scaling Group (%s): %w", instanceRefreshID, d.Id(), err)
+		}
+	} else if instanceRefreshID != "" {
+		// Surface refreshes that fail straight away, e.g. due to an invalid launch template,
+		// while still returning once the refresh is under way.
+		failureCheckTimeout, err := parseAutoScalingGroupInstanceRefreshFailureCheckTimeout(d.Get("instance_refresh.0.failure_check_timeout").(string))
+		if err != nil {
+			return err
+		}
+
+		if failureCheckTimeout > 0 {
+			if _, err := waiter.InstanceRefreshNotFailed(conn, d.Id(), instanceRefreshID, failureCheckTimeout); err != nil {
+				return fmt.Errorf("error checking instance refresh (%s) of Auto Scaling Group (%s): %w", instanceRefreshID, d.Id(), err)
+			}
+		}
+	}
+
+	if err := waitForASGUpdateConsistency(conn, d.Id(), expandAutoScalingGroupExpectedState(d)); err != nil {
+		return fmt.Errorf("error waiting for Auto Scaling Group (%s) update: %w", d.Id(), err)
+	}
+
 	return resourceAwsAutoscalingGroupRead(d, meta)
 }
 
+// autoScalingGroupExpectedState holds the values requested in an update
+// that a subsequent DescribeAutoScalingGroups call is expected to return.
+// Nil fields were not changed and are not checked.
+type autoScalingGroupExpectedState struct {
+	DesiredCapacity   *int64
+	LoadBalancerNames *schema.Set
+	MaxSize           *int64
+	MinSize           *int64
+	TargetGroupARNs   *schema.Set
+}
+
+func expandAutoScalingGroupExpectedState(d *schema.ResourceData) autoScalingGroupExpectedState {
+	var expected autoScalingGroupExpectedState
+
+	if d.HasChange("desired_capacity") {
+		expected.DesiredCapacity = aws.Int64(int64(d.Get("desired_capacity").(int)))
+	}
+
+	if d.HasChange("load_balancers") {
+		expected.LoadBalancerNames = d.Get("load_balancers").(*schema.Set)
+	}
+
+	if d.HasChange("max_size") {
+		expected.MaxSize = aws.Int64(int64(d.Get("max_size").(int)))
+	}
+
+	if d.HasChange("min_size") {
+		expected.MinSize = aws.Int64(int64(d.Get("min_size").(int)))
+	}
+
+	if d.HasChange("target_group_arns") {
+		expected.TargetGroupARNs = d.Get("target_group_arns").(*schema.Set)
+	}
+
+	return expected
+}
+
+// autoScalingGroupPendingFields returns the names of the attributes whose
+// values in the described group do not yet match the expected values.
+func autoScalingGroupPendingFields(expected autoScalingGroupExpectedState, g *autoscaling.Group) []string {
+	var pending []string
+
+	if expected.DesiredCapacity != nil && aws.Int64Value(expected.DesiredCapacity) != aws.Int64Value(g.DesiredCapacity) {
+		pending = append(pending, "desired_capacity")
+	}
+
+	if expected.LoadBalancerNames != nil && !expected.LoadBalancerNames.Equal(flattenStringSet(g.LoadBalancerNames)) {
+		pending = append(pending, "load_balancers")
+	}
+
+	if expected.MaxSize != nil && aws.Int64Value(expected.MaxSize) != aws.Int64Value(g.MaxSize) {
+		pending = append(pending, "max_size")
+	}
+
+	if expected.MinSize != nil && aws.Int64Value(expected.MinSize) != aws.Int64Value(g.MinSize) {
+		pending = append(pending, "min_size")
+	}
+
+	if expected.TargetGroupARNs != nil && !expected.TargetGroupARNs.Equal(flattenStringSet(g.TargetGroupARNs)) {
+		pending = append(pending, "target_group_arns")
+	}
+
+	return pending
+}
+
+// waitForASGUpdateConsistency waits until DescribeAutoScalingGroups reflects
+// the expected values so that the following Read does not store a stale snapshot.
+// Values still pending after the timeout are logged and left for the next refresh.
+func waitForASGUpdateConsistency(conn *autoscaling.AutoScaling, asgName string, expected autoScalingGroupExpectedState) error {
+	if expected.DesiredCapacity == nil && expected.LoadBalancerNames == nil && expected.MaxSize == nil && expected.MinSize == nil && expected.TargetGroupARNs == nil {
+		return nil
+	}
+
+	var pending []string
+
+	err := resource.Retry(autoscalingGroupUpdateConsistencyTimeout, func() *resource.RetryError {
+		g, err := getAwsAutoscalingGroup(asgName, conn)
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if g == nil {
+			return nil
+		}
+
+		pending = autoScalingGroupPendingFields(expected, g)
+
+		if len(pending) > 0 {
+			return resource.RetryableError(fmt.Errorf("Auto Scaling Group (%s) does not yet reflect %s", asgName, strings.Join(pending, ", ")))
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		log.Printf("[WARN] Auto Scaling Group (%s) does not yet reflect %s after %s", asgName, strings.Join(pending, ", "), autoscalingGroupUpdateConsistencyTimeout)
+		return nil
+	}
+
+	return err
+}
+
 func resourceAwsAutoscalingGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).autoscalingconn
 
@@ -1460,14 +2360,12 @@ func updateASGSuspendedProcesses(d *schema.ResourceData, conn *autoscaling.AutoS
 		n = new(schema.Set)
 	}
 
-	os := o.(*schema.Set)
-	ns := n.(*schema.Set)
+	resumeProcesses, suspendProcesses := autoScalingGroupSuspendedProcessesDelta(o.(*schema.Set), n.(*schema.Set))
 
-	resumeProcesses := os.Difference(ns)
-	if resumeProcesses.Len() != 0 {
+	if len(resumeProcesses) != 0 {
 		props := &autoscaling.ScalingProcessQuery{
 			AutoScalingGroupName: aws.String(d.Id()),
-			ScalingProcesses:     expandStringList(resumeProcesses.List()),
+			ScalingProcesses:     resumeProcesses,
 		}
 
 		_, err := conn.ResumeProcesses(props)
@@ -1476,11 +2374,10 @@ func updateASGSuspendedProcesses(d *schema.ResourceData, conn *autoscaling.AutoS
 		}
 	}
 
-	suspendedProcesses := ns.Difference(os)
-	if suspendedProcesses.Len() != 0 {
+	if len(suspendProcesses) != 0 {
 		props := &autoscaling.ScalingProcessQuery{
 			AutoScalingGroupName: aws.String(d.Id()),
-			ScalingProcesses:     expandStringList(suspendedProcesses.List()),
+			ScalingProcesses:     suspendProcesses,
 		}
 
 		_, err := conn.SuspendProcesses(props)
@@ -1489,8 +2386,105 @@ func updateASGSuspendedProcesses(d *schema.ResourceData, conn *autoscaling.AutoS
 		}
 	}
 
-	return nil
+	return nil
+
+}
+
+// autoScalingGroupSuspendedProcessesDelta returns the processes to resume and to suspend
+// to get from the old to the new suspended_processes. Processes in both stay suspended,
+// so that e.g. AZRebalance does not run between resuming and suspending again.
+func autoScalingGroupSuspendedProcessesDelta(o, n *schema.Set) (resume, suspend []*string) {
+	return expandStringList(o.Difference(n).List()), expandStringList(n.Difference(o).List())
+}
+
+// pauseASG suspends all scaling processes that are not already suspended
+// and records them in paused_processes so that only they are resumed later.
+func pauseASG(d *schema.ResourceData, conn *autoscaling.AutoScaling) error {
+	processTypes, err := conn.DescribeScalingProcessTypes(&autoscaling.DescribeScalingProcessTypesInput{})
+
+	if err != nil {
+		return fmt.Errorf("error describing scaling process types: %w", err)
+	}
+
+	var allProcesses []string
+	for _, process := range processTypes.Processes {
+		if process != nil {
+			allProcesses = append(allProcesses, aws.StringValue(process.ProcessName))
+		}
+	}
+
+	g, err := getAwsAutoscalingGroup(d.Id(), conn)
+
+	if err != nil {
+		return err
+	}
+
+	if g == nil {
+		return fmt.Errorf("not found")
+	}
+
+	processes := autoScalingGroupProcessesToPause(allProcesses, flattenAsgSuspendedProcesses(g.SuspendedProcesses))
+
+	if len(processes) > 0 {
+		log.Printf("[DEBUG] Pausing Auto Scaling Group (%s) by suspending processes: %s", d.Id(), processes)
+		_, err = conn.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     aws.StringSlice(processes),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error suspending processes: %w", err)
+		}
+	}
+
+	if err := d.Set("paused_processes", processes); err != nil {
+		return fmt.Errorf("error setting paused_processes: %w", err)
+	}
+
+	return nil
+}
+
+// resumePausedASG resumes the processes suspended by pauseASG.
+func resumePausedASG(d *schema.ResourceData, conn *autoscaling.AutoScaling) error {
+	processes := d.Get("paused_processes").(*schema.Set)
+
+	if processes.Len() > 0 {
+		log.Printf("[DEBUG] Resuming paused Auto Scaling Group (%s) processes: %s", d.Id(), processes.List())
+		_, err := conn.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     expandStringSet(processes),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error resuming processes: %w", err)
+		}
+	}
+
+	if err := d.Set("paused_processes", []string{}); err != nil {
+		return fmt.Errorf("error setting paused_processes: %w", err)
+	}
+
+	return nil
+}
+
+// autoScalingGroupProcessesToPause returns the processes, in sorted order,
+// that are not already suspended.
+func autoScalingGroupProcessesToPause(allProcesses, suspendedProcesses []string) []string {
+	suspended := make(map[string]bool, len(suspendedProcesses))
+	for _, process := range suspendedProcesses {
+		suspended[process] = true
+	}
+
+	processes := []string{}
+	for _, process := range allProcesses {
+		if !suspended[process] {
+			processes = append(processes, process)
+		}
+	}
+
+	sort.Strings(processes)
 
+	return processes
 }
 
 func updateASGMetricsCollection(d *schema.ResourceData, conn *autoscaling.AutoScaling) error {
@@ -1576,23 +2570,41 @@ func getTargetGroupInstanceStates(g *autoscaling.Group, meta interface{}) (map[s
 	elbv2conn := meta.(*AWSClient).elbv2conn
 
 	for _, targetGroupARN := range g.TargetGroupARNs {
-		targetInstanceStates[*targetGroupARN] = make(map[string]string)
 		opts := &elbv2.DescribeTargetHealthInput{TargetGroupArn: targetGroupARN}
 		r, err := elbv2conn.DescribeTargetHealth(opts)
 		if err != nil {
 			return nil, err
 		}
-		for _, desc := range r.TargetHealthDescriptions {
-			if desc.Target == nil || desc.Target.Id == nil || desc.TargetHealth == nil || desc.TargetHealth.State == nil {
-				continue
-			}
-			targetInstanceStates[*targetGroupARN][*desc.Target.Id] = *desc.TargetHealth.State
-		}
+		targetInstanceStates[*targetGroupARN] = flattenAutoScalingGroupTargetHealthStates(r.TargetHealthDescriptions)
 	}
 
 	return targetInstanceStates, nil
 }
 
+// flattenAutoScalingGroupTargetHealthStates maps instance IDs to their target health state.
+// An instance registered more than once, e.g. on several ports, is only healthy if every
+// registration is healthy, so that a draining registration is never counted.
+func flattenAutoScalingGroupTargetHealthStates(descs []*elbv2.TargetHealthDescription) map[string]string {
+	states := make(map[string]string)
+
+	for _, desc := range descs {
+		if desc == nil || desc.Target == nil || desc.Target.Id == nil || desc.TargetHealth == nil || desc.TargetHealth.State == nil {
+			continue
+		}
+
+		id := aws.StringValue(desc.Target.Id)
+		state := aws.StringValue(desc.TargetHealth.State)
+
+		if existing, ok := states[id]; ok && existing != elbv2.TargetHealthStateEnumHealthy {
+			continue
+		}
+
+		states[id] = state
+	}
+
+	return states
+}
+
 func expandVpcZoneIdentifiers(list []interface{}) *string {
 	strs := make([]string, len(list))
 	for _, s := range list {
@@ -1679,6 +2691,11 @@ func expandAutoScalingLaunchTemplateOverride(m map[string]interface{}) *autoscal
 		launchTemplateOverrides.InstanceType = aws.String(v.(string))
 	}
 
+	// Overrides without a launch template use the mixed instances policy's launch template.
+	if v, ok := m["launch_template_specification"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		launchTemplateOverrides.LaunchTemplateSpecification = expandAutoScalingLaunchTemplateSpecification(v)
+	}
+
 	if v, ok := m["weighted_capacity"]; ok && v.(string) != "" {
 		launchTemplateOverrides.WeightedCapacity = aws.String(v.(string))
 	}
@@ -1770,8 +2787,9 @@ func flattenAutoScalingLaunchTemplateOverrides(launchTemplateOverrides []*autosc
 			continue
 		}
 		m := map[string]interface{}{
-			"instance_type":     aws.StringValue(launchTemplateOverride.InstanceType),
-			"weighted_capacity": aws.StringValue(launchTemplateOverride.WeightedCapacity),
+			"instance_type":                 aws.StringValue(launchTemplateOverride.InstanceType),
+			"launch_template_specification": flattenAutoScalingLaunchTemplateSpecification(launchTemplateOverride.LaunchTemplateSpecification),
+			"weighted_capacity":             aws.StringValue(launchTemplateOverride.WeightedCapacity),
 		}
 		l[i] = m
 	}
@@ -1806,6 +2824,73 @@ func flattenAutoScalingMixedInstancesPolicy(mixedInstancesPolicy *autoscaling.Mi
 	return []interface{}{m}
 }
 
+// flattenAutoScalingGroupInstancesPerAvailabilityZone returns the number of
+// instances in each Availability Zone. Only counts are kept so that state
+// stays small for very large groups.
+func flattenAutoScalingGroupInstancesPerAvailabilityZone(instances []*autoscaling.Instance) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	for _, instance := range instances {
+		if instance == nil {
+			continue
+		}
+
+		az := aws.StringValue(instance.AvailabilityZone)
+
+		if az == "" {
+			continue
+		}
+
+		count, _ := m[az].(int)
+		m[az] = count + 1
+	}
+
+	return m
+}
+
+// flattenAutoScalingGroupInstances returns the group's instances ordered by instance ID.
+func flattenAutoScalingGroupInstances(instances []*autoscaling.Instance) []interface{} {
+	sorted := make([]*autoscaling.Instance, 0, len(instances))
+
+	for _, instance := range instances {
+		if instance != nil {
+			sorted = append(sorted, instance)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return aws.StringValue(sorted[i].InstanceId) < aws.StringValue(sorted[j].InstanceId)
+	})
+
+	l := make([]interface{}, 0, len(sorted))
+
+	for _, instance := range sorted {
+		m := map[string]interface{}{
+			"availability_zone":         aws.StringValue(instance.AvailabilityZone),
+			"health_status":             aws.StringValue(instance.HealthStatus),
+			"instance_id":               aws.StringValue(instance.InstanceId),
+			"launch_configuration_name": aws.StringValue(instance.LaunchConfigurationName),
+			"launch_template":           []interface{}{},
+			"lifecycle_state":           aws.StringValue(instance.LifecycleState),
+			"protected_from_scale_in":   aws.BoolValue(instance.ProtectedFromScaleIn),
+		}
+
+		if lt := instance.LaunchTemplate; lt != nil {
+			m["launch_template"] = []interface{}{
+				map[string]interface{}{
+					"id":      aws.StringValue(lt.LaunchTemplateId),
+					"name":    aws.StringValue(lt.LaunchTemplateName),
+					"version": aws.StringValue(lt.Version),
+				},
+			}
+		}
+
+		l = append(l, m)
+	}
+
+	return l
+}
+
 func waitUntilAutoscalingGroupLoadBalancersAdded(conn *autoscaling.AutoScaling, asgName string) error {
 	input := &autoscaling.DescribeLoadBalancersInput{
 		AutoScalingGroupName: aws.String(asgName),
@@ -1914,10 +2999,12 @@ func expandAutoScalingGroupInstanceRefreshPreferences(l []interface{}) *autoscal
 	return refreshPreferences
 }
 
-func autoScalingGroupRefreshInstances(conn *autoscaling.AutoScaling, asgName string, refreshConfig []interface{}) error {
+func autoScalingGroupRefreshInstances(conn *autoscaling.AutoScaling, asgName string, refreshConfig []interface{}) (string, error) {
 	input := createAutoScalingGroupInstanceRefreshInput(asgName, refreshConfig)
+	var output *autoscaling.StartInstanceRefreshOutput
 	err := resource.Retry(waiter.InstanceRefreshStartedTimeout, func() *resource.RetryError {
-		_, err := conn.StartInstanceRefresh(input)
+		var err error
+		output, err = conn.StartInstanceRefresh(input)
 		if tfawserr.ErrCodeEquals(err, autoscaling.ErrCodeInstanceRefreshInProgressFault) {
 			cancelErr := cancelAutoscalingInstanceRefresh(conn, asgName)
 			if cancelErr != nil {
@@ -1931,13 +3018,64 @@ func autoScalingGroupRefreshInstances(conn *autoscaling.AutoScaling, asgName str
 		return nil
 	})
 	if isResourceTimeoutError(err) {
-		_, err = conn.StartInstanceRefresh(input)
+		output, err = conn.StartInstanceRefresh(input)
 	}
 	if err != nil {
-		return fmt.Errorf("error starting Instance Refresh: %w", err)
+		return "", fmt.Errorf("error starting Instance Refresh: %w", err)
+	}
+	if output == nil {
+		return "", fmt.Errorf("error starting Instance Refresh: empty result")
 	}
 
-	return nil
+	return aws.StringValue(output.InstanceRefreshId), nil
+}
+
+// waitForASGInstanceRefresh waits for an Instance Refresh to complete.
+// An Instance Refresh that was cancelled because a newer one was started is not treated as an error.
+func waitForASGInstanceRefresh(conn *autoscaling.AutoScaling, asgName, instanceRefreshID string, timeout time.Duration) error {
+	instanceRefresh, err := waiter.InstanceRefreshFinished(conn, asgName, instanceRefreshID, timeout)
+
+	if err == nil || instanceRefresh == nil {
+		return err
+	}
+
+	var refreshes []*autoscaling.InstanceRefresh
+
+	if aws.StringValue(instanceRefresh.Status) == autoscaling.InstanceRefreshStatusCancelled {
+		output, describeErr := conn.DescribeInstanceRefreshes(&autoscaling.DescribeInstanceRefreshesInput{
+			AutoScalingGroupName: aws.String(asgName),
+		})
+
+		if describeErr != nil {
+			return fmt.Errorf("error describing Instance Refreshes: %w", describeErr)
+		}
+
+		refreshes = output.InstanceRefreshes
+	}
+
+	return autoScalingGroupInstanceRefreshError(instanceRefresh, refreshes)
+}
+
+// autoScalingGroupInstanceRefreshError returns an error for an Instance Refresh that ended without succeeding.
+// refreshes are the group's Instance Refreshes, most recent first, used to ignore a superseded cancellation.
+func autoScalingGroupInstanceRefreshError(instanceRefresh *autoscaling.InstanceRefresh, refreshes []*autoscaling.InstanceRefresh) error {
+	status := aws.StringValue(instanceRefresh.Status)
+
+	switch status {
+	case autoscaling.InstanceRefreshStatusSuccessful:
+		return nil
+	case autoscaling.InstanceRefreshStatusCancelled:
+		for _, refresh := range refreshes {
+			if aws.StringValue(refresh.InstanceRefreshId) == aws.StringValue(instanceRefresh.InstanceRefreshId) {
+				break
+			}
+
+			log.Printf("[INFO] Instance Refresh (%s) was cancelled by Instance Refresh (%s)", aws.StringValue(instanceRefresh.InstanceRefreshId), aws.StringValue(refresh.InstanceRefreshId))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Instance Refresh ended with status %s: %s", status, aws.StringValue(instanceRefresh.StatusReason))
 }
 
 func cancelAutoscalingInstanceRefresh(conn *autoscaling.AutoScaling, asgName string) error {
@@ -1969,7 +3107,9 @@ func validateAutoScalingGroupInstanceRefreshTriggerFields(i interface{}, path ct
 		return diag.Errorf("expected type to be string")
 	}
 
-	if v == "launch_configuration" || v == "launch_template" || v == "mixed_instances_policy" {
+	// launch_template additionally triggers an instance refresh when the
+	// launch template version it resolves to changes.
+	if v == "launch_configuration" || v == "mixed_instances_policy" {
 		return diag.Diagnostics{
 			diag.Diagnostic{
 				Severity: diag.Warning,
@@ -1990,3 +3130,377 @@ func validateAutoScalingGroupInstanceRefreshTriggerFields(i interface{}, path ct
 
 	return diag.Errorf("'%s' is not a recognized parameter name for aws_autoscaling_group", v)
 }
+
+// autoScalingGroupLaunchTemplateVersion returns the launch template version to store in state.
+// The API normally returns the version as it was set, but when it returns a symbolic version
+// ($Default or $Latest) and the configured value is the version number it resolves to, or the
+// other way around, the configured value is kept so that the two are not reported as a difference.
+func autoScalingGroupLaunchTemplateVersion(conn *ec2.EC2, spec *autoscaling.LaunchTemplateSpecification, configured string) (interface{}, error) {
+	if spec.Version == nil {
+		return nil, nil
+	}
+
+	version := aws.StringValue(spec.Version)
+
+	if configured == "" || configured == version {
+		return version, nil
+	}
+
+	launchTemplate, err := finder.LaunchTemplateByID(conn, aws.StringValue(spec.LaunchTemplateId))
+
+	if tfawserr.ErrCodeEquals(err, "InvalidLaunchTemplateId.NotFound") {
+		return version, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if launchTemplate == nil {
+		return version, nil
+	}
+
+	if launchTemplateVersionsEquivalent(configured, version, aws.Int64Value(launchTemplate.DefaultVersionNumber), aws.Int64Value(launchTemplate.LatestVersionNumber)) {
+		return configured, nil
+	}
+
+	return version, nil
+}
+
+// autoScalingGroupLaunchTemplateChangeEquivalent returns whether a launch_template change keeps
+// the same launch template and only switches between a symbolic version and the version number
+// it currently resolves to, so that the group's instances don't need to be refreshed.
+func autoScalingGroupLaunchTemplateChangeEquivalent(conn *ec2.EC2, o, n []interface{}) (bool, error) {
+	if len(o) == 0 || o[0] == nil || len(n) == 0 || n[0] == nil {
+		return false, nil
+	}
+
+	om := o[0].(map[string]interface{})
+	nm := n[0].(map[string]interface{})
+
+	// id and name are both read, so the launch template is the same when neither changes.
+	if om["id"].(string) == "" || om["id"] != nm["id"] || om["name"] != nm["name"] {
+		return false, nil
+	}
+
+	oldVersion, newVersion := om["version"].(string), nm["version"].(string)
+
+	if oldVersion == newVersion {
+		return true, nil
+	}
+
+	launchTemplate, err := finder.LaunchTemplateByID(conn, om["id"].(string))
+
+	if tfawserr.ErrCodeEquals(err, "InvalidLaunchTemplateId.NotFound") {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if launchTemplate == nil {
+		return false, nil
+	}
+
+	return launchTemplateVersionsEquivalent(oldVersion, newVersion, aws.Int64Value(launchTemplate.DefaultVersionNumber), aws.Int64Value(launchTemplate.LatestVersionNumber)), nil
+}
+
+// launchTemplateVersionsEquivalent returns whether one launch template version is symbolic
+// and resolves to the other, which is a version number.
+func launchTemplateVersionsEquivalent(a, b string, defaultVersion, latestVersion int64) bool {
+	return launchTemplateVersionEquivalent(a, b, defaultVersion, latestVersion) || launchTemplateVersionEquivalent(b, a, defaultVersion, latestVersion)
+}
+
+// launchTemplateVersionEquivalent returns whether a symbolic launch template version
+// resolves to the specified version number.
+func launchTemplateVersionEquivalent(symbolic, number string, defaultVersion, latestVersion int64) bool {
+	n, err := strconv.ParseInt(number, 10, 64)
+
+	if err != nil {
+		return false
+	}
+
+	switch symbolic {
+	case autoScalingGroupLaunchTemplateVersionDefault:
+		return n == defaultVersion
+	case autoScalingGroupLaunchTemplateVersionLatest:
+		return n == latestVersion
+	}
+
+	return false
+}
+
+// autoScalingGroupNameFromARN returns the group name from an Auto Scaling Group ARN, e.g.
+// arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:uuid:autoScalingGroupName/name.
+func autoScalingGroupNameFromARN(s string) (string, error) {
+	const (
+		resourceTypePrefix = "autoScalingGroup:"
+		namePrefix         = "autoScalingGroupName/"
+	)
+
+	parsedARN, err := arn.Parse(s)
+
+	if err != nil {
+		return "", fmt.Errorf("error parsing Auto Scaling Group ARN (%s): %w", s, err)
+	}
+
+	if parsedARN.Service != autoscaling.ServiceName {
+		return "", fmt.Errorf("error parsing Auto Scaling Group ARN (%s): expected service %s, got %s", s, autoscaling.ServiceName, parsedARN.Service)
+	}
+
+	if !strings.HasPrefix(parsedARN.Resource, resourceTypePrefix) {
+		return "", fmt.Errorf("error parsing Auto Scaling Group ARN (%s): expected resource to start with %q", s, resourceTypePrefix)
+	}
+
+	i := strings.Index(parsedARN.Resource, ":"+namePrefix)
+
+	if i == -1 {
+		return "", fmt.Errorf("error parsing Auto Scaling Group ARN (%s): expected resource to contain %q", s, namePrefix)
+	}
+
+	name := parsedARN.Resource[i+len(namePrefix)+1:]
+
+	if name == "" {
+		return "", fmt.Errorf("error parsing Auto Scaling Group ARN (%s): empty group name", s)
+	}
+
+	return name, nil
+}
+
+// isAutoScalingGroupInstanceTypeError returns whether the error may be caused by an
+// instance type that is not offered or not authorized in the group's Availability Zones.
+func isAutoScalingGroupInstanceTypeError(err error) bool {
+	var awsErr awserr.Error
+
+	if !errors.As(err, &awsErr) || awsErr.Code() != "ValidationError" {
+		return false
+	}
+
+	message := strings.ToLower(awsErr.Message())
+
+	return strings.Contains(message, "instance type") || strings.Contains(message, "not authorized")
+}
+
+// autoScalingGroupInstanceTypeOfferingsError appends the mixed instances policy override
+// instance types that are not offered in the group's Availability Zones to an
+// instance type related error. Otherwise the error is returned unchanged.
+func autoScalingGroupInstanceTypeOfferingsError(d *schema.ResourceData, meta interface{}, err error) error {
+	if !isAutoScalingGroupInstanceTypeError(err) {
+		return err
+	}
+
+	var instanceTypes []string
+
+	if mixedInstancesPolicy := expandAutoScalingMixedInstancesPolicy(d.Get("mixed_instances_policy").([]interface{})); mixedInstancesPolicy != nil && mixedInstancesPolicy.LaunchTemplate != nil {
+		for _, override := range mixedInstancesPolicy.LaunchTemplate.Overrides {
+			if v := aws.StringValue(override.InstanceType); v != "" {
+				instanceTypes = append(instanceTypes, v)
+			}
+		}
+	}
+
+	if len(instanceTypes) == 0 {
+		return err
+	}
+
+	conn := meta.(*AWSClient).ec2conn
+
+	availabilityZones, azErr := autoScalingGroupAvailabilityZones(conn, d)
+
+	if azErr != nil {
+		log.Printf("[WARN] Unable to determine Auto Scaling Group Availability Zones: %s", azErr)
+		return err
+	}
+
+	if len(availabilityZones) == 0 {
+		return err
+	}
+
+	offerings, offeringsErr := finder.InstanceTypeOfferingsByAvailabilityZones(conn, instanceTypes, availabilityZones)
+
+	if offeringsErr != nil {
+		log.Printf("[WARN] Unable to describe EC2 Instance Type Offerings: %s", offeringsErr)
+		return err
+	}
+
+	notOffered := autoScalingGroupInstanceTypesNotOffered(instanceTypes, availabilityZones, offerings)
+
+	if len(notOffered) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w (instance types not offered in the group's Availability Zones: %s)", err, strings.Join(notOffered, ", "))
+}
+
+// autoScalingGroupAvailabilityZones returns the group's configured Availability Zones,
+// looking up the Availability Zones of any configured VPC subnets.
+func autoScalingGroupAvailabilityZones(conn *ec2.EC2, d *schema.ResourceData) ([]string, error) {
+	if v, ok := d.GetOk("availability_zones"); ok && v.(*schema.Set).Len() > 0 {
+		return aws.StringValueSlice(expandStringSet(v.(*schema.Set))), nil
+	}
+
+	v, ok := d.GetOk("vpc_zone_identifier")
+
+	if !ok || v.(*schema.Set).Len() == 0 {
+		return nil, nil
+	}
+
+	return autoScalingGroupSubnetAvailabilityZones(conn, expandStringSet(v.(*schema.Set)))
+}
+
+// autoScalingGroupSubnetAvailabilityZones returns the distinct Availability Zones of VPC subnets.
+func autoScalingGroupSubnetAvailabilityZones(conn *ec2.EC2, subnetIDs []*string) ([]string, error) {
+	output, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIDs,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var availabilityZones []string
+	seen := make(map[string]bool)
+
+	for _, subnet := range output.Subnets {
+		if availabilityZone := aws.StringValue(subnet.AvailabilityZone); availabilityZone != "" && !seen[availabilityZone] {
+			seen[availabilityZone] = true
+			availabilityZones = append(availabilityZones, availabilityZone)
+		}
+	}
+
+	return availabilityZones, nil
+}
+
+// autoScalingGroupInstanceTypesNotOffered returns the instance types that are not offered
+// in at least one of the Availability Zones, along with the Availability Zones missing them.
+func autoScalingGroupInstanceTypesNotOffered(instanceTypes, availabilityZones []string, offerings []*ec2.InstanceTypeOffering) []string {
+	offered := make(map[string]map[string]bool)
+
+	for _, offering := range offerings {
+		if offering == nil {
+			continue
+		}
+
+		instanceType := aws.StringValue(offering.InstanceType)
+
+		if offered[instanceType] == nil {
+			offered[instanceType] = make(map[string]bool)
+		}
+
+		offered[instanceType][aws.StringValue(offering.Location)] = true
+	}
+
+	var notOffered []string
+	seen := make(map[string]bool)
+
+	for _, instanceType := range instanceTypes {
+		if seen[instanceType] {
+			continue
+		}
+
+		seen[instanceType] = true
+
+		var missing []string
+
+		for _, availabilityZone := range availabilityZones {
+			if !offered[instanceType][availabilityZone] {
+				missing = append(missing, availabilityZone)
+			}
+		}
+
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			notOffered = append(notOffered, fmt.Sprintf("%s (%s)", instanceType, strings.Join(missing, ", ")))
+		}
+	}
+
+	sort.Strings(notOffered)
+
+	return notOffered
+}
+
+// expandAutoScalingGroupCreateDesiredCapacity returns the desired capacity to use
+// when creating the group, from either desired_capacity or initial_desired_capacity.
+func expandAutoScalingGroupCreateDesiredCapacity(d *schema.ResourceData) *int64 {
+	if v, ok := d.GetOk("desired_capacity"); ok {
+		return aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("initial_desired_capacity"); ok {
+		return aws.Int64(int64(v.(int)))
+	}
+
+	return nil
+}
+
+// autoScalingGroupDesiredCapacityWithinSize returns the desired capacity moved within the
+// minimum and maximum size, or nil if it is already within them.
+func autoScalingGroupDesiredCapacityWithinSize(desiredCapacity, minSize, maxSize int) *int64 {
+	switch {
+	case desiredCapacity < minSize:
+		return aws.Int64(int64(minSize))
+	case desiredCapacity > maxSize:
+		return aws.Int64(int64(maxSize))
+	}
+
+	return nil
+}
+
+// parseAutoScalingGroupWaitForCapacityTimeout parses a wait_for_capacity_timeout value.
+// A value of "0" disables waiting for capacity.
+func parseAutoScalingGroupWaitForCapacityTimeout(v string) (time.Duration, error) {
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("wait_for_capacity_timeout (%q) cannot be parsed as a duration such as \"10m\" or \"0\": %w", v, err)
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("wait_for_capacity_timeout (%q) must not be negative, use \"0\" to skip waiting for capacity", v)
+	}
+
+	return duration, nil
+}
+
+// parseAutoScalingGroupInstanceRefreshFailureCheckTimeout parses an instance_refresh failure_check_timeout value.
+// A value of "0" disables checking a started Instance Refresh for failure.
+func parseAutoScalingGroupInstanceRefreshFailureCheckTimeout(v string) (time.Duration, error) {
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("instance_refresh failure_check_timeout (%q) cannot be parsed as a duration such as \"2m\" or \"0\": %w", v, err)
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("instance_refresh failure_check_timeout (%q) must not be negative, use \"0\" to skip checking for failure", v)
+	}
+
+	return duration, nil
+}
+
+func validateAutoScalingGroupInstanceRefreshFailureCheckTimeout(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if _, err := parseAutoScalingGroupInstanceRefreshFailureCheckTimeout(value); err != nil {
+		es = append(es, err)
+	}
+
+	return
+}
+
+func validateAutoScalingGroupWaitForCapacityTimeout(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if _, err := parseAutoScalingGroupWaitForCapacityTimeout(value); err != nil {
+		es = append(es, err)
+	}
+
+	return
+}
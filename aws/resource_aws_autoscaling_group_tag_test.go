@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func TestAccAWSAutoscalingGroupTag_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_autoscaling_group_tag.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAutoscalingGroupTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingGroupTagConfig(rName, "key1", "value1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAutoscalingGroupTagExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "autoscaling_group_name", "aws_autoscaling_group.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.key", "key1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.value", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.propagate_at_launch", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoscalingGroupTag_disappears(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_autoscaling_group_tag.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAutoscalingGroupTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingGroupTagConfig(rName, "key1", "value1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAutoscalingGroupTagExists(resourceName),
+					testAccCheckResourceDisappears(testAccProvider, resourceAwsAutoscalingGroupTag(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoscalingGroupTag_Value(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_autoscaling_group_tag.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAutoscalingGroupTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingGroupTagConfig(rName, "key1", "value1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAutoscalingGroupTagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.value", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.propagate_at_launch", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAutoscalingGroupTagConfig(rName, "key1", "value1updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAutoscalingGroupTagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.value", "value1updated"),
+					resource.TestCheckResourceAttr(resourceName, "tag.0.propagate_at_launch", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAutoscalingGroupTagDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_autoscaling_group_tag" {
+			continue
+		}
+
+		asgName, key, err := extractAutoscalingGroupNameAndKeyFromTagID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		exists, _, err := keyvaluetags.AutoscalingGetTag(conn, asgName, autoscalingTagResourceTypeAutoScalingGroup, key)
+
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Tag (%s) for resource (%s) still exists", key, asgName)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAutoscalingGroupTagExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		asgName, key, err := extractAutoscalingGroupNameAndKeyFromTagID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		exists, _, err := keyvaluetags.AutoscalingGetTag(conn, asgName, autoscalingTagResourceTypeAutoScalingGroup, key)
+
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("Tag (%s) for resource (%s) not found", key, asgName)
+		}
+
+		return nil
+	}
+}
+
+// The group manages its own tag, which must not conflict with the tag managed separately.
+func testAccAutoscalingGroupTagConfig(rName, key, value string, propagateAtLaunch bool) string {
+	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_configuration" "test" {
+  image_id      = data.aws_ami.test.id
+  instance_type = "t3.micro"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  launch_configuration = aws_launch_configuration.test.name
+
+  tag {
+    key                 = "Name"
+    value               = %[1]q
+    propagate_at_launch = true
+  }
+}
+
+resource "aws_autoscaling_group_tag" "test" {
+  autoscaling_group_name = aws_autoscaling_group.test.name
+
+  tag {
+    key                 = %[2]q
+    value               = %[3]q
+    propagate_at_launch = %[4]t
+  }
+}
+`, rName, key, value, propagateAtLaunch))
+}
@@ -7,16 +7,21 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -27,6 +32,19 @@ func init() {
 	})
 }
 
+// autoScalingGroupSweepNamePrefixes are the name prefixes of Auto Scaling Groups
+// created by the acceptance tests. The "tf-asg-" prefix of generated names is
+// deliberately absent, as it is shared by every unnamed group in the account.
+var autoScalingGroupSweepNamePrefixes = []string{
+	"asg_pg_",
+	"terraform-test-",
+	"tf-acc-test-",
+	"tf-test-",
+}
+
+// autoScalingGroupSweepWorkers bounds the number of groups deleted in parallel.
+const autoScalingGroupSweepWorkers = 10
+
 func testSweepAutoscalingGroups(region string) error {
 	client, err := sharedClientForRegion(region)
 	if err != nil {
@@ -34,49 +52,96 @@ func testSweepAutoscalingGroups(region string) error {
 	}
 	conn := client.(*AWSClient).autoscalingconn
 
-	resp, err := conn.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{})
-	if err != nil {
-		if testSweepSkipSweepError(err) {
-			log.Printf("[WARN] Skipping Auto Scaling Group sweep for %s: %s", region, err)
-			return nil
+	var names []string
+
+	err = conn.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{}, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		for _, asg := range page.AutoScalingGroups {
+			if name := aws.StringValue(asg.AutoScalingGroupName); isAutoScalingGroupSweepable(name) {
+				names = append(names, name)
+			}
 		}
-		return fmt.Errorf("Error retrieving Auto Scaling Groups in Sweeper: %s", err)
+
+		return !lastPage
+	})
+
+	if testSweepSkipSweepError(err) {
+		log.Printf("[WARN] Skipping Auto Scaling Group sweep for %s: %s", region, err)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error listing Auto Scaling Groups: %w", err)
 	}
 
-	if len(resp.AutoScalingGroups) == 0 {
+	if len(names) == 0 {
 		log.Print("[DEBUG] No Auto Scaling Groups to sweep")
 		return nil
 	}
 
-	for _, asg := range resp.AutoScalingGroups {
-		deleteopts := autoscaling.DeleteAutoScalingGroupInput{
-			AutoScalingGroupName: asg.AutoScalingGroupName,
-			ForceDelete:          aws.Bool(true),
-		}
+	var sweeperErrs *multierror.Error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, autoScalingGroupSweepWorkers)
 
-		err = resource.Retry(5*time.Minute, func() *resource.RetryError {
-			if _, err := conn.DeleteAutoScalingGroup(&deleteopts); err != nil {
-				if awserr, ok := err.(awserr.Error); ok {
-					switch awserr.Code() {
-					case "InvalidGroup.NotFound":
-						return nil
-					case "ResourceInUse", "ScalingActivityInProgress":
-						return resource.RetryableError(awserr)
-					}
-				}
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
 
-				// Didn't recognize the error, so shouldn't retry.
-				return resource.NonRetryableError(err)
+			log.Printf("[INFO] Deleting Auto Scaling Group: %s", name)
+
+			if err := testSweepAutoscalingGroupDelete(conn, name); err != nil {
+				mu.Lock()
+				sweeperErrs = multierror.Append(sweeperErrs, fmt.Errorf("error deleting Auto Scaling Group (%s): %w", name, err))
+				mu.Unlock()
 			}
-			// Successful delete
-			return nil
-		})
-		if err != nil {
-			return err
+		}(name)
+	}
+
+	wg.Wait()
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+func testSweepAutoscalingGroupDelete(conn *autoscaling.AutoScaling, name string) error {
+	deleteopts := autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		ForceDelete:          aws.Bool(true),
+	}
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		if _, err := conn.DeleteAutoScalingGroup(&deleteopts); err != nil {
+			if awserr, ok := err.(awserr.Error); ok {
+				switch awserr.Code() {
+				case "InvalidGroup.NotFound":
+					return nil
+				case "ResourceInUse", "ScalingActivityInProgress":
+					return resource.RetryableError(awserr)
+				}
+			}
+
+			// Didn't recognize the error, so shouldn't retry.
+			return resource.NonRetryableError(err)
+		}
+		// Successful delete
+		return nil
+	})
+}
+
+// isAutoScalingGroupSweepable returns whether a group was created by the acceptance tests.
+func isAutoScalingGroupSweepable(name string) bool {
+	for _, prefix := range autoScalingGroupSweepNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
 		}
 	}
 
-	return nil
+	return false
 }
 
 func TestAccAWSAutoScalingGroup_basic(t *testing.T) {
@@ -127,6 +192,14 @@ func TestAccAWSAutoScalingGroup_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "vpc_zone_identifier.#", "0"),
 					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "max_instance_lifetime", "0"),
 					resource.TestCheckNoResourceAttr("aws_autoscaling_group.bar", "instance_refresh.#"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "instances_per_availability_zone.%", "1"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "instances.#", "4"),
+					resource.TestCheckResourceAttrPair("aws_autoscaling_group.bar", "instances.0.availability_zone", "data.aws_availability_zones.available", "names.0"),
+					resource.TestMatchResourceAttr("aws_autoscaling_group.bar", "instances.0.instance_id", regexp.MustCompile(`^i-`)),
+					resource.TestCheckResourceAttrPair("aws_autoscaling_group.bar", "instances.0.launch_configuration_name", "aws_launch_configuration.foobar", "name"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "instances.0.launch_template.#", "0"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "instances.0.lifecycle_state", autoscaling.LifecycleStateInService),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "instances.0.protected_from_scale_in", "false"),
 				),
 			},
 			{
@@ -135,7 +208,21 @@ func TestAccAWSAutoScalingGroup_basic(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
+					"instances",
+					"name_prefix",
+					"tag",
+					"tags",
+					"wait_for_capacity_timeout",
+					"wait_for_elb_capacity",
+				},
+			},
+			{
+				ResourceName:      "aws_autoscaling_group.bar",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAWSAutoScalingGroupImportStateIdFuncARN("aws_autoscaling_group.bar"),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_delete",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -194,7 +281,6 @@ func TestAccAWSAutoScalingGroup_namePrefix(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -229,7 +315,6 @@ func TestAccAWSAutoScalingGroup_autoGeneratedName(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -260,7 +345,6 @@ func TestAccAWSAutoScalingGroup_terminationPolicies(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -283,7 +367,6 @@ func TestAccAWSAutoScalingGroup_terminationPolicies(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -345,7 +428,6 @@ func TestAccAWSAutoScalingGroup_tags(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -376,6 +458,82 @@ func TestAccAWSAutoScalingGroup_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSAutoScalingGroup_tag(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfigTag(rName, "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":                 "key1",
+						"value":               "value1",
+						"propagate_at_launch": "true",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_delete",
+					"wait_for_capacity_timeout",
+				},
+			},
+			{
+				// A tag value changed outside of Terraform is detected.
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+					_, err := conn.CreateOrUpdateTags(&autoscaling.CreateOrUpdateTagsInput{
+						Tags: []*autoscaling.Tag{{
+							Key:               aws.String("key1"),
+							PropagateAtLaunch: aws.Bool(true),
+							ResourceId:        group.AutoScalingGroupName,
+							ResourceType:      aws.String(autoscalingTagResourceTypeAutoScalingGroup),
+							Value:             aws.String("changed"),
+						}},
+					})
+
+					if err != nil {
+						t.Fatalf("error updating Auto Scaling Group (%s) tags: %s", aws.StringValue(group.AutoScalingGroupName), err)
+					}
+				},
+				Config:             testAccAWSAutoScalingGroupConfigTag(rName, "value1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfigTagsPropagateAtLaunch(rName, "TRUE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAutoscalingTags(&group.Tags, "key1", map[string]interface{}{
+						"value":               "value1",
+						"propagate_at_launch": true,
+					}),
+				),
+			},
+			{
+				Config:      testAccAWSAutoScalingGroupConfigTagsPropagateAtLaunch(rName, "yes"),
+				ExpectError: regexp.MustCompile(`propagate_at_launch \(yes\) of tag key \(key1\) must be true or false`),
+			},
+			{
+				Config:      testAccAWSAutoScalingGroupConfigTagDuplicateKey(rName),
+				ExpectError: regexp.MustCompile(`tag key \(key1\) is defined more than once`),
+			},
+		},
+	})
+}
+
 func TestAccAWSAutoScalingGroup_VpcUpdates(t *testing.T) {
 	var group autoscaling.Group
 
@@ -401,7 +559,6 @@ func TestAccAWSAutoScalingGroup_VpcUpdates(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -410,7 +567,11 @@ func TestAccAWSAutoScalingGroup_VpcUpdates(t *testing.T) {
 				},
 			},
 			{
-				Config: testAccAWSAutoScalingGroupConfigWithVPCIdent(),
+				Config:      testAccAWSAutoScalingGroupConfigWithVPCIdent(false),
+				ExpectError: regexp.MustCompile(`set allow_az_change to true`),
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfigWithVPCIdent(true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
 					testAccCheckAWSAutoScalingGroupAttributesVPCZoneIdentifier(&group),
@@ -446,7 +607,6 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -481,7 +641,6 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer_ToTargetGroup(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -503,7 +662,6 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer_ToTargetGroup(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -525,7 +683,6 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer_ToTargetGroup(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -538,7 +695,7 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer_ToTargetGroup(t *testing.T) {
 }
 
 func TestAccAWSAutoScalingGroup_withPlacementGroup(t *testing.T) {
-	var group autoscaling.Group
+	var group, groupUpdated autoscaling.Group
 
 	randName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
 	resource.ParallelTest(t, resource.TestCase{
@@ -547,19 +704,27 @@ func TestAccAWSAutoScalingGroup_withPlacementGroup(t *testing.T) {
 		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSAutoScalingGroupConfig_withPlacementGroup(randName),
+				Config: testAccAWSAutoScalingGroupConfig_withPlacementGroup(randName, "test"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
 					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "placement_group", randName),
 				),
 			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_withPlacementGroup(randName, "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &groupUpdated),
+					testAccCheckAWSAutoScalingGroupNotRecreated(&group, &groupUpdated),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "name", randName),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "placement_group", randName+"-2"),
+				),
+			},
 			{
 				ResourceName:      "aws_autoscaling_group.bar",
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -594,7 +759,6 @@ func TestAccAWSAutoScalingGroup_enablingMetrics(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -602,6 +766,32 @@ func TestAccAWSAutoScalingGroup_enablingMetrics(t *testing.T) {
 					"wait_for_elb_capacity",
 				},
 			},
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+					// Without Metrics, all metrics are enabled.
+					_, err := conn.EnableMetricsCollection(&autoscaling.EnableMetricsCollectionInput{
+						AutoScalingGroupName: group.AutoScalingGroupName,
+						Granularity:          aws.String("1Minute"),
+					})
+
+					if err != nil {
+						t.Fatalf("error enabling Auto Scaling Group (%s) metrics collection: %s", aws.StringValue(group.AutoScalingGroupName), err)
+					}
+				},
+				Config:             testAccAWSAutoScalingGroupConfig(randName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupEnabledMetrics(&group),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "enabled_metrics.#", "0"),
+				),
+			},
 			{
 				Config: testAccAWSAutoscalingMetricsCollectionConfig_updatingMetricsCollected(),
 				Check: resource.ComposeTestCheckFunc(
@@ -610,6 +800,32 @@ func TestAccAWSAutoScalingGroup_enablingMetrics(t *testing.T) {
 						"aws_autoscaling_group.bar", "enabled_metrics.#", "5"),
 				),
 			},
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+					_, err := conn.EnableMetricsCollection(&autoscaling.EnableMetricsCollectionInput{
+						AutoScalingGroupName: group.AutoScalingGroupName,
+						Granularity:          aws.String("1Minute"),
+						Metrics:              aws.StringSlice([]string{"GroupMinSize"}),
+					})
+
+					if err != nil {
+						t.Fatalf("error enabling Auto Scaling Group (%s) metrics collection: %s", aws.StringValue(group.AutoScalingGroupName), err)
+					}
+				},
+				Config:             testAccAWSAutoscalingMetricsCollectionConfig_updatingMetricsCollected(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSAutoscalingMetricsCollectionConfig_updatingMetricsCollected(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupEnabledMetrics(&group, "GroupDesiredCapacity", "GroupMaxSize", "GroupPendingInstances", "GroupTerminatingInstances", "GroupTotalInstances"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "enabled_metrics.#", "5"),
+				),
+			},
 		},
 	})
 }
@@ -645,7 +861,6 @@ func TestAccAWSAutoScalingGroup_suspendingProcesses(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -661,6 +876,163 @@ func TestAccAWSAutoScalingGroup_suspendingProcesses(t *testing.T) {
 						"aws_autoscaling_group.bar", "suspended_processes.#", "2"),
 				),
 			},
+			{
+				// A process resumed outside of Terraform is detected.
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+					_, err := conn.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+						AutoScalingGroupName: group.AutoScalingGroupName,
+						ScalingProcesses:     aws.StringSlice([]string{"AZRebalance"}),
+					})
+
+					if err != nil {
+						t.Fatalf("error resuming Auto Scaling Group (%s) processes: %s", aws.StringValue(group.AutoScalingGroupName), err)
+					}
+				},
+				Config:             testAccAWSAutoScalingGroupConfigWithSuspendedProcessesUpdated(randName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfigWithSuspendedProcessesUpdated(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupSuspendedProcesses(&group, "AZRebalance", "ScheduledActions"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "suspended_processes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("aws_autoscaling_group.bar", "suspended_processes.*", "AZRebalance"),
+					resource.TestCheckTypeSetElemAttr("aws_autoscaling_group.bar", "suspended_processes.*", "ScheduledActions"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAutoScalingGroupEnabledMetrics(group *autoscaling.Group, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		got := flattenAsgEnabledMetrics(group.EnabledMetrics)
+
+		if expected == nil {
+			expected = []string{}
+		}
+
+		sort.Strings(got)
+		sort.Strings(expected)
+
+		if !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("expected enabled metrics %v, got %v", expected, got)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoScalingGroupSuspendedProcesses(group *autoscaling.Group, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		got := flattenAsgSuspendedProcesses(group.SuspendedProcesses)
+
+		sort.Strings(got)
+		sort.Strings(expected)
+
+		if !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("expected suspended processes %v, got %v", expected, got)
+		}
+
+		return nil
+	}
+}
+
+func TestAutoScalingGroupSuspendedProcessesDelta(t *testing.T) {
+	testCases := []struct {
+		name            string
+		old             []interface{}
+		new             []interface{}
+		expectedResume  []string
+		expectedSuspend []string
+	}{
+		{
+			name: "unchanged",
+			old:  []interface{}{"AZRebalance"},
+			new:  []interface{}{"AZRebalance"},
+		},
+		{
+			name:            "added",
+			old:             []interface{}{"AZRebalance"},
+			new:             []interface{}{"AZRebalance", "ScheduledActions"},
+			expectedSuspend: []string{"ScheduledActions"},
+		},
+		{
+			name:           "removed",
+			old:            []interface{}{"AZRebalance", "ScheduledActions"},
+			new:            []interface{}{"AZRebalance"},
+			expectedResume: []string{"ScheduledActions"},
+		},
+		{
+			name:            "replaced",
+			old:             []interface{}{"AZRebalance", "Launch"},
+			new:             []interface{}{"AZRebalance", "Terminate"},
+			expectedResume:  []string{"Launch"},
+			expectedSuspend: []string{"Terminate"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resume, suspend := autoScalingGroupSuspendedProcessesDelta(
+				schema.NewSet(schema.HashString, testCase.old),
+				schema.NewSet(schema.HashString, testCase.new),
+			)
+
+			if got := aws.StringValueSlice(resume); len(got) != len(testCase.expectedResume) || (len(got) > 0 && !reflect.DeepEqual(got, testCase.expectedResume)) {
+				t.Errorf("got resume %v, expected %v", got, testCase.expectedResume)
+			}
+
+			if got := aws.StringValueSlice(suspend); len(got) != len(testCase.expectedSuspend) || (len(got) > 0 && !reflect.DeepEqual(got, testCase.expectedSuspend)) {
+				t.Errorf("got suspend %v, expected %v", got, testCase.expectedSuspend)
+			}
+		})
+	}
+}
+
+func TestAccAWSAutoScalingGroup_Paused(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfig_Paused(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAWSAutoScalingGroupSuspendedProcessCount(&group, 0),
+					resource.TestCheckResourceAttr(resourceName, "paused", "false"),
+					resource.TestCheckResourceAttr(resourceName, "paused_processes.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "suspended_processes.#", "0"),
+				),
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_Paused(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAWSAutoScalingGroupPausedProcesses(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "paused", "true"),
+					resource.TestCheckResourceAttr(resourceName, "suspended_processes.#", "0"),
+				),
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_Paused(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAWSAutoScalingGroupSuspendedProcessCount(&group, 0),
+					resource.TestCheckResourceAttr(resourceName, "paused", "false"),
+					resource.TestCheckResourceAttr(resourceName, "paused_processes.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "suspended_processes.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -687,7 +1059,6 @@ func TestAccAWSAutoScalingGroup_withMetrics(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -703,6 +1074,32 @@ func TestAccAWSAutoScalingGroup_withMetrics(t *testing.T) {
 						"aws_autoscaling_group.bar", "enabled_metrics.#", "5"),
 				),
 			},
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+					_, err := conn.EnableMetricsCollection(&autoscaling.EnableMetricsCollectionInput{
+						AutoScalingGroupName: group.AutoScalingGroupName,
+						Granularity:          aws.String("1Minute"),
+						Metrics:              aws.StringSlice([]string{"GroupInServiceInstances", "GroupStandbyInstances"}),
+					})
+
+					if err != nil {
+						t.Fatalf("error enabling Auto Scaling Group (%s) metrics collection: %s", aws.StringValue(group.AutoScalingGroupName), err)
+					}
+				},
+				Config:             testAccAWSAutoscalingMetricsCollectionConfig_updatingMetricsCollected(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSAutoscalingMetricsCollectionConfig_updatingMetricsCollected(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupEnabledMetrics(&group, "GroupDesiredCapacity", "GroupMaxSize", "GroupPendingInstances", "GroupTerminatingInstances", "GroupTotalInstances"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "enabled_metrics.#", "5"),
+				),
+			},
 		},
 	})
 }
@@ -729,7 +1126,6 @@ func TestAccAWSAutoScalingGroup_serviceLinkedRoleARN(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -741,8 +1137,10 @@ func TestAccAWSAutoScalingGroup_serviceLinkedRoleARN(t *testing.T) {
 	})
 }
 
-func TestAccAWSAutoScalingGroup_MaxInstanceLifetime(t *testing.T) {
+func TestAccAWSAutoScalingGroup_InitialDesiredCapacity(t *testing.T) {
 	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -750,21 +1148,214 @@ func TestAccAWSAutoScalingGroup_MaxInstanceLifetime(t *testing.T) {
 		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSAutoScalingGroupConfig_withMaxInstanceLifetime(),
+				Config: testAccAWSAutoScalingGroupConfig_InitialDesiredCapacity(rName, 1),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
-					resource.TestCheckResourceAttr(
-						"aws_autoscaling_group.bar", "max_instance_lifetime", "864000"),
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "initial_desired_capacity", "1"),
+					resource.TestCheckResourceAttr(resourceName, "desired_capacity", "1"),
+					// Simulate a deployment controller taking over the desired capacity.
+					testAccCheckAWSAutoScalingGroupSetDesiredCapacity(&group, 2),
 				),
 			},
 			{
-				ResourceName:      "aws_autoscaling_group.bar",
+				// Live desired capacity drift does not produce a diff.
+				Config:   testAccAWSAutoScalingGroupConfig_InitialDesiredCapacity(rName, 1),
+				PlanOnly: true,
+			},
+			{
+				// Changing initial_desired_capacity after creation does not produce a diff.
+				Config:   testAccAWSAutoScalingGroupConfig_InitialDesiredCapacity(rName, 0),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      resourceName,
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
-					"name_prefix",
+					"initial_desired_capacity",
+					"wait_for_capacity_timeout",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_IgnoreDesiredCapacityChanges(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfig_IgnoreDesiredCapacityChanges(rName, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "ignore_desired_capacity_changes", "true"),
+					resource.TestCheckResourceAttr(resourceName, "desired_capacity", "1"),
+					// Simulate a scaling policy adjusting the desired capacity.
+					testAccCheckAWSAutoScalingGroupSetDesiredCapacity(&group, 2),
+				),
+			},
+			{
+				// Live desired capacity drift does not produce a diff.
+				Config:   testAccAWSAutoScalingGroupConfig_IgnoreDesiredCapacityChanges(rName, 1, 2),
+				PlanOnly: true,
+			},
+			{
+				// Lowering max_size below the live desired capacity lowers the desired capacity.
+				Config: testAccAWSAutoScalingGroupConfig_IgnoreDesiredCapacityChanges(rName, 0, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "desired_capacity", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_size", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_delete",
+					"ignore_desired_capacity_changes",
+					"wait_for_capacity_timeout",
+				},
+			},
+		},
+	})
+}
+
+func TestExpandAutoScalingLaunchTemplateOverride(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    map[string]interface{}
+		expected *autoscaling.LaunchTemplateOverrides
+	}{
+		{
+			name: "instance type only",
+			input: map[string]interface{}{
+				"instance_type":                 "t3.micro",
+				"launch_template_specification": []interface{}{},
+				"weighted_capacity":             "",
+			},
+			expected: &autoscaling.LaunchTemplateOverrides{
+				InstanceType: aws.String("t3.micro"),
+			},
+		},
+		{
+			name: "launch template specification",
+			input: map[string]interface{}{
+				"instance_type": "t4g.micro",
+				"launch_template_specification": []interface{}{
+					map[string]interface{}{
+						"launch_template_id":   "lt-12345678",
+						"launch_template_name": "test",
+						"version":              "",
+					},
+				},
+				"weighted_capacity": "2",
+			},
+			expected: &autoscaling.LaunchTemplateOverrides{
+				InstanceType: aws.String("t4g.micro"),
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateId: aws.String("lt-12345678"),
+				},
+				WeightedCapacity: aws.String("2"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := expandAutoScalingLaunchTemplateOverride(testCase.input)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %s, expected %s", got, testCase.expected)
+			}
+
+			flattened := flattenAutoScalingLaunchTemplateOverrides([]*autoscaling.LaunchTemplateOverrides{got})
+
+			if got, expected := len(flattened[0].(map[string]interface{})["launch_template_specification"].([]interface{})), len(testCase.input["launch_template_specification"].([]interface{})); got != expected {
+				t.Errorf("got %d flattened launch template specifications, expected %d", got, expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupDesiredCapacityWithinSize(t *testing.T) {
+	testCases := []struct {
+		name            string
+		desiredCapacity int
+		minSize         int
+		maxSize         int
+		expected        *int64
+	}{
+		{
+			name:            "within size",
+			desiredCapacity: 2,
+			minSize:         1,
+			maxSize:         3,
+		},
+		{
+			name:            "at bounds",
+			desiredCapacity: 3,
+			minSize:         3,
+			maxSize:         3,
+		},
+		{
+			name:            "below minimum",
+			desiredCapacity: 0,
+			minSize:         1,
+			maxSize:         3,
+			expected:        aws.Int64(1),
+		},
+		{
+			name:            "above maximum",
+			desiredCapacity: 5,
+			minSize:         1,
+			maxSize:         3,
+			expected:        aws.Int64(3),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupDesiredCapacityWithinSize(testCase.desiredCapacity, testCase.minSize, testCase.maxSize)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", aws.Int64Value(got), aws.Int64Value(testCase.expected))
+			}
+		})
+	}
+}
+
+func TestAccAWSAutoScalingGroup_MaxInstanceLifetime(t *testing.T) {
+	var group autoscaling.Group
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfig_withMaxInstanceLifetime(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "max_instance_lifetime", "864000"),
+				),
+			},
+			{
+				ResourceName:      "aws_autoscaling_group.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_delete",
+					"name_prefix",
 					"tag",
 					"tags",
 					"wait_for_capacity_timeout",
@@ -842,7 +1433,6 @@ func TestAccAWSAutoScalingGroup_ALB_TargetGroups(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -888,7 +1478,6 @@ func TestAccAWSAutoScalingGroup_TargetGroupArns(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -951,10 +1540,179 @@ func TestAccAWSAutoScalingGroup_initialLifecycleHook(t *testing.T) {
 					"wait_for_elb_capacity",
 				},
 			},
+			{
+				Config: testAccAWSAutoScalingGroupWithHookConfigUpdated(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupLifecycleHookNames("aws_autoscaling_group.bar", "launching", "separate", "terminating"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "initial_lifecycle_hook.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aws_autoscaling_group.bar", "initial_lifecycle_hook.*", map[string]string{
+						"default_result":    "CONTINUE",
+						"heartbeat_timeout": "60",
+						"name":              "launching",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aws_autoscaling_group.bar", "initial_lifecycle_hook.*", map[string]string{
+						"default_result":    "ABANDON",
+						"heartbeat_timeout": "3600",
+						"name":              "terminating",
+					}),
+				),
+			},
+			{
+				Config: testAccAWSAutoScalingGroupWithHookConfigRemoved(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					// Hooks that were never in initial_lifecycle_hook are not deleted.
+					testAccCheckAWSAutoScalingGroupLifecycleHookNames("aws_autoscaling_group.bar", "separate"),
+					resource.TestCheckResourceAttr("aws_autoscaling_group.bar", "initial_lifecycle_hook.#", "0"),
+				),
+			},
 		},
 	})
 }
 
+func testAccCheckAWSAutoScalingGroupLifecycleHookNames(resourceName string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		hooks, err := getAwsAutoscalingGroupLifecycleHooks(conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		got := []string{}
+
+		for _, hook := range hooks {
+			got = append(got, aws.StringValue(hook.LifecycleHookName))
+		}
+
+		if expected == nil {
+			expected = []string{}
+		}
+
+		sort.Strings(got)
+		sort.Strings(expected)
+
+		if !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("expected lifecycle hooks %v, got %v", expected, got)
+		}
+
+		return nil
+	}
+}
+
+func TestAutoScalingGroupInitialLifecycleHooksDelta(t *testing.T) {
+	launching := map[string]interface{}{
+		"default_result":       "CONTINUE",
+		"heartbeat_timeout":    30,
+		"lifecycle_transition": "autoscaling:EC2_INSTANCE_LAUNCHING",
+		"name":                 "launching",
+	}
+	launchingUpdated := map[string]interface{}{
+		"default_result":       "CONTINUE",
+		"heartbeat_timeout":    60,
+		"lifecycle_transition": "autoscaling:EC2_INSTANCE_LAUNCHING",
+		"name":                 "launching",
+	}
+	terminating := map[string]interface{}{
+		"default_result":       "ABANDON",
+		"heartbeat_timeout":    3600,
+		"lifecycle_transition": "autoscaling:EC2_INSTANCE_TERMINATING",
+		"name":                 "terminating",
+	}
+
+	testCases := []struct {
+		name           string
+		old            []interface{}
+		new            []interface{}
+		expectedRemove []string
+		expectedPut    []interface{}
+	}{
+		{
+			name: "unchanged",
+			old:  []interface{}{launching},
+			new:  []interface{}{launching},
+		},
+		{
+			name:        "added",
+			old:         []interface{}{launching},
+			new:         []interface{}{launching, terminating},
+			expectedPut: []interface{}{terminating},
+		},
+		{
+			name:        "changed",
+			old:         []interface{}{launching, terminating},
+			new:         []interface{}{launchingUpdated, terminating},
+			expectedPut: []interface{}{launchingUpdated},
+		},
+		{
+			name:           "removed",
+			old:            []interface{}{terminating, launching},
+			new:            []interface{}{},
+			expectedRemove: []string{"launching", "terminating"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			remove, put := autoScalingGroupInitialLifecycleHooksDelta(testCase.old, testCase.new)
+
+			if !reflect.DeepEqual(remove, testCase.expectedRemove) {
+				t.Errorf("got remove %v, expected %v", remove, testCase.expectedRemove)
+			}
+
+			if !reflect.DeepEqual(put, testCase.expectedPut) {
+				t.Errorf("got put %v, expected %v", put, testCase.expectedPut)
+			}
+		})
+	}
+}
+
+func TestFlattenAutoScalingGroupInitialLifecycleHooks(t *testing.T) {
+	hooks := []*autoscaling.LifecycleHook{
+		{
+			DefaultResult:       aws.String("CONTINUE"),
+			HeartbeatTimeout:    aws.Int64(30),
+			LifecycleHookName:   aws.String("launching"),
+			LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+		},
+		nil,
+		{
+			DefaultResult:       aws.String("ABANDON"),
+			HeartbeatTimeout:    aws.Int64(3600),
+			LifecycleHookName:   aws.String("managed-separately"),
+			LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+		},
+	}
+
+	if got := flattenAutoScalingGroupInitialLifecycleHooks(hooks, nil); len(got) != 0 {
+		t.Errorf("got %d hooks without names, expected 0", len(got))
+	}
+
+	got := flattenAutoScalingGroupInitialLifecycleHooks(hooks, map[string]bool{"launching": true})
+	expected := []interface{}{
+		map[string]interface{}{
+			"default_result":          "CONTINUE",
+			"heartbeat_timeout":       30,
+			"lifecycle_transition":    "autoscaling:EC2_INSTANCE_LAUNCHING",
+			"name":                    "launching",
+			"notification_metadata":   "",
+			"notification_target_arn": "",
+			"role_arn":                "",
+		},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
 func TestAccAWSAutoScalingGroup_ALB_TargetGroups_ELBCapacity(t *testing.T) {
 	var group autoscaling.Group
 	var tg elbv2.TargetGroup
@@ -980,7 +1738,6 @@ func TestAccAWSAutoScalingGroup_ALB_TargetGroups_ELBCapacity(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1083,9 +1840,10 @@ func TestAccAWSAutoScalingGroup_InstanceRefresh_Start(t *testing.T) {
 	})
 }
 
-func TestAccAWSAutoScalingGroup_InstanceRefresh_Triggers(t *testing.T) {
+func TestAccAWSAutoScalingGroup_LaunchTemplate_DefaultVersion(t *testing.T) {
 	var group autoscaling.Group
 	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -1093,49 +1851,371 @@ func TestAccAWSAutoScalingGroup_InstanceRefresh_Triggers(t *testing.T) {
 		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_Basic(),
+				Config: testAccAwsAutoScalingGroupConfig_LaunchTemplate_DefaultVersion(rName, "t3.nano"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
-					resource.TestCheckResourceAttr(resourceName, "instance_refresh.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.strategy", "Rolling"),
-					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.preferences.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "$Default"),
 				),
 			},
 			{
-				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_Triggers(),
+				// Only the launch template's default version changes.
+				Config: testAccAwsAutoScalingGroupConfig_LaunchTemplate_DefaultVersion(rName, "t3.micro"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
-					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.triggers.#", "1"),
-					resource.TestCheckTypeSetElemAttr(resourceName, "instance_refresh.0.triggers.*", "tags"),
-					testAccCheckAutoScalingInstanceRefreshCount(&group, 1),
-					testAccCheckAutoScalingInstanceRefreshStatus(&group, 0, autoscaling.InstanceRefreshStatusPending, autoscaling.InstanceRefreshStatusInProgress),
+					resource.TestCheckResourceAttr("aws_launch_template.test", "default_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "$Default"),
+					testAccCheckAutoScalingInstanceRefreshCount(&group, 0),
 				),
 			},
+			{
+				Config:   testAccAwsAutoScalingGroupConfig_LaunchTemplate_DefaultVersion(rName, "t3.micro"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func testAccCheckAWSAutoScalingGroupExists(n string, group *autoscaling.Group) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Not found: %s", n)
-		}
-
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("No Auto Scaling Group ID is set")
-		}
-
-		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+func TestAccAWSAutoScalingGroup_InstanceRefresh_LaunchTemplateTrigger(t *testing.T) {
+	var group autoscaling.Group
+	var launchTemplate ec2.LaunchTemplate
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
 
-		describeGroups, err := conn.DescribeAutoScalingGroups(
-			&autoscaling.DescribeAutoScalingGroupsInput{
-				AutoScalingGroupNames: []*string{aws.String(rs.Primary.ID)},
-			})
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_LaunchTemplateTrigger(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAWSLaunchTemplateExists("aws_launch_template.test", &launchTemplate),
+					resource.TestCheckResourceAttr(resourceName, "launch_template_resolved_version", "1"),
+					testAccCheckAutoScalingInstanceRefreshCount(&group, 0),
+				),
+			},
+			{
+				// Publish a new launch template version outside of Terraform.
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+					_, err := conn.CreateLaunchTemplateVersion(&ec2.CreateLaunchTemplateVersionInput{
+						LaunchTemplateId: launchTemplate.LaunchTemplateId,
+						SourceVersion:    aws.String("1"),
+						LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+							InstanceType: aws.String("t3.micro"),
+						},
+					})
+
+					if err != nil {
+						t.Fatalf("error creating launch template version: %s", err)
+					}
+				},
+				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_LaunchTemplateTrigger(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "launch_template_resolved_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "$Latest"),
+					testAccCheckAutoScalingInstanceRefreshCount(&group, 1),
+				),
+			},
+			{
+				Config:   testAccAwsAutoScalingGroupConfig_InstanceRefresh_LaunchTemplateTrigger(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
 
-		if err != nil {
-			return err
-		}
+func TestResolveLaunchTemplateVersion(t *testing.T) {
+	launchTemplate := &ec2.LaunchTemplate{
+		DefaultVersionNumber: aws.Int64(2),
+		LatestVersionNumber:  aws.Int64(3),
+	}
+
+	testCases := []struct {
+		name           string
+		version        string
+		launchTemplate *ec2.LaunchTemplate
+		expected       string
+	}{
+		{
+			name:           "not specified",
+			launchTemplate: launchTemplate,
+			expected:       "2",
+		},
+		{
+			name:           "default",
+			version:        "$Default",
+			launchTemplate: launchTemplate,
+			expected:       "2",
+		},
+		{
+			name:           "latest",
+			version:        "$Latest",
+			launchTemplate: launchTemplate,
+			expected:       "3",
+		},
+		{
+			name:           "number",
+			version:        "1",
+			launchTemplate: launchTemplate,
+			expected:       "1",
+		},
+		{
+			name:    "not found",
+			version: "$Latest",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := resolveLaunchTemplateVersion(testCase.version, testCase.launchTemplate)
+
+			if got != testCase.expected {
+				t.Errorf("got %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateVersionEquivalent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		symbolic string
+		number   string
+		expected bool
+	}{
+		{
+			name:     "default",
+			symbolic: "$Default",
+			number:   "2",
+			expected: true,
+		},
+		{
+			name:     "not default",
+			symbolic: "$Default",
+			number:   "3",
+		},
+		{
+			name:     "latest",
+			symbolic: "$Latest",
+			number:   "3",
+			expected: true,
+		},
+		{
+			name:     "not latest",
+			symbolic: "$Latest",
+			number:   "2",
+		},
+		{
+			name:     "both symbolic",
+			symbolic: "$Default",
+			number:   "$Default",
+		},
+		{
+			name:     "both numeric",
+			symbolic: "2",
+			number:   "2",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Default version 2, latest version 3.
+			got := launchTemplateVersionEquivalent(testCase.symbolic, testCase.number, 2, 3)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateVersionsEquivalent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{
+			name:     "default and number",
+			a:        "$Default",
+			b:        "2",
+			expected: true,
+		},
+		{
+			name:     "number and default",
+			a:        "2",
+			b:        "$Default",
+			expected: true,
+		},
+		{
+			name:     "number and latest",
+			a:        "3",
+			b:        "$Latest",
+			expected: true,
+		},
+		{
+			name: "number and not default",
+			a:    "3",
+			b:    "$Default",
+		},
+		{
+			name: "default and latest",
+			a:    "$Default",
+			b:    "$Latest",
+		},
+		{
+			name: "different numbers",
+			a:    "2",
+			b:    "3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Default version 2, latest version 3.
+			got := launchTemplateVersionsEquivalent(testCase.a, testCase.b, 2, 3)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAccAWSAutoScalingGroup_InstanceRefresh_Triggers(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_Basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.strategy", "Rolling"),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.preferences.#", "0"),
+				),
+			},
+			{
+				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_Triggers(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.triggers.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "instance_refresh.0.triggers.*", "tags"),
+					testAccCheckAutoScalingInstanceRefreshCount(&group, 1),
+					testAccCheckAutoScalingInstanceRefreshStatus(&group, 0, autoscaling.InstanceRefreshStatusPending, autoscaling.InstanceRefreshStatusInProgress),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_InstanceRefresh_Failed(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsAutoScalingGroupConfig_InstanceRefresh_Failed(rName, false, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.failure_check_timeout", "10m"),
+					testAccCheckAutoScalingInstanceRefreshCount(&group, 0),
+				),
+			},
+			{
+				// The new launch template version references a security group that does not exist,
+				// so replacement instances fail to launch and the refresh fails.
+				Config:      testAccAwsAutoScalingGroupConfig_InstanceRefresh_Failed(rName, true, "two"),
+				ExpectError: regexp.MustCompile(`Instance Refresh failed`),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAutoScalingGroupSetDesiredCapacity(group *autoscaling.Group, desiredCapacity int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		_, err := conn.SetDesiredCapacity(&autoscaling.SetDesiredCapacityInput{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+			DesiredCapacity:      aws.Int64(int64(desiredCapacity)),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error setting Auto Scaling Group (%s) desired capacity: %w", aws.StringValue(group.AutoScalingGroupName), err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoScalingGroupSuspendedProcessCount(group *autoscaling.Group, n int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if got := len(group.SuspendedProcesses); got != n {
+			return fmt.Errorf("expected %d suspended processes, got %d", n, got)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSAutoScalingGroupPausedProcesses checks that every suspended process is recorded in paused_processes.
+func testAccCheckAWSAutoScalingGroupPausedProcesses(n string, group *autoscaling.Group) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(group.SuspendedProcesses) == 0 {
+			return fmt.Errorf("expected suspended processes, got none")
+		}
+
+		if err := resource.TestCheckResourceAttr(n, "paused_processes.#", strconv.Itoa(len(group.SuspendedProcesses)))(s); err != nil {
+			return err
+		}
+
+		for _, process := range group.SuspendedProcesses {
+			if err := resource.TestCheckTypeSetElemAttr(n, "paused_processes.*", aws.StringValue(process.ProcessName))(s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoScalingGroupExists(n string, group *autoscaling.Group) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Auto Scaling Group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		describeGroups, err := conn.DescribeAutoScalingGroups(
+			&autoscaling.DescribeAutoScalingGroupsInput{
+				AutoScalingGroupNames: []*string{aws.String(rs.Primary.ID)},
+			})
+
+		if err != nil {
+			return err
+		}
 
 		if len(describeGroups.AutoScalingGroups) != 1 ||
 			*describeGroups.AutoScalingGroups[0].AutoScalingGroupName != rs.Primary.ID {
@@ -1399,7 +2479,6 @@ func TestAccAWSAutoScalingGroup_classicVpcZoneIdentifier(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1433,7 +2512,6 @@ func TestAccAWSAutoScalingGroup_launchTemplate(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1467,7 +2545,6 @@ func TestAccAWSAutoScalingGroup_launchTemplate_update(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1522,6 +2599,67 @@ func TestAccAWSAutoScalingGroup_launchTemplate_update(t *testing.T) {
 	})
 }
 
+func TestAccAWSAutoScalingGroup_LaunchTemplate_representation(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, "name", `"$Default"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttrPair(resourceName, "launch_template.0.id", "aws_launch_template.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "launch_template.0.name", "aws_launch_template.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "$Default"),
+				),
+			},
+			{
+				Config:   testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, "id", `"$Default"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, "id", "aws_launch_template.test.default_version"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAutoScalingGroupLaunchTemplateVersion(&group, "1"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "1"),
+				),
+			},
+			{
+				Config:   testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, "name", "aws_launch_template.test.default_version"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, "name", `"$Default"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					testAccCheckAutoScalingGroupLaunchTemplateVersion(&group, "$Default"),
+					resource.TestCheckResourceAttr(resourceName, "launch_template.0.version", "$Default"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAutoScalingGroupLaunchTemplateVersion(group *autoscaling.Group, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if group.LaunchTemplate == nil {
+			return fmt.Errorf("expected launch template version %q, got no launch template", expected)
+		}
+
+		if got := aws.StringValue(group.LaunchTemplate.Version); got != expected {
+			return fmt.Errorf("expected launch template version %q, got %q", expected, got)
+		}
+
+		return nil
+	}
+}
+
 func TestAccAWSAutoScalingGroup_LaunchTemplate_IAMInstanceProfile(t *testing.T) {
 	var group autoscaling.Group
 	resourceName := "aws_autoscaling_group.test"
@@ -1544,7 +2682,6 @@ func TestAccAWSAutoScalingGroup_LaunchTemplate_IAMInstanceProfile(t *testing.T)
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1580,7 +2717,6 @@ func TestAccAWSAutoScalingGroup_LoadBalancers(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1606,6 +2742,26 @@ func TestAccAWSAutoScalingGroup_LoadBalancers(t *testing.T) {
 	})
 }
 
+func TestAccAWSAutoScalingGroup_FailedScalingActivity(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSAutoScalingGroupConfig_FailedScalingActivity(rName, false),
+				ExpectError: regexp.MustCompile(`scaling activity \(.+\) failed`),
+			},
+			{
+				Config:      testAccAWSAutoScalingGroupConfig_FailedScalingActivity(rName+"-ignore", true),
+				ExpectError: regexp.MustCompile(`timeout while waiting for state to become 'success'`),
+			},
+		},
+	})
+}
+
 func TestAccAWSAutoScalingGroup_MixedInstancesPolicy(t *testing.T) {
 	var group autoscaling.Group
 	resourceName := "aws_autoscaling_group.test"
@@ -1637,7 +2793,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1681,7 +2836,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_CapacityRebalance(t *testin
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1718,7 +2872,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_OnDem
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1755,7 +2908,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_OnDem
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1811,7 +2963,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_Updat
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1834,7 +2985,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_Updat
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1871,7 +3021,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_OnDem
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1917,7 +3066,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_SpotA
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -1954,7 +3102,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_SpotI
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2000,7 +3147,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_InstancesDistribution_SpotM
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2056,7 +3202,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_LaunchTempla
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2094,7 +3239,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_LaunchTempla
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2143,7 +3287,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_Ins
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2166,9 +3309,10 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_Ins
 	})
 }
 
-func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_WeightedCapacity(t *testing.T) {
+func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_LaunchTemplateSpecification(t *testing.T) {
 	var group autoscaling.Group
 	resourceName := "aws_autoscaling_group.test"
+	launchTemplateResourceName := "aws_launch_template.test_arm64"
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -2177,7 +3321,57 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_Wei
 		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_WeightedCapacity(rName),
+				Config: testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_LaunchTemplateSpecification(rName, "t4g.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.0.instance_type", "t2.micro"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.0.launch_template_specification.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.1.instance_type", "t4g.micro"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.1.launch_template_specification.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "mixed_instances_policy.0.launch_template.0.override.1.launch_template_specification.0.launch_template_id", launchTemplateResourceName, "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_delete",
+					"name_prefix",
+					"tag",
+					"tags",
+					"wait_for_capacity_timeout",
+					"wait_for_elb_capacity",
+				},
+			},
+			{
+				Config: testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_LaunchTemplateSpecification(rName, "t4g.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.0.launch_template.0.override.1.instance_type", "t4g.small"),
+					resource.TestCheckResourceAttrPair(resourceName, "mixed_instances_policy.0.launch_template.0.override.1.launch_template_specification.0.launch_template_id", launchTemplateResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_WeightedCapacity(t *testing.T) {
+	var group autoscaling.Group
+	resourceName := "aws_autoscaling_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_WeightedCapacity(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSAutoScalingGroupExists(resourceName, &group),
 					resource.TestCheckResourceAttr(resourceName, "mixed_instances_policy.#", "1"),
@@ -2195,7 +3389,6 @@ func TestAccAWSAutoScalingGroup_MixedInstancesPolicy_LaunchTemplate_Override_Wei
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2229,7 +3422,6 @@ func TestAccAWSAutoScalingGroup_launchTempPartitionNum(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"force_delete",
-					"initial_lifecycle_hook",
 					"name_prefix",
 					"tag",
 					"tags",
@@ -2443,6 +3635,87 @@ resource "aws_autoscaling_group" "bar" {
 `, name, name)
 }
 
+func testAccAWSAutoScalingGroupConfigTagBase(rName string) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		testAccLatestAmazonLinuxHvmEbsAmiConfig(),
+		fmt.Sprintf(`
+resource "aws_launch_configuration" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t2.micro"
+}
+`, rName))
+}
+
+func testAccAWSAutoScalingGroupConfigTag(rName, value string) string {
+	return composeConfig(
+		testAccAWSAutoScalingGroupConfigTagBase(rName),
+		fmt.Sprintf(`
+resource "aws_autoscaling_group" "test" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  launch_configuration = aws_launch_configuration.test.name
+
+  tag {
+    key                 = "key1"
+    value               = %[2]q
+    propagate_at_launch = true
+  }
+}
+`, rName, value))
+}
+
+func testAccAWSAutoScalingGroupConfigTagsPropagateAtLaunch(rName, propagateAtLaunch string) string {
+	return composeConfig(
+		testAccAWSAutoScalingGroupConfigTagBase(rName),
+		fmt.Sprintf(`
+resource "aws_autoscaling_group" "test" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  launch_configuration = aws_launch_configuration.test.name
+
+  tags = [
+    {
+      key                 = "key1"
+      value               = "value1"
+      propagate_at_launch = %[2]q
+    },
+  ]
+}
+`, rName, propagateAtLaunch))
+}
+
+func testAccAWSAutoScalingGroupConfigTagDuplicateKey(rName string) string {
+	return composeConfig(
+		testAccAWSAutoScalingGroupConfigTagBase(rName),
+		fmt.Sprintf(`
+resource "aws_autoscaling_group" "test" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  launch_configuration = aws_launch_configuration.test.name
+
+  tag {
+    key                 = "key1"
+    value               = "value1"
+    propagate_at_launch = true
+  }
+
+  tag {
+    key                 = "key1"
+    value               = "value2"
+    propagate_at_launch = true
+  }
+}
+`, rName))
+}
+
 func testAccAWSAutoScalingGroupConfigUpdate(name string) string {
 	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
 		fmt.Sprintf(`
@@ -2739,9 +4012,9 @@ resource "aws_autoscaling_group" "bar" {
 `)
 }
 
-func testAccAWSAutoScalingGroupConfigWithVPCIdent() string {
+func testAccAWSAutoScalingGroupConfigWithVPCIdent(allowAZChange bool) string {
 	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
-		`
+		fmt.Sprintf(`
 resource "aws_vpc" "default" {
   cidr_block = "10.0.0.0/16"
   tags = {
@@ -2777,15 +4050,26 @@ resource "aws_autoscaling_group" "bar" {
   vpc_zone_identifier = [
     aws_subnet.main.id,
   ]
+  allow_az_change      = %[1]t
   desired_capacity     = 0
   max_size             = 0
   min_size             = 0
   launch_configuration = aws_launch_configuration.foobar.name
 }
-`)
+`, allowAZChange))
+}
+
+func testAccCheckAWSAutoScalingGroupNotRecreated(i, j *autoscaling.Group) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(i.AutoScalingGroupARN) != aws.StringValue(j.AutoScalingGroupARN) {
+			return fmt.Errorf("Auto Scaling Group (%s) recreated", aws.StringValue(i.AutoScalingGroupName))
+		}
+
+		return nil
+	}
 }
 
-func testAccAWSAutoScalingGroupConfig_withPlacementGroup(name string) string {
+func testAccAWSAutoScalingGroupConfig_withPlacementGroup(name, placementGroup string) string {
 	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
 		fmt.Sprintf(`
 data "aws_ami" "test_ami" {
@@ -2804,13 +4088,18 @@ resource "aws_launch_configuration" "foobar" {
 }
 
 resource "aws_placement_group" "test" {
-  name     = "%s"
+  name     = %[1]q
+  strategy = "cluster"
+}
+
+resource "aws_placement_group" "test2" {
+  name     = "%[1]s-2"
   strategy = "cluster"
 }
 
 resource "aws_autoscaling_group" "bar" {
   availability_zones        = [data.aws_availability_zones.available.names[0]]
-  name                      = "%s"
+  name                      = %[1]q
   max_size                  = 1
   min_size                  = 1
   health_check_grace_period = 300
@@ -2818,7 +4107,7 @@ resource "aws_autoscaling_group" "bar" {
   desired_capacity          = 1
   force_delete              = true
   termination_policies      = ["OldestInstance", "ClosestToNextInstanceHour"]
-  placement_group           = aws_placement_group.test.name
+  placement_group           = aws_placement_group.%[2]s.name
 
   launch_configuration = aws_launch_configuration.foobar.name
 
@@ -2828,7 +4117,7 @@ resource "aws_autoscaling_group" "bar" {
     propagate_at_launch = true
   }
 }
-`, name, name)
+`, name, placementGroup)
 }
 
 func testAccAWSAutoScalingGroupConfig_withServiceLinkedRoleARN() string {
@@ -2864,6 +4153,96 @@ resource "aws_autoscaling_group" "bar" {
 `)
 }
 
+func testAccAWSAutoScalingGroupConfig_InitialDesiredCapacity(rName string, initialDesiredCapacity int) string {
+	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_configuration" "test" {
+  image_id      = data.aws_ami.test.id
+  instance_type = "t3.micro"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones        = [data.aws_availability_zones.available.names[0]]
+  name                      = %[1]q
+  initial_desired_capacity  = %[2]d
+  max_size                  = 2
+  min_size                  = 0
+  launch_configuration      = aws_launch_configuration.test.name
+  wait_for_capacity_timeout = "0"
+}
+`, rName, initialDesiredCapacity))
+}
+
+func testAccAWSAutoScalingGroupConfig_IgnoreDesiredCapacityChanges(rName string, desiredCapacity, maxSize int) string {
+	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_configuration" "test" {
+  image_id      = data.aws_ami.test.id
+  instance_type = "t3.micro"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones              = [data.aws_availability_zones.available.names[0]]
+  name                            = %[1]q
+  desired_capacity                = %[2]d
+  ignore_desired_capacity_changes = true
+  max_size                        = %[3]d
+  min_size                        = 0
+  launch_configuration            = aws_launch_configuration.test.name
+  wait_for_capacity_timeout       = "0"
+}
+`, rName, desiredCapacity, maxSize))
+}
+
+func testAccAWSAutoScalingGroupConfig_Paused(rName string, paused bool) string {
+	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_configuration" "test" {
+  image_id      = data.aws_ami.test.id
+  instance_type = "t3.micro"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = %[1]q
+  max_size             = 0
+  min_size             = 0
+  launch_configuration = aws_launch_configuration.test.name
+  paused               = %[2]t
+}
+`, rName, paused))
+}
+
 func testAccAWSAutoScalingGroupConfig_withMaxInstanceLifetime() string {
 	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
 		`
@@ -3391,64 +4770,159 @@ resource "aws_autoscaling_group" "bar" {
 `, name)
 }
 
-func testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity(rInt int) string {
+func testAccAWSAutoScalingGroupWithHookConfigUpdated(name string) string {
 	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
 		fmt.Sprintf(`
-resource "aws_vpc" "default" {
-  cidr_block           = "10.0.0.0/16"
-  enable_dns_hostnames = "true"
-  enable_dns_support   = "true"
+data "aws_ami" "test_ami" {
+  most_recent = true
+  owners      = ["amazon"]
 
-  tags = {
-    Name = "terraform-testacc-autoscaling-group-alb-target-group-elb-capacity"
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
   }
 }
 
-resource "aws_lb" "test_lb" {
-  subnets = [aws_subnet.main.id, aws_subnet.alt.id]
-
-  tags = {
-    Name = "testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity"
-  }
+resource "aws_launch_configuration" "foobar" {
+  image_id      = data.aws_ami.test_ami.id
+  instance_type = "t2.micro"
 }
 
-resource "aws_lb_listener" "test_listener" {
-  load_balancer_arn = aws_lb.test_lb.arn
-  port              = "80"
-
-  default_action {
-    target_group_arn = aws_lb_target_group.test.arn
-    type             = "forward"
-  }
-}
+resource "aws_autoscaling_group" "bar" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = "%s"
+  max_size             = 5
+  min_size             = 2
+  health_check_type    = "ELB"
+  desired_capacity     = 4
+  force_delete         = true
+  termination_policies = ["OldestInstance", "ClosestToNextInstanceHour"]
 
-resource "aws_lb_target_group" "test" {
-  name     = "tf-alb-test-%d"
-  port     = 80
-  protocol = "HTTP"
-  vpc_id   = aws_vpc.default.id
+  launch_configuration = aws_launch_configuration.foobar.name
 
-  health_check {
-    path              = "/"
-    healthy_threshold = "2"
-    timeout           = "2"
-    interval          = "5"
-    matcher           = "200"
+  initial_lifecycle_hook {
+    name                 = "launching"
+    default_result       = "CONTINUE"
+    heartbeat_timeout    = 60
+    lifecycle_transition = "autoscaling:EC2_INSTANCE_LAUNCHING"
   }
 
-  tags = {
-    Name = "testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity"
+  initial_lifecycle_hook {
+    name                 = "terminating"
+    default_result       = "ABANDON"
+    lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING"
   }
 }
 
-resource "aws_subnet" "main" {
-  vpc_id            = aws_vpc.default.id
-  cidr_block        = "10.0.1.0/24"
-  availability_zone = data.aws_availability_zones.available.names[0]
-
-  tags = {
-    Name = "tf-acc-autoscaling-group-alb-target-group-elb-capacity-main"
-  }
+resource "aws_autoscaling_lifecycle_hook" "separate" {
+  name                   = "separate"
+  autoscaling_group_name = aws_autoscaling_group.bar.name
+  default_result         = "CONTINUE"
+  heartbeat_timeout      = 30
+  lifecycle_transition   = "autoscaling:EC2_INSTANCE_TERMINATING"
+}
+`, name)
+}
+
+func testAccAWSAutoScalingGroupWithHookConfigRemoved(name string) string {
+	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
+		fmt.Sprintf(`
+data "aws_ami" "test_ami" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_configuration" "foobar" {
+  image_id      = data.aws_ami.test_ami.id
+  instance_type = "t2.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  name                 = "%s"
+  max_size             = 5
+  min_size             = 2
+  health_check_type    = "ELB"
+  desired_capacity     = 4
+  force_delete         = true
+  termination_policies = ["OldestInstance", "ClosestToNextInstanceHour"]
+
+  launch_configuration = aws_launch_configuration.foobar.name
+}
+
+resource "aws_autoscaling_lifecycle_hook" "separate" {
+  name                   = "separate"
+  autoscaling_group_name = aws_autoscaling_group.bar.name
+  default_result         = "CONTINUE"
+  heartbeat_timeout      = 30
+  lifecycle_transition   = "autoscaling:EC2_INSTANCE_TERMINATING"
+}
+`, name)
+}
+
+func testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity(rInt int) string {
+	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
+		fmt.Sprintf(`
+resource "aws_vpc" "default" {
+  cidr_block           = "10.0.0.0/16"
+  enable_dns_hostnames = "true"
+  enable_dns_support   = "true"
+
+  tags = {
+    Name = "terraform-testacc-autoscaling-group-alb-target-group-elb-capacity"
+  }
+}
+
+resource "aws_lb" "test_lb" {
+  subnets = [aws_subnet.main.id, aws_subnet.alt.id]
+
+  tags = {
+    Name = "testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity"
+  }
+}
+
+resource "aws_lb_listener" "test_listener" {
+  load_balancer_arn = aws_lb.test_lb.arn
+  port              = "80"
+
+  default_action {
+    target_group_arn = aws_lb_target_group.test.arn
+    type             = "forward"
+  }
+}
+
+resource "aws_lb_target_group" "test" {
+  name     = "tf-alb-test-%d"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = aws_vpc.default.id
+
+  health_check {
+    path              = "/"
+    healthy_threshold = "2"
+    timeout           = "2"
+    interval          = "5"
+    matcher           = "200"
+  }
+
+  tags = {
+    Name = "testAccAWSAutoScalingGroupConfig_ALB_TargetGroup_ELBCapacity"
+  }
+}
+
+resource "aws_subnet" "main" {
+  vpc_id            = aws_vpc.default.id
+  cidr_block        = "10.0.1.0/24"
+  availability_zone = data.aws_availability_zones.available.names[0]
+
+  tags = {
+    Name = "tf-acc-autoscaling-group-alb-target-group-elb-capacity-main"
+  }
 }
 
 resource "aws_subnet" "alt" {
@@ -3682,6 +5156,32 @@ resource "aws_autoscaling_group" "bar" {
 `)
 }
 
+func testAccAWSAutoScalingGroupConfig_LaunchTemplate_representation(rName, attribute, version string) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		testAccLatestAmazonLinuxHvmEbsAmiConfig(),
+		fmt.Sprintf(`
+resource "aws_launch_template" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t3.micro"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.available.names[0]]
+  name               = %[1]q
+  desired_capacity   = 0
+  max_size           = 0
+  min_size           = 0
+
+  launch_template {
+    %[2]s = aws_launch_template.test.%[2]s
+    version = %[3]s
+  }
+}
+`, rName, attribute, version))
+}
+
 func testAccAWSAutoScalingGroupConfig_withLaunchTemplate_toLaunchConfig() string {
 	return composeConfig(testAccAvailableAZsNoOptInDefaultExcludeConfig(),
 		`
@@ -3938,6 +5438,43 @@ resource "aws_launch_template" "test" {
 `, rName)
 }
 
+func testAccAWSAutoScalingGroupConfig_FailedScalingActivity(rName string, ignoreFailedScalingActivities bool) string {
+	return testAccAvailableAZsNoOptInDefaultExcludeConfig() +
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+# An x86_64 AMI cannot be launched on an arm64 instance type.
+resource "aws_launch_template" "test" {
+  image_id      = data.aws_ami.test.id
+  instance_type = "t4g.micro"
+  name          = %[1]q
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.available.names[0]]
+  desired_capacity   = 1
+  max_size           = 1
+  min_size           = 1
+  name               = %[1]q
+
+  ignore_failed_scaling_activities = %[2]t
+  wait_for_capacity_timeout        = "2m"
+
+  launch_template {
+    id = aws_launch_template.test.id
+  }
+}
+`, rName, ignoreFailedScalingActivities)
+}
+
 func testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy(rName string) string {
 	return testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_Base(rName) +
 		fmt.Sprintf(`
@@ -4276,6 +5813,54 @@ resource "aws_autoscaling_group" "test" {
 `, rName, instanceType)
 }
 
+func testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_LaunchTemplateSpecification(rName, instanceType string) string {
+	return testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_Base(rName) +
+		fmt.Sprintf(`
+data "aws_ami" "test_arm64" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn2-ami-hvm-*-arm64-gp2"]
+  }
+}
+
+resource "aws_launch_template" "test_arm64" {
+  image_id = data.aws_ami.test_arm64.id
+  name     = "%[1]s-arm64"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.available.names[0]]
+  desired_capacity   = 0
+  max_size           = 0
+  min_size           = 0
+  name               = %[1]q
+
+  mixed_instances_policy {
+    launch_template {
+      launch_template_specification {
+        launch_template_id = aws_launch_template.test.id
+      }
+
+      override {
+        instance_type = "t2.micro"
+      }
+
+      override {
+        instance_type = %[2]q
+
+        launch_template_specification {
+          launch_template_id = aws_launch_template.test_arm64.id
+        }
+      }
+    }
+  }
+}
+`, rName, instanceType)
+}
+
 func testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_LaunchTemplate_Override_WeightedCapacity(rName string) string {
 	return testAccAWSAutoScalingGroupConfig_MixedInstancesPolicy_Base(rName) +
 		fmt.Sprintf(`
@@ -4561,53 +6146,180 @@ resource "aws_launch_configuration" "test" {
 `
 }
 
-func testAccCheckAutoScalingInstanceRefreshCount(group *autoscaling.Group, expected int) resource.TestCheckFunc {
-	return func(state *terraform.State) error {
-		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+func testAccAwsAutoScalingGroupConfig_InstanceRefresh_Failed(rName string, invalidSecurityGroup bool, tagValue string) string {
+	return composeConfig(
+		testAccLatestAmazonLinuxHvmEbsAmiConfig(),
+		fmt.Sprintf(`
+resource "aws_launch_template" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t3.nano"
 
-		input := autoscaling.DescribeInstanceRefreshesInput{
-			AutoScalingGroupName: group.AutoScalingGroupName,
-		}
-		resp, err := conn.DescribeInstanceRefreshes(&input)
-		if err != nil {
-			return fmt.Errorf("error describing Auto Scaling Group (%s) Instance Refreshes: %w", aws.StringValue(group.AutoScalingGroupName), err)
-		}
+  vpc_security_group_ids = %[2]t ? ["sg-00000000000000000"] : null
+}
 
-		if len(resp.InstanceRefreshes) != expected {
-			return fmt.Errorf("expected %d Instance Refreshes, got %d", expected, len(resp.InstanceRefreshes))
-		}
-		return nil
-	}
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.current.names[0]]
+  max_size           = 2
+  min_size           = 1
+  desired_capacity   = 1
+
+  launch_template {
+    id      = aws_launch_template.test.id
+    version = aws_launch_template.test.latest_version
+  }
+
+  instance_refresh {
+    strategy              = "Rolling"
+    triggers              = ["tags"]
+    failure_check_timeout = "10m"
+  }
+
+  tag {
+    key                 = "Key"
+    value               = %[3]q
+    propagate_at_launch = true
+  }
 }
 
-func testAccCheckAutoScalingInstanceRefreshStatus(group *autoscaling.Group, offset int, expected ...string) resource.TestCheckFunc {
-	return func(state *terraform.State) error {
-		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+data "aws_availability_zones" "current" {
+  state = "available"
 
-		input := autoscaling.DescribeInstanceRefreshesInput{
-			AutoScalingGroupName: group.AutoScalingGroupName,
-		}
-		resp, err := conn.DescribeInstanceRefreshes(&input)
-		if err != nil {
-			return fmt.Errorf("error describing Auto Scaling Group (%s) Instance Refreshes: %w", aws.StringValue(group.AutoScalingGroupName), err)
-		}
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+`, rName, invalidSecurityGroup, tagValue))
+}
 
-		if len(resp.InstanceRefreshes) < offset {
-			return fmt.Errorf("expected at least %d Instance Refreshes, got %d", offset+1, len(resp.InstanceRefreshes))
-		}
+func testAccAwsAutoScalingGroupConfig_LaunchTemplate_DefaultVersion(rName, instanceType string) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
 
-		actual := aws.StringValue(resp.InstanceRefreshes[offset].Status)
-		for _, s := range expected {
-			if actual == s {
-				return nil
-			}
-		}
-		return fmt.Errorf("expected Instance Refresh at index %d to be in %q, got %q", offset, expected, actual)
-	}
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
 }
 
-func TestCreateAutoScalingGroupInstanceRefreshInput(t *testing.T) {
-	const asgName = "test-asg"
+resource "aws_launch_template" "test" {
+  name                   = %[1]q
+  image_id               = data.aws_ami.test.id
+  instance_type          = %[2]q
+  update_default_version = true
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.available.names[0]]
+  name               = %[1]q
+  max_size           = 0
+  min_size           = 0
+  desired_capacity   = 0
+
+  launch_template {
+    id      = aws_launch_template.test.id
+    version = "$Default"
+  }
+
+  instance_refresh {
+    strategy = "Rolling"
+  }
+}
+`, rName, instanceType))
+}
+
+func testAccAwsAutoScalingGroupConfig_InstanceRefresh_LaunchTemplateTrigger(rName string) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		fmt.Sprintf(`
+data "aws_ami" "test" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_launch_template" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.test.id
+  instance_type = "t3.nano"
+}
+
+resource "aws_autoscaling_group" "test" {
+  availability_zones = [data.aws_availability_zones.available.names[0]]
+  name               = %[1]q
+  max_size           = 1
+  min_size           = 1
+  desired_capacity   = 1
+
+  launch_template {
+    id      = aws_launch_template.test.id
+    version = "$Latest"
+  }
+
+  instance_refresh {
+    strategy = "Rolling"
+    triggers = ["launch_template"]
+  }
+}
+`, rName))
+}
+
+func testAccCheckAutoScalingInstanceRefreshCount(group *autoscaling.Group, expected int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		input := autoscaling.DescribeInstanceRefreshesInput{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+		}
+		resp, err := conn.DescribeInstanceRefreshes(&input)
+		if err != nil {
+			return fmt.Errorf("error describing Auto Scaling Group (%s) Instance Refreshes: %w", aws.StringValue(group.AutoScalingGroupName), err)
+		}
+
+		if len(resp.InstanceRefreshes) != expected {
+			return fmt.Errorf("expected %d Instance Refreshes, got %d", expected, len(resp.InstanceRefreshes))
+		}
+		return nil
+	}
+}
+
+func testAccCheckAutoScalingInstanceRefreshStatus(group *autoscaling.Group, offset int, expected ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+		input := autoscaling.DescribeInstanceRefreshesInput{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+		}
+		resp, err := conn.DescribeInstanceRefreshes(&input)
+		if err != nil {
+			return fmt.Errorf("error describing Auto Scaling Group (%s) Instance Refreshes: %w", aws.StringValue(group.AutoScalingGroupName), err)
+		}
+
+		if len(resp.InstanceRefreshes) < offset {
+			return fmt.Errorf("expected at least %d Instance Refreshes, got %d", offset+1, len(resp.InstanceRefreshes))
+		}
+
+		actual := aws.StringValue(resp.InstanceRefreshes[offset].Status)
+		for _, s := range expected {
+			if actual == s {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected Instance Refresh at index %d to be in %q, got %q", offset, expected, actual)
+	}
+}
+
+func TestCreateAutoScalingGroupInstanceRefreshInput(t *testing.T) {
+	const asgName = "test-asg"
 	testCases := []struct {
 		name     string
 		input    []interface{}
@@ -4744,3 +6456,934 @@ func TestCreateAutoScalingGroupInstanceRefreshInput(t *testing.T) {
 		})
 	}
 }
+
+func TestFlattenAutoScalingGroupInstancesPerAvailabilityZone(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []*autoscaling.Instance
+		expected map[string]interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: map[string]interface{}{},
+		},
+		{
+			name: "single zone",
+			input: []*autoscaling.Instance{
+				{InstanceId: aws.String("i-1"), AvailabilityZone: aws.String("us-west-2a")},
+				{InstanceId: aws.String("i-2"), AvailabilityZone: aws.String("us-west-2a")},
+			},
+			expected: map[string]interface{}{
+				"us-west-2a": 2,
+			},
+		},
+		{
+			name: "multiple zones",
+			input: []*autoscaling.Instance{
+				{InstanceId: aws.String("i-1"), AvailabilityZone: aws.String("us-west-2a")},
+				{InstanceId: aws.String("i-2"), AvailabilityZone: aws.String("us-west-2b")},
+				{InstanceId: aws.String("i-3"), AvailabilityZone: aws.String("us-west-2c")},
+				{InstanceId: aws.String("i-4"), AvailabilityZone: aws.String("us-west-2b")},
+			},
+			expected: map[string]interface{}{
+				"us-west-2a": 1,
+				"us-west-2b": 2,
+				"us-west-2c": 1,
+			},
+		},
+		{
+			name: "missing zone and nil instance",
+			input: []*autoscaling.Instance{
+				nil,
+				{InstanceId: aws.String("i-1")},
+				{InstanceId: aws.String("i-2"), AvailabilityZone: aws.String("us-west-2a")},
+			},
+			expected: map[string]interface{}{
+				"us-west-2a": 1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := flattenAutoScalingGroupInstancesPerAvailabilityZone(testCase.input)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenAutoScalingGroupInstances(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []*autoscaling.Instance
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			expected: []interface{}{},
+		},
+		{
+			name: "ordered by instance ID",
+			input: []*autoscaling.Instance{
+				nil,
+				{
+					AvailabilityZone: aws.String("us-west-2b"),
+					HealthStatus:     aws.String("Healthy"),
+					InstanceId:       aws.String("i-2"),
+					LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+						LaunchTemplateId:   aws.String("lt-1"),
+						LaunchTemplateName: aws.String("test"),
+						Version:            aws.String("3"),
+					},
+					LifecycleState:       aws.String(autoscaling.LifecycleStatePending),
+					ProtectedFromScaleIn: aws.Bool(true),
+				},
+				{
+					AvailabilityZone:        aws.String("us-west-2a"),
+					HealthStatus:            aws.String("Unhealthy"),
+					InstanceId:              aws.String("i-1"),
+					LaunchConfigurationName: aws.String("test"),
+					LifecycleState:          aws.String(autoscaling.LifecycleStateInService),
+					ProtectedFromScaleIn:    aws.Bool(false),
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"availability_zone":         "us-west-2a",
+					"health_status":             "Unhealthy",
+					"instance_id":               "i-1",
+					"launch_configuration_name": "test",
+					"launch_template":           []interface{}{},
+					"lifecycle_state":           autoscaling.LifecycleStateInService,
+					"protected_from_scale_in":   false,
+				},
+				map[string]interface{}{
+					"availability_zone":         "us-west-2b",
+					"health_status":             "Healthy",
+					"instance_id":               "i-2",
+					"launch_configuration_name": "",
+					"launch_template": []interface{}{
+						map[string]interface{}{
+							"id":      "lt-1",
+							"name":    "test",
+							"version": "3",
+						},
+					},
+					"lifecycle_state":         autoscaling.LifecycleStatePending,
+					"protected_from_scale_in": true,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := flattenAutoScalingGroupInstances(testCase.input)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupHealthCheckTypeWarning(t *testing.T) {
+	testCases := []struct {
+		name            string
+		healthCheckType string
+		loadBalancers   int
+		targetGroups    int
+		expectWarning   bool
+	}{
+		{
+			name:            "EC2 without attachments",
+			healthCheckType: "EC2",
+		},
+		{
+			name:            "unset without attachments",
+			healthCheckType: "",
+		},
+		{
+			name:            "ELB without attachments",
+			healthCheckType: "ELB",
+			expectWarning:   true,
+		},
+		{
+			name:            "ELB with load balancer",
+			healthCheckType: "ELB",
+			loadBalancers:   1,
+		},
+		{
+			name:            "ELB with target group",
+			healthCheckType: "ELB",
+			targetGroups:    2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupHealthCheckTypeWarning(testCase.healthCheckType, testCase.loadBalancers, testCase.targetGroups)
+
+			if testCase.expectWarning && got == "" {
+				t.Error("expected warning, got none")
+			}
+
+			if !testCase.expectWarning && got != "" {
+				t.Errorf("unexpected warning: %s", got)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupPlacementGroupWarning(t *testing.T) {
+	if got := autoScalingGroupPlacementGroupWarning(false); got == "" {
+		t.Error("expected warning without instance refresh trigger, got none")
+	}
+
+	if got := autoScalingGroupPlacementGroupWarning(true); got != "" {
+		t.Errorf("unexpected warning with instance refresh trigger: %s", got)
+	}
+}
+
+func TestIsAutoScalingGroupSweepable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{
+			name:     "terraform-test-abc123",
+			expected: true,
+		},
+		{
+			name:     "tf-acc-test-1234567890",
+			expected: true,
+		},
+		{
+			name:     "tf-test-abcde",
+			expected: true,
+		},
+		{
+			name:     "terraform-test-asg-lb-assoc-1234",
+			expected: true,
+		},
+		{
+			name: "production-web",
+		},
+		{
+			name: "my-tf-acc-test",
+		},
+		{
+			name: "tf-asg-20201214123456789000000001",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := isAutoScalingGroupSweepable(testCase.name); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupSwitchesZoneAttribute(t *testing.T) {
+	testCases := []struct {
+		name       string
+		oldSubnets int
+		newSubnets int
+		expected   bool
+	}{
+		{
+			name: "availability zones unchanged",
+		},
+		{
+			name:       "availability zones to subnets",
+			newSubnets: 1,
+			expected:   true,
+		},
+		{
+			name:       "subnets to availability zones",
+			oldSubnets: 2,
+			expected:   true,
+		},
+		{
+			name:       "subnets changed",
+			oldSubnets: 1,
+			newSubnets: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupSwitchesZoneAttribute(testCase.oldSubnets, testCase.newSubnets)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupAvailabilityZonesChanged(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      []string
+		new      []string
+		expected bool
+	}{
+		{
+			name: "unchanged",
+			old:  []string{"us-west-2a", "us-west-2b"},
+			new:  []string{"us-west-2b", "us-west-2a"},
+		},
+		{
+			name:     "zone added",
+			old:      []string{"us-west-2a"},
+			new:      []string{"us-west-2a", "us-west-2b"},
+			expected: true,
+		},
+		{
+			name:     "zone removed",
+			old:      []string{"us-west-2a", "us-west-2b"},
+			new:      []string{"us-west-2a"},
+			expected: true,
+		},
+		{
+			name:     "zone replaced",
+			old:      []string{"us-west-2a", "us-west-2b"},
+			new:      []string{"us-west-2a", "us-west-2c"},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupAvailabilityZonesChanged(testCase.old, testCase.new)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestValidateAutoScalingGroupWaitForCapacityTimeout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{
+			name:  "default",
+			value: "10m",
+		},
+		{
+			name:  "zero",
+			value: "0",
+		},
+		{
+			name:  "compound",
+			value: "1h30m",
+		},
+		{
+			name:        "empty",
+			value:       "",
+			expectError: true,
+		},
+		{
+			name:        "unparseable",
+			value:       "10minutes",
+			expectError: true,
+		},
+		{
+			name:        "missing unit",
+			value:       "10",
+			expectError: true,
+		},
+		{
+			name:        "negative",
+			value:       "-5m",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validateAutoScalingGroupWaitForCapacityTimeout(testCase.value, "wait_for_capacity_timeout")
+
+			if testCase.expectError && len(errs) == 0 {
+				t.Errorf("expected error for %q, got none", testCase.value)
+			}
+
+			if !testCase.expectError && len(errs) > 0 {
+				t.Errorf("unexpected errors for %q: %v", testCase.value, errs)
+			}
+		})
+	}
+}
+
+// An invalid wait_for_capacity_timeout must be rejected before any API call is made.
+// The client has no Auto Scaling connection, so any API call would panic.
+func TestValidateAutoScalingGroupInstanceRefreshFailureCheckTimeout(t *testing.T) {
+	testCases := []struct {
+		value       string
+		expectError bool
+	}{
+		{value: "2m"},
+		{value: "0"},
+		{value: "90s"},
+		{value: "2", expectError: true},
+		{value: "-1m", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validateAutoScalingGroupInstanceRefreshFailureCheckTimeout(testCase.value, "failure_check_timeout")
+
+		if got := len(errs) > 0; got != testCase.expectError {
+			t.Errorf("%q: got errors %v, expected error: %t", testCase.value, errs, testCase.expectError)
+		}
+	}
+}
+
+func TestResourceAwsAutoscalingGroupCreate_invalidWaitForCapacityTimeout(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAwsAutoscalingGroup().Schema, map[string]interface{}{
+		"name":                      "tf-acc-test",
+		"launch_configuration":      "tf-acc-test",
+		"availability_zones":        []interface{}{"us-west-2a"},
+		"min_size":                  1,
+		"max_size":                  1,
+		"wait_for_capacity_timeout": "10minutes",
+	})
+
+	err := resourceAwsAutoscalingGroupCreate(d, &AWSClient{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "wait_for_capacity_timeout") {
+		t.Errorf("expected error to reference wait_for_capacity_timeout, got: %s", err)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected no resource ID, got: %s", d.Id())
+	}
+}
+
+func TestResourceAwsAutoscalingGroupUpdate_invalidWaitForCapacityTimeout(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAwsAutoscalingGroup().Schema, map[string]interface{}{
+		"name":                      "tf-acc-test",
+		"launch_configuration":      "tf-acc-test",
+		"min_size":                  1,
+		"max_size":                  1,
+		"wait_for_capacity_timeout": "-5m",
+	})
+	d.SetId("tf-acc-test")
+
+	err := resourceAwsAutoscalingGroupUpdate(d, &AWSClient{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("expected negative duration error, got: %s", err)
+	}
+}
+
+func TestIsAutoScalingGroupInstanceTypeError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("some error"),
+			expected: false,
+		},
+		{
+			name:     "other validation error",
+			err:      awserr.New("ValidationError", "Max bound, 1, must be greater than or equal to min bound, 2", nil),
+			expected: false,
+		},
+		{
+			name:     "instance type not supported",
+			err:      awserr.New("ValidationError", "Your requested instance type (c5.large) is not supported in your requested Availability Zone (us-east-1e).", nil),
+			expected: true,
+		},
+		{
+			name:     "not authorized",
+			err:      awserr.New("ValidationError", "You are not authorized to use launch template: lt-1234567890abcdef0", nil),
+			expected: true,
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("wrapped: %w", awserr.New("ValidationError", "Instance type m5.24xlarge is not authorized", nil)),
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := isAutoScalingGroupInstanceTypeError(testCase.err)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupProcessesToPause(t *testing.T) {
+	allProcesses := []string{"Launch", "Terminate", "HealthCheck", "ReplaceUnhealthy", "AZRebalance", "AlarmNotification", "ScheduledActions", "AddToLoadBalancer"}
+
+	testCases := []struct {
+		name               string
+		suspendedProcesses []string
+		expected           []string
+	}{
+		{
+			name:     "none suspended",
+			expected: []string{"AZRebalance", "AddToLoadBalancer", "AlarmNotification", "HealthCheck", "Launch", "ReplaceUnhealthy", "ScheduledActions", "Terminate"},
+		},
+		{
+			name:               "some suspended manually",
+			suspendedProcesses: []string{"AZRebalance", "ScheduledActions"},
+			expected:           []string{"AddToLoadBalancer", "AlarmNotification", "HealthCheck", "Launch", "ReplaceUnhealthy", "Terminate"},
+		},
+		{
+			name:               "all suspended",
+			suspendedProcesses: allProcesses,
+			expected:           []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupProcessesToPause(allProcesses, testCase.suspendedProcesses)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupPendingFields(t *testing.T) {
+	targetGroupArn1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tf-acc-test-1/1234567890abcdef"
+	targetGroupArn2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tf-acc-test-2/1234567890abcdef"
+
+	group := &autoscaling.Group{
+		DesiredCapacity:   aws.Int64(2),
+		LoadBalancerNames: aws.StringSlice([]string{"tf-acc-test"}),
+		MaxSize:           aws.Int64(4),
+		MinSize:           aws.Int64(1),
+		TargetGroupARNs:   aws.StringSlice([]string{targetGroupArn1}),
+	}
+
+	testCases := []struct {
+		name     string
+		expected autoScalingGroupExpectedState
+		pending  []string
+	}{
+		{
+			name: "nothing changed",
+		},
+		{
+			name: "all reflected",
+			expected: autoScalingGroupExpectedState{
+				DesiredCapacity:   aws.Int64(2),
+				LoadBalancerNames: schema.NewSet(schema.HashString, []interface{}{"tf-acc-test"}),
+				MaxSize:           aws.Int64(4),
+				MinSize:           aws.Int64(1),
+				TargetGroupARNs:   schema.NewSet(schema.HashString, []interface{}{targetGroupArn1}),
+			},
+		},
+		{
+			name: "capacity pending",
+			expected: autoScalingGroupExpectedState{
+				DesiredCapacity: aws.Int64(3),
+				MaxSize:         aws.Int64(4),
+				MinSize:         aws.Int64(3),
+			},
+			pending: []string{"desired_capacity", "min_size"},
+		},
+		{
+			name: "target group attachment pending",
+			expected: autoScalingGroupExpectedState{
+				TargetGroupARNs: schema.NewSet(schema.HashString, []interface{}{targetGroupArn1, targetGroupArn2}),
+			},
+			pending: []string{"target_group_arns"},
+		},
+		{
+			name: "detachments pending",
+			expected: autoScalingGroupExpectedState{
+				LoadBalancerNames: schema.NewSet(schema.HashString, []interface{}{}),
+				TargetGroupARNs:   schema.NewSet(schema.HashString, []interface{}{}),
+			},
+			pending: []string{"load_balancers", "target_group_arns"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupPendingFields(testCase.expected, group)
+
+			if !reflect.DeepEqual(got, testCase.pending) {
+				t.Errorf("got %v, expected %v", got, testCase.pending)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupInstanceRefreshError(t *testing.T) {
+	refresh := func(id, status, reason string) *autoscaling.InstanceRefresh {
+		return &autoscaling.InstanceRefresh{
+			InstanceRefreshId: aws.String(id),
+			Status:            aws.String(status),
+			StatusReason:      aws.String(reason),
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		instanceRefresh *autoscaling.InstanceRefresh
+		refreshes       []*autoscaling.InstanceRefresh
+		expectError     bool
+	}{
+		{
+			name:            "successful",
+			instanceRefresh: refresh("one", autoscaling.InstanceRefreshStatusSuccessful, ""),
+		},
+		{
+			name:            "failed",
+			instanceRefresh: refresh("one", autoscaling.InstanceRefreshStatusFailed, "Instance failed to launch"),
+			expectError:     true,
+		},
+		{
+			name:            "cancelled",
+			instanceRefresh: refresh("one", autoscaling.InstanceRefreshStatusCancelled, "Cancelled by user"),
+			refreshes: []*autoscaling.InstanceRefresh{
+				refresh("one", autoscaling.InstanceRefreshStatusCancelled, "Cancelled by user"),
+				refresh("zero", autoscaling.InstanceRefreshStatusSuccessful, ""),
+			},
+			expectError: true,
+		},
+		{
+			name:            "cancelled by newer refresh",
+			instanceRefresh: refresh("one", autoscaling.InstanceRefreshStatusCancelled, "Cancelled by user"),
+			refreshes: []*autoscaling.InstanceRefresh{
+				refresh("two", autoscaling.InstanceRefreshStatusInProgress, ""),
+				refresh("one", autoscaling.InstanceRefreshStatusCancelled, "Cancelled by user"),
+			},
+		},
+		{
+			name:            "failed with newer refresh",
+			instanceRefresh: refresh("one", autoscaling.InstanceRefreshStatusFailed, "Instance failed to launch"),
+			refreshes: []*autoscaling.InstanceRefresh{
+				refresh("two", autoscaling.InstanceRefreshStatusInProgress, ""),
+				refresh("one", autoscaling.InstanceRefreshStatusFailed, "Instance failed to launch"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := autoScalingGroupInstanceRefreshError(testCase.instanceRefresh, testCase.refreshes)
+
+			if err == nil && testCase.expectError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.expectError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), aws.StringValue(testCase.instanceRefresh.StatusReason)) {
+				t.Errorf("expected error to contain status reason, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupInstanceTypesNotOffered(t *testing.T) {
+	offering := func(instanceType, location string) *ec2.InstanceTypeOffering {
+		return &ec2.InstanceTypeOffering{
+			InstanceType: aws.String(instanceType),
+			Location:     aws.String(location),
+			LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		instanceTypes     []string
+		availabilityZones []string
+		offerings         []*ec2.InstanceTypeOffering
+		expected          []string
+	}{
+		{
+			name:              "all offered",
+			instanceTypes:     []string{"t3.micro", "t3.small"},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+			offerings: []*ec2.InstanceTypeOffering{
+				offering("t3.micro", "us-west-2a"),
+				offering("t3.micro", "us-west-2b"),
+				offering("t3.small", "us-west-2a"),
+				offering("t3.small", "us-west-2b"),
+			},
+			expected: nil,
+		},
+		{
+			name:              "not offered in one Availability Zone",
+			instanceTypes:     []string{"t3.micro", "c5.large"},
+			availabilityZones: []string{"us-east-1a", "us-east-1e"},
+			offerings: []*ec2.InstanceTypeOffering{
+				offering("t3.micro", "us-east-1a"),
+				offering("t3.micro", "us-east-1e"),
+				offering("c5.large", "us-east-1a"),
+			},
+			expected: []string{"c5.large (us-east-1e)"},
+		},
+		{
+			name:              "not offered anywhere",
+			instanceTypes:     []string{"x1.32xlarge", "t3.micro", "x1.32xlarge", "m5.large"},
+			availabilityZones: []string{"us-east-1b", "us-east-1a"},
+			offerings: []*ec2.InstanceTypeOffering{
+				offering("t3.micro", "us-east-1a"),
+				offering("t3.micro", "us-east-1b"),
+				nil,
+			},
+			expected: []string{"m5.large (us-east-1a, us-east-1b)", "x1.32xlarge (us-east-1a, us-east-1b)"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := autoScalingGroupInstanceTypesNotOffered(testCase.instanceTypes, testCase.availabilityZones, testCase.offerings)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func testAccAWSAutoScalingGroupImportStateIdFuncARN(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return rs.Primary.Attributes["arn"], nil
+	}
+}
+
+func TestAutoScalingGroupNameFromARN(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		ARN           string
+		ExpectedName  string
+		ExpectedError bool
+	}{
+		{
+			Name:         "commercial partition",
+			ARN:          "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/my-asg",
+			ExpectedName: "my-asg",
+		},
+		{
+			Name:         "China partition",
+			ARN:          "arn:aws-cn:autoscaling:cn-north-1:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/my-asg",
+			ExpectedName: "my-asg",
+		},
+		{
+			Name:         "GovCloud partition",
+			ARN:          "arn:aws-us-gov:autoscaling:us-gov-west-1:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/my-asg",
+			ExpectedName: "my-asg",
+		},
+		{
+			Name:          "not an ARN",
+			ARN:           "my-asg",
+			ExpectedError: true,
+		},
+		{
+			Name:          "wrong service",
+			ARN:           "arn:aws:ec2:us-west-2:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/my-asg",
+			ExpectedError: true,
+		},
+		{
+			Name:          "launch configuration ARN",
+			ARN:           "arn:aws:autoscaling:us-west-2:123456789012:launchConfiguration:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:launchConfigurationName/my-lc",
+			ExpectedError: true,
+		},
+		{
+			Name:          "missing group name",
+			ARN:           "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := autoScalingGroupNameFromARN(testCase.ARN)
+
+			if err == nil && testCase.ExpectedError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.ExpectedError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if got != testCase.ExpectedName {
+				t.Errorf("got %q, expected %q", got, testCase.ExpectedName)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupImportName(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		ID            string
+		ExpectedID    string
+		ExpectedError bool
+	}{
+		{
+			Name:       "name",
+			ID:         "my-asg",
+			ExpectedID: "my-asg",
+		},
+		{
+			Name:       "ARN",
+			ID:         "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:4f5ce9e1-1a1c-4e23-b1d8-6c3a2f7e0d8a:autoScalingGroupName/my-asg",
+			ExpectedID: "my-asg",
+		},
+		{
+			Name:          "other ARN",
+			ID:            "arn:aws:ec2:us-west-2:123456789012:instance/i-1234567890abcdef0",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := autoScalingGroupImportName(testCase.ID)
+
+			if err == nil && testCase.ExpectedError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil {
+				if !testCase.ExpectedError {
+					t.Fatalf("got unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if got != testCase.ExpectedID {
+				t.Errorf("got %q, expected %q", got, testCase.ExpectedID)
+			}
+		})
+	}
+}
+
+func TestValidateAutoScalingGroupTags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tags        []interface{}
+		expectError bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "tag blocks",
+			tags: []interface{}{
+				map[string]interface{}{"key": "Name", "value": "test", "propagate_at_launch": true},
+				map[string]interface{}{"key": "Env", "value": "test", "propagate_at_launch": false},
+			},
+		},
+		{
+			name: "legacy tags",
+			tags: []interface{}{
+				map[string]interface{}{"key": "Name", "value": "test", "propagate_at_launch": "true"},
+				map[string]interface{}{"key": "Env", "value": "test", "propagate_at_launch": "TRUE"},
+				map[string]interface{}{"key": "Team", "value": "test", "propagate_at_launch": "0"},
+			},
+		},
+		{
+			name: "legacy tags unknown",
+			tags: []interface{}{
+				map[string]interface{}{"key": "", "value": "", "propagate_at_launch": ""},
+				map[string]interface{}{"key": "", "value": "", "propagate_at_launch": ""},
+			},
+		},
+		{
+			name: "legacy tags invalid propagate_at_launch",
+			tags: []interface{}{
+				map[string]interface{}{"key": "Name", "value": "test", "propagate_at_launch": "yes"},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate key",
+			tags: []interface{}{
+				map[string]interface{}{"key": "Name", "value": "test1", "propagate_at_launch": true},
+				map[string]interface{}{"key": "Name", "value": "test2", "propagate_at_launch": true},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateAutoScalingGroupTags(testCase.tags)
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestFlattenAutoScalingGroupTagsPropagateAtLaunch(t *testing.T) {
+	tags := []interface{}{
+		map[string]string{"key": "Configured", "value": "test", "propagate_at_launch": "true"},
+		map[string]string{"key": "Changed", "value": "test", "propagate_at_launch": "false"},
+		map[string]string{"key": "Lowercase", "value": "test", "propagate_at_launch": "true"},
+	}
+	configured := []interface{}{
+		map[string]interface{}{"key": "Configured", "value": "test", "propagate_at_launch": "TRUE"},
+		map[string]interface{}{"key": "Changed", "value": "test", "propagate_at_launch": "True"},
+		map[string]interface{}{"key": "Lowercase", "value": "test", "propagate_at_launch": "true"},
+	}
+
+	expected := []interface{}{
+		map[string]string{"key": "Configured", "value": "test", "propagate_at_launch": "TRUE"},
+		map[string]string{"key": "Changed", "value": "test", "propagate_at_launch": "false"},
+		map[string]string{"key": "Lowercase", "value": "test", "propagate_at_launch": "true"},
+	}
+
+	if got := flattenAutoScalingGroupTagsPropagateAtLaunch(tags, configured); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
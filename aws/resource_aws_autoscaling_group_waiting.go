@@ -9,25 +9,30 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// autoScalingGroupScalingActivityClockSkew is the allowed difference between the local
+// clock and the start times of scaling activities reported by Auto Scaling.
+const autoScalingGroupScalingActivityClockSkew = 1 * time.Minute
+
 // waitForASGCapacityTimeout gathers the current numbers of healthy instances
 // in the ASG and its attached ELBs and yields these numbers to a
-// capacitySatifiedFunction. Loops for up to wait_for_capacity_timeout until
-// the capacitySatisfiedFunc returns true.
+// capacitySatifiedFunction. Loops for up to the parsed wait_for_capacity_timeout
+// until the capacitySatisfiedFunc returns true.
+//
+// startTime is the time before the group was created or updated. Failed scaling
+// activities that started after it fail the wait.
 //
 // See "Waiting for Capacity" in docs for more discussion of the feature.
 func waitForASGCapacity(
 	d *schema.ResourceData,
 	meta interface{},
+	wait time.Duration,
+	startTime time.Time,
 	satisfiedFunc capacitySatisfiedFunc) error {
-	wait, err := time.ParseDuration(d.Get("wait_for_capacity_timeout").(string))
-	if err != nil {
-		return err
-	}
-
 	if wait == 0 {
 		log.Printf("[DEBUG] Capacity timeout set to 0, skipping capacity waiting.")
 		return nil
@@ -35,7 +40,11 @@ func waitForASGCapacity(
 
 	log.Printf("[DEBUG] Waiting on %s for capacity...", d.Id())
 
-	err = resource.Retry(wait, func() *resource.RetryError {
+	// Scaling activities that started before the change belong to earlier changes.
+	activitiesSince := startTime.Add(-autoScalingGroupScalingActivityClockSkew)
+	ignoreFailedScalingActivities := d.Get("ignore_failed_scaling_activities").(bool)
+
+	err := resource.Retry(wait, func() *resource.RetryError {
 		g, err := getAwsAutoscalingGroup(d.Id(), meta.(*AWSClient).autoscalingconn)
 		if err != nil {
 			return resource.NonRetryableError(err)
@@ -51,6 +60,18 @@ func waitForASGCapacity(
 			return nil
 		}
 
+		if !ignoreFailedScalingActivities {
+			activities, err := getAutoScalingGroupScalingActivitiesSince(meta.(*AWSClient).autoscalingconn, d.Id(), activitiesSince)
+
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("error describing Auto Scaling Group (%s) scaling activities: %w", d.Id(), err))
+			}
+
+			if err := autoScalingGroupFailedScalingActivityError(activities); err != nil {
+				return resource.NonRetryableError(fmt.Errorf("%q: %w", d.Id(), err))
+			}
+		}
+
 		return resource.RetryableError(fmt.Errorf("%q: Waiting up to %s: %s", d.Id(), wait, reason))
 	})
 	if isResourceTimeoutError(err) {
@@ -96,6 +117,55 @@ func waitForASGCapacity(
 	return fmt.Errorf("%s. Most recent activity: %s", err, recentStatus)
 }
 
+// getAutoScalingGroupScalingActivitiesSince returns the scaling activities of a group
+// that started at or after the specified time. Activities are returned newest first.
+func getAutoScalingGroupScalingActivitiesSince(conn *autoscaling.AutoScaling, name string, since time.Time) ([]*autoscaling.Activity, error) {
+	input := &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+
+	var activities []*autoscaling.Activity
+
+	err := conn.DescribeScalingActivitiesPages(input, func(page *autoscaling.DescribeScalingActivitiesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, activity := range page.Activities {
+			if activity == nil {
+				continue
+			}
+
+			if aws.TimeValue(activity.StartTime).Before(since) {
+				return false
+			}
+
+			activities = append(activities, activity)
+		}
+
+		return !lastPage
+	})
+
+	return activities, err
+}
+
+// autoScalingGroupFailedScalingActivityError returns an error with the status message of
+// the most recent failed or cancelled scaling activity, if any.
+func autoScalingGroupFailedScalingActivityError(activities []*autoscaling.Activity) error {
+	for _, activity := range activities {
+		if activity == nil {
+			continue
+		}
+
+		switch statusCode := aws.StringValue(activity.StatusCode); statusCode {
+		case autoscaling.ScalingActivityStatusCodeFailed, autoscaling.ScalingActivityStatusCodeCancelled:
+			return fmt.Errorf("scaling activity (%s) %s: %s: %s", aws.StringValue(activity.ActivityId), strings.ToLower(statusCode), aws.StringValue(activity.Description), aws.StringValue(activity.StatusMessage))
+		}
+	}
+
+	return nil
+}
+
 func isELBCapacitySatisfied(d *schema.ResourceData, meta interface{}, g *autoscaling.Group, satisfiedFunc capacitySatisfiedFunc) (bool, string) {
 	elbis, err := getELBInstanceStates(g, meta)
 	if err != nil {
@@ -108,6 +178,7 @@ func isELBCapacitySatisfied(d *schema.ResourceData, meta interface{}, g *autosca
 
 	haveASG := 0
 	haveELB := 0
+	pendingTargets := 0
 
 	for _, i := range g.Instances {
 		if i.HealthStatus == nil || i.InstanceId == nil || i.LifecycleState == nil {
@@ -132,32 +203,53 @@ func isELBCapacitySatisfied(d *schema.ResourceData, meta interface{}, g *autosca
 
 		haveASG += capacity
 
-		inAllLbs := true
-		for _, states := range elbis {
-			state, ok := states[*i.InstanceId]
-			if !ok || !strings.EqualFold(state, "InService") {
-				inAllLbs = false
-			}
-		}
-		for _, states := range albis {
-			state, ok := states[*i.InstanceId]
-			if !ok || !strings.EqualFold(state, "healthy") {
-				inAllLbs = false
-			}
-		}
+		inAllLbs, pending := autoScalingGroupInstanceLoadBalancerState(*i.InstanceId, elbis, albis)
 		if inAllLbs {
 			haveELB++
 		}
+		if pending {
+			pendingTargets++
+		}
 	}
 
 	satisfied, reason := satisfiedFunc(d, haveASG, haveELB)
 
+	if !satisfied && pendingTargets > 0 {
+		reason = fmt.Sprintf("%s, %d pending target group health checks", reason, pendingTargets)
+	}
+
 	log.Printf("[DEBUG] %q Capacity: %d ASG, %d ELB/ALB, satisfied: %t, reason: %q",
 		d.Id(), haveASG, haveELB, satisfied, reason)
 
 	return satisfied, reason
 }
 
+// autoScalingGroupInstanceLoadBalancerState returns whether an instance is in service in every
+// attached Classic ELB and healthy in every attached target group. Targets that are draining or
+// unhealthy are not counted; pending is true if a target group health check has not completed yet.
+func autoScalingGroupInstanceLoadBalancerState(instanceID string, elbis, albis map[string]map[string]string) (inService, pending bool) {
+	inService = true
+
+	for _, states := range elbis {
+		state, ok := states[instanceID]
+		if !ok || !strings.EqualFold(state, "InService") {
+			inService = false
+		}
+	}
+
+	for _, states := range albis {
+		state, ok := states[instanceID]
+		if !ok || !strings.EqualFold(state, elbv2.TargetHealthStateEnumHealthy) {
+			inService = false
+		}
+		if ok && strings.EqualFold(state, elbv2.TargetHealthStateEnumInitial) {
+			pending = true
+		}
+	}
+
+	return inService, pending
+}
+
 type capacitySatisfiedFunc func(*schema.ResourceData, int, int) (bool, string)
 
 // capacitySatisfiedCreate treats all targets as minimums
@@ -166,6 +258,9 @@ func capacitySatisfiedCreate(d *schema.ResourceData, haveASG, haveELB int) (bool
 	if wantASG := d.Get("desired_capacity").(int); wantASG > 0 {
 		minASG = wantASG
 	}
+	if wantASG := d.Get("initial_desired_capacity").(int); wantASG > 0 {
+		minASG = wantASG
+	}
 	if haveASG < minASG {
 		return false, fmt.Sprintf(
 			"Need at least %d healthy instances in ASG, have %d", minASG, haveASG)
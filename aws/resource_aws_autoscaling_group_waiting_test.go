@@ -1,6 +1,13 @@
 package aws
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
 
 func TestCapacitySatisfiedCreate(t *testing.T) {
 	cases := map[string]struct {
@@ -49,6 +56,22 @@ func TestCapacitySatisfiedCreate(t *testing.T) {
 			ExpectSatisfied: false,
 			ExpectReason:    "Need at least 5 healthy instances in ASG, have 2",
 		},
+		"initial_desired_capacity overrides min_size": {
+			Data: map[string]interface{}{
+				"min_size":                 2,
+				"initial_desired_capacity": 5,
+			},
+			HaveASG:         2,
+			ExpectSatisfied: false,
+			ExpectReason:    "Need at least 5 healthy instances in ASG, have 2",
+		},
+		"initial_desired_capacity, got it": {
+			Data: map[string]interface{}{
+				"initial_desired_capacity": 5,
+			},
+			HaveASG:         5,
+			ExpectSatisfied: true,
+		},
 		"desired_capacity, got it": {
 			Data: map[string]interface{}{
 				"desired_capacity": 5,
@@ -222,3 +245,157 @@ func TestCapacitySatisfiedUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestAutoScalingGroupInstanceLoadBalancerState(t *testing.T) {
+	cases := map[string]struct {
+		ELBStates       map[string]map[string]string
+		TargetStates    map[string]map[string]string
+		ExpectInService bool
+		ExpectPending   bool
+	}{
+		"no load balancers": {
+			ExpectInService: true,
+		},
+		"classic ELB in service": {
+			ELBStates:       map[string]map[string]string{"elb": {"i-1": "InService"}},
+			ExpectInService: true,
+		},
+		"classic ELB out of service": {
+			ELBStates: map[string]map[string]string{"elb": {"i-1": "OutOfService"}},
+		},
+		"target healthy": {
+			TargetStates:    map[string]map[string]string{"tg": {"i-1": elbv2.TargetHealthStateEnumHealthy}},
+			ExpectInService: true,
+		},
+		"target initial": {
+			TargetStates:  map[string]map[string]string{"tg": {"i-1": elbv2.TargetHealthStateEnumInitial}},
+			ExpectPending: true,
+		},
+		"target draining": {
+			TargetStates: map[string]map[string]string{"tg": {"i-1": elbv2.TargetHealthStateEnumDraining}},
+		},
+		"target not registered": {
+			TargetStates: map[string]map[string]string{"tg": {"i-2": elbv2.TargetHealthStateEnumHealthy}},
+		},
+		"healthy in one of two target groups": {
+			TargetStates: map[string]map[string]string{
+				"tg1": {"i-1": elbv2.TargetHealthStateEnumHealthy},
+				"tg2": {"i-1": elbv2.TargetHealthStateEnumInitial},
+			},
+			ExpectPending: true,
+		},
+		"ELB in service but target unhealthy": {
+			ELBStates:    map[string]map[string]string{"elb": {"i-1": "InService"}},
+			TargetStates: map[string]map[string]string{"tg": {"i-1": elbv2.TargetHealthStateEnumUnhealthy}},
+		},
+	}
+
+	for tn, tc := range cases {
+		gotInService, gotPending := autoScalingGroupInstanceLoadBalancerState("i-1", tc.ELBStates, tc.TargetStates)
+
+		if gotInService != tc.ExpectInService {
+			t.Errorf("%s: expected in service: %t, got: %t", tn, tc.ExpectInService, gotInService)
+		}
+
+		if gotPending != tc.ExpectPending {
+			t.Errorf("%s: expected pending: %t, got: %t", tn, tc.ExpectPending, gotPending)
+		}
+	}
+}
+
+func TestFlattenAutoScalingGroupTargetHealthStates(t *testing.T) {
+	description := func(id, state string) *elbv2.TargetHealthDescription {
+		return &elbv2.TargetHealthDescription{
+			Target:       &elbv2.TargetDescription{Id: aws.String(id)},
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		}
+	}
+
+	got := flattenAutoScalingGroupTargetHealthStates([]*elbv2.TargetHealthDescription{
+		nil,
+		{Target: &elbv2.TargetDescription{Id: aws.String("i-0")}},
+		description("i-1", elbv2.TargetHealthStateEnumHealthy),
+		description("i-2", elbv2.TargetHealthStateEnumHealthy),
+		description("i-2", elbv2.TargetHealthStateEnumDraining),
+		description("i-3", elbv2.TargetHealthStateEnumInitial),
+		description("i-3", elbv2.TargetHealthStateEnumHealthy),
+	})
+
+	expected := map[string]string{
+		"i-1": elbv2.TargetHealthStateEnumHealthy,
+		"i-2": elbv2.TargetHealthStateEnumDraining,
+		"i-3": elbv2.TargetHealthStateEnumInitial,
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestAutoScalingGroupFailedScalingActivityError(t *testing.T) {
+	cases := map[string]struct {
+		Activities  []*autoscaling.Activity
+		ExpectError string
+	}{
+		"no activities": {},
+		"successful and in progress": {
+			Activities: []*autoscaling.Activity{
+				{
+					ActivityId: aws.String("a2"),
+					StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeInProgress),
+				},
+				{
+					ActivityId: aws.String("a1"),
+					StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+				},
+			},
+		},
+		"failed": {
+			Activities: []*autoscaling.Activity{
+				{
+					ActivityId: aws.String("a2"),
+					StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeInProgress),
+				},
+				{
+					ActivityId:    aws.String("a1"),
+					Description:   aws.String("Launching a new EC2 instance"),
+					StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+					StatusMessage: aws.String("The architecture 'arm64' of the specified instance type does not match the architecture 'x86_64' of the specified AMI."),
+				},
+			},
+			ExpectError: "scaling activity (a1) failed: Launching a new EC2 instance: The architecture 'arm64' of the specified instance type does not match the architecture 'x86_64' of the specified AMI.",
+		},
+		"cancelled": {
+			Activities: []*autoscaling.Activity{
+				nil,
+				{
+					ActivityId:    aws.String("a1"),
+					Description:   aws.String("Launching a new EC2 instance"),
+					StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeCancelled),
+					StatusMessage: aws.String("Cancelled"),
+				},
+			},
+			ExpectError: "scaling activity (a1) cancelled: Launching a new EC2 instance: Cancelled",
+		},
+	}
+
+	for name, tc := range cases {
+		err := autoScalingGroupFailedScalingActivityError(tc.Activities)
+
+		if tc.ExpectError == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", name, err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Errorf("%s: expected error %q, got none", name, tc.ExpectError)
+			continue
+		}
+
+		if got := err.Error(); got != tc.ExpectError {
+			t.Errorf("%s: expected error %q, got %q", name, tc.ExpectError, got)
+		}
+	}
+}
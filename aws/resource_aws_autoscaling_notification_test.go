@@ -25,7 +25,7 @@ func TestAccAWSASGNotification_basic(t *testing.T) {
 			{
 				Config: testAccASGNotificationConfig_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"foobar1-terraform-test-" + rName}, &asgn),
+					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"terraform-test-foobar1-" + rName}, &asgn),
 					testAccCheckAWSASGNotificationAttributes("aws_autoscaling_notification.example", &asgn),
 				),
 			},
@@ -46,7 +46,7 @@ func TestAccAWSASGNotification_update(t *testing.T) {
 			{
 				Config: testAccASGNotificationConfig_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"foobar1-terraform-test-" + rName}, &asgn),
+					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"terraform-test-foobar1-" + rName}, &asgn),
 					testAccCheckAWSASGNotificationAttributes("aws_autoscaling_notification.example", &asgn),
 				),
 			},
@@ -54,7 +54,7 @@ func TestAccAWSASGNotification_update(t *testing.T) {
 			{
 				Config: testAccASGNotificationConfig_update(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"foobar1-terraform-test-" + rName, "barfoo-terraform-test-" + rName}, &asgn),
+					testAccCheckASGNotificationExists("aws_autoscaling_notification.example", []string{"terraform-test-foobar1-" + rName, "terraform-test-barfoo-" + rName}, &asgn),
 					testAccCheckAWSASGNotificationAttributes("aws_autoscaling_notification.example", &asgn),
 				),
 			},
@@ -77,26 +77,26 @@ func TestAccAWSASGNotification_Pagination(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckASGNotificationExists(resourceName,
 						[]string{
-							"foobar3-terraform-test-0",
-							"foobar3-terraform-test-1",
-							"foobar3-terraform-test-2",
-							"foobar3-terraform-test-3",
-							"foobar3-terraform-test-4",
-							"foobar3-terraform-test-5",
-							"foobar3-terraform-test-6",
-							"foobar3-terraform-test-7",
-							"foobar3-terraform-test-8",
-							"foobar3-terraform-test-9",
-							"foobar3-terraform-test-10",
-							"foobar3-terraform-test-11",
-							"foobar3-terraform-test-12",
-							"foobar3-terraform-test-13",
-							"foobar3-terraform-test-14",
-							"foobar3-terraform-test-15",
-							"foobar3-terraform-test-16",
-							"foobar3-terraform-test-17",
-							"foobar3-terraform-test-18",
-							"foobar3-terraform-test-19",
+							"terraform-test-foobar3-0",
+							"terraform-test-foobar3-1",
+							"terraform-test-foobar3-2",
+							"terraform-test-foobar3-3",
+							"terraform-test-foobar3-4",
+							"terraform-test-foobar3-5",
+							"terraform-test-foobar3-6",
+							"terraform-test-foobar3-7",
+							"terraform-test-foobar3-8",
+							"terraform-test-foobar3-9",
+							"terraform-test-foobar3-10",
+							"terraform-test-foobar3-11",
+							"terraform-test-foobar3-12",
+							"terraform-test-foobar3-13",
+							"terraform-test-foobar3-14",
+							"terraform-test-foobar3-15",
+							"terraform-test-foobar3-16",
+							"terraform-test-foobar3-17",
+							"terraform-test-foobar3-18",
+							"terraform-test-foobar3-19",
 						}, &asgn),
 					testAccCheckAWSASGNotificationAttributes(resourceName, &asgn),
 				),
@@ -139,7 +139,7 @@ func testAccCheckASGNDestroy(s *terraform.State) error {
 			continue
 		}
 
-		groups := []*string{aws.String("foobar1-terraform-test")}
+		groups := []*string{aws.String("terraform-test-foobar1")}
 		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
 		opts := &autoscaling.DescribeNotificationConfigurationsInput{
 			AutoScalingGroupNames: groups,
@@ -235,7 +235,7 @@ data "aws_availability_zones" "available" {
 
 resource "aws_autoscaling_group" "bar" {
   availability_zones        = [data.aws_availability_zones.available.names[1]]
-  name                      = "foobar1-terraform-test-%s"
+  name                      = "terraform-test-foobar1-%s"
   max_size                  = 1
   min_size                  = 1
   health_check_grace_period = 100
@@ -282,7 +282,7 @@ data "aws_availability_zones" "available" {
 
 resource "aws_autoscaling_group" "bar" {
   availability_zones        = [data.aws_availability_zones.available.names[1]]
-  name                      = "foobar1-terraform-test-%s"
+  name                      = "terraform-test-foobar1-%s"
   max_size                  = 1
   min_size                  = 1
   health_check_grace_period = 100
@@ -295,7 +295,7 @@ resource "aws_autoscaling_group" "bar" {
 
 resource "aws_autoscaling_group" "foo" {
   availability_zones        = [data.aws_availability_zones.available.names[2]]
-  name                      = "barfoo-terraform-test-%s"
+  name                      = "terraform-test-barfoo-%s"
   max_size                  = 1
   min_size                  = 1
   health_check_grace_period = 200
@@ -346,7 +346,7 @@ data "aws_availability_zones" "available" {
 resource "aws_autoscaling_group" "bar" {
   availability_zones        = [data.aws_availability_zones.available.names[1]]
   count                     = 20
-  name                      = "foobar3-terraform-test-${count.index}"
+  name                      = "terraform-test-foobar3-${count.index}"
   max_size                  = 1
   min_size                  = 0
   health_check_grace_period = 300
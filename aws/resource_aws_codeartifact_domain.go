@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/codeartifact"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
@@ -20,7 +22,7 @@ func resourceAwsCodeArtifactDomain() *schema.Resource {
 		Delete: resourceAwsCodeArtifactDomainDelete,
 		Update: resourceAwsCodeArtifactDomainUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAwsCodeArtifactDomainImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,11 +36,17 @@ func resourceAwsCodeArtifactDomain() *schema.Resource {
 				ForceNew: true,
 			},
 			"encryption_key": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				// Changes replace the domain unless they are between a KMS alias ARN
+				// and the ARN of the key it refers to. See CustomizeDiff.
 				ValidateFunc: validateArn,
 			},
+			"encryption_key_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"owner": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -55,19 +63,67 @@ func resourceAwsCodeArtifactDomain() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"s3_bucket_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags": tagsSchema(),
 		},
+
+		CustomizeDiff: resourceAwsCodeArtifactDomainCustomizeDiff,
 	}
 }
 
+// resourceAwsCodeArtifactDomainCustomizeDiff replaces the domain when encryption_key changes,
+// unless it changes between a KMS alias ARN and the ARN of the key it refers to, e.g. after import.
+// The domain's key is unchanged in that case, so the change is applied in place without any API call.
+func resourceAwsCodeArtifactDomainCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("encryption_key") {
+		return nil
+	}
+
+	if !diff.NewValueKnown("encryption_key") {
+		return diff.ForceNew("encryption_key")
+	}
+
+	o, n := diff.GetChange("encryption_key")
+	oldKey, newKey := o.(string), n.(string)
+
+	if oldKey == "" || newKey == "" || (!isKmsAliasArn(oldKey) && !isKmsAliasArn(newKey)) {
+		return diff.ForceNew("encryption_key")
+	}
+
+	conn := meta.(*AWSClient).kmsconn
+
+	oldKeyArn, err := codeArtifactDomainEncryptionKeyArn(conn, oldKey)
+	if err != nil {
+		return err
+	}
+
+	newKeyArn, err := codeArtifactDomainEncryptionKeyArn(conn, newKey)
+	if err != nil {
+		return err
+	}
+
+	if oldKeyArn != newKeyArn {
+		return diff.ForceNew("encryption_key")
+	}
+
+	return nil
+}
+
 func resourceAwsCodeArtifactDomainCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).codeartifactconn
 	log.Print("[DEBUG] Creating CodeArtifact Domain")
 
 	params := &codeartifact.CreateDomainInput{
-		Domain:        aws.String(d.Get("domain").(string)),
-		EncryptionKey: aws.String(d.Get("encryption_key").(string)),
-		Tags:          keyvaluetags.New(d.Get("tags").(map[string]interface{})).IgnoreAws().CodeartifactTags(),
+		Domain: aws.String(d.Get("domain").(string)),
+		Tags:   keyvaluetags.New(d.Get("tags").(map[string]interface{})).IgnoreAws().CodeartifactTags(),
+	}
+
+	// The aws/codeartifact AWS managed key is used when no key is specified.
+	if v, ok := d.GetOk("encryption_key"); ok {
+		params.EncryptionKey = aws.String(v.(string))
 	}
 
 	domain, err := conn.CreateDomain(params)
@@ -107,11 +163,30 @@ func resourceAwsCodeArtifactDomainRead(d *schema.ResourceData, meta interface{})
 	arn := aws.StringValue(sm.Domain.Arn)
 	d.Set("domain", sm.Domain.Name)
 	d.Set("arn", arn)
-	d.Set("encryption_key", sm.Domain.EncryptionKey)
+	d.Set("encryption_key_arn", sm.Domain.EncryptionKey)
+
+	// Keep a configured alias ARN as long as it still refers to the domain's key.
+	encryptionKey := aws.StringValue(sm.Domain.EncryptionKey)
+	if v := d.Get("encryption_key").(string); isKmsAliasArn(v) {
+		keyArn, err := codeArtifactDomainEncryptionKeyArn(meta.(*AWSClient).kmsconn, v)
+
+		if err != nil {
+			log.Printf("[WARN] Unable to resolve CodeArtifact Domain (%s) encryption key alias (%s): %s", d.Id(), v, err)
+		} else if keyArn == encryptionKey {
+			encryptionKey = v
+		}
+	}
+	d.Set("encryption_key", encryptionKey)
 	d.Set("owner", sm.Domain.Owner)
 	d.Set("asset_size_bytes", sm.Domain.AssetSizeBytes)
 	d.Set("repository_count", sm.Domain.RepositoryCount)
-	d.Set("created_time", sm.Domain.CreatedTime.Format(time.RFC3339))
+	d.Set("s3_bucket_arn", sm.Domain.S3BucketArn)
+	// CreatedTime is not returned for domains that are being deleted.
+	if v := sm.Domain.CreatedTime; v != nil {
+		d.Set("created_time", v.Format(time.RFC3339))
+	} else {
+		d.Set("created_time", nil)
+	}
 
 	tags, err := keyvaluetags.CodeartifactListTags(conn, arn)
 
@@ -166,12 +241,100 @@ func resourceAwsCodeArtifactDomainDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func resourceAwsCodeArtifactDomainImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if arn.IsARN(d.Id()) {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	conn := meta.(*AWSClient).codeartifactconn
+
+	domainOwner, domainName, err := decodeCodeArtifactDomainID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	input := &codeartifact.DescribeDomainInput{
+		Domain: aws.String(domainName),
+	}
+
+	// DescribeDomain defaults to the caller's account when no owner is given.
+	if domainOwner != "" {
+		input.DomainOwner = aws.String(domainOwner)
+	}
+
+	output, err := conn.DescribeDomain(input)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading CodeArtifact Domain (%s): %w", d.Id(), err)
+	}
+
+	if output == nil || output.Domain == nil {
+		return nil, fmt.Errorf("error reading CodeArtifact Domain (%s): empty response", d.Id())
+	}
+
+	d.SetId(aws.StringValue(output.Domain.Arn))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// decodeCodeArtifactDomainID returns the owner and name of the domain identified by
+// a domain ARN, an "owner:domain" pair or a bare domain name, for which the owner is empty.
 func decodeCodeArtifactDomainID(id string) (string, string, error) {
-	repoArn, err := arn.Parse(id)
+	if arn.IsARN(id) {
+		domainArn, err := arn.Parse(id)
+		if err != nil {
+			return "", "", err
+		}
+
+		domainName := strings.TrimPrefix(domainArn.Resource, "domain/")
+		if domainArn.Service != codeartifact.ServiceName || domainName == domainArn.Resource || domainName == "" || domainArn.AccountID == "" {
+			return "", "", fmt.Errorf("unexpected format of CodeArtifact Domain ARN (%s), expected arn:PARTITION:codeartifact:REGION:OWNER:domain/DOMAIN", id)
+		}
+
+		return domainArn.AccountID, domainName, nil
+	}
+
+	idParts := strings.Split(id, ":")
+
+	switch {
+	case len(idParts) == 1 && idParts[0] != "":
+		return "", idParts[0], nil
+	case len(idParts) == 2 && idParts[0] != "" && idParts[1] != "":
+		return idParts[0], idParts[1], nil
+	}
+
+	return "", "", fmt.Errorf("unexpected format of ID (%s), expected DOMAIN, OWNER:DOMAIN or domain ARN", id)
+}
+
+// isKmsAliasArn returns whether the specified value is a KMS alias ARN.
+func isKmsAliasArn(s string) bool {
+	parsedArn, err := arn.Parse(s)
+
+	if err != nil {
+		return false
+	}
+
+	return parsedArn.Service == kms.ServiceName && strings.HasPrefix(parsedArn.Resource, "alias/")
+}
+
+// codeArtifactDomainEncryptionKeyArn returns the ARN of the KMS key identified by the
+// specified key or alias ARN.
+func codeArtifactDomainEncryptionKeyArn(conn *kms.KMS, key string) (string, error) {
+	if !isKmsAliasArn(key) {
+		return key, nil
+	}
+
+	output, err := conn.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(key),
+	})
+
 	if err != nil {
-		return "", "", err
+		return "", fmt.Errorf("error describing KMS key (%s): %w", key, err)
+	}
+
+	if output == nil || output.KeyMetadata == nil {
+		return "", fmt.Errorf("error describing KMS key (%s): empty response", key)
 	}
 
-	domainName := strings.TrimPrefix(repoArn.Resource, "domain/")
-	return repoArn.AccountID, domainName, nil
+	return aws.StringValue(output.KeyMetadata.Arn), nil
 }
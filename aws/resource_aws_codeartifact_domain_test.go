@@ -3,6 +3,8 @@ package aws
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -27,45 +29,170 @@ func testSweepCodeArtifactDomains(region string) error {
 	}
 	conn := client.(*AWSClient).codeartifactconn
 	input := &codeartifact.ListDomainsInput{}
-	var sweeperErrs *multierror.Error
+	var domains []*codeartifact.DomainSummary
 
 	err = conn.ListDomainsPages(input, func(page *codeartifact.ListDomainsOutput, lastPage bool) bool {
-		for _, domainPtr := range page.Domains {
-			if domainPtr == nil {
+		for _, domain := range page.Domains {
+			if domain == nil || !strings.HasPrefix(aws.StringValue(domain.Name), "tf-acc-test") {
 				continue
 			}
 
-			domain := aws.StringValue(domainPtr.Name)
-			input := &codeartifact.DeleteDomainInput{
-				Domain: domainPtr.Name,
+			domains = append(domains, domain)
+		}
+
+		return !lastPage
+	})
+
+	if testSweepSkipSweepError(err) {
+		log.Printf("[WARN] Skipping CodeArtifact Domain sweep for %s: %s", region, err)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error listing CodeArtifact Domains: %w", err)
+	}
+
+	var sweeperErrs *multierror.Error
+
+	for _, domain := range domains {
+		name := aws.StringValue(domain.Name)
+
+		// A domain cannot be deleted while it contains repositories.
+		if err := testSweepCodeArtifactDomainRepositories(conn, domain); err != nil {
+			sweeperErr := fmt.Errorf("error deleting CodeArtifact Domain (%s) repositories: %w", name, err)
+			log.Printf("[ERROR] %s", sweeperErr)
+			sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+			continue
+		}
+
+		log.Printf("[INFO] Deleting CodeArtifact Domain: %s", name)
+
+		_, err := conn.DeleteDomain(&codeartifact.DeleteDomainInput{
+			Domain:      domain.Name,
+			DomainOwner: domain.Owner,
+		})
+
+		if isAWSErr(err, codeartifact.ErrCodeResourceNotFoundException, "") {
+			continue
+		}
+
+		if err != nil {
+			sweeperErr := fmt.Errorf("error deleting CodeArtifact Domain (%s): %w", name, err)
+			log.Printf("[ERROR] %s", sweeperErr)
+			sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+		}
+	}
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+func testSweepCodeArtifactDomainRepositories(conn *codeartifact.CodeArtifact, domain *codeartifact.DomainSummary) error {
+	input := &codeartifact.ListRepositoriesInDomainInput{
+		Domain:      domain.Name,
+		DomainOwner: domain.Owner,
+	}
+	var sweeperErrs *multierror.Error
+
+	err := conn.ListRepositoriesInDomainPages(input, func(page *codeartifact.ListRepositoriesInDomainOutput, lastPage bool) bool {
+		for _, repository := range page.Repositories {
+			if repository == nil {
+				continue
 			}
 
-			log.Printf("[INFO] Deleting CodeArtifact Domain: %s", domain)
+			name := aws.StringValue(repository.Name)
+
+			log.Printf("[INFO] Deleting CodeArtifact Repository: %s", name)
+
+			_, err := conn.DeleteRepository(&codeartifact.DeleteRepositoryInput{
+				Repository:  repository.Name,
+				Domain:      repository.DomainName,
+				DomainOwner: repository.DomainOwner,
+			})
 
-			_, err := conn.DeleteDomain(input)
+			if isAWSErr(err, codeartifact.ErrCodeResourceNotFoundException, "") {
+				continue
+			}
 
 			if err != nil {
-				sweeperErr := fmt.Errorf("error deleting CodeArtifact Domain (%s): %w", domain, err)
-				log.Printf("[ERROR] %s", sweeperErr)
-				sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+				sweeperErrs = multierror.Append(sweeperErrs, fmt.Errorf("error deleting CodeArtifact Repository (%s): %w", name, err))
 			}
 		}
 
 		return !lastPage
 	})
 
-	if testSweepSkipSweepError(err) {
-		log.Printf("[WARN] Skipping CodeArtifact Domain sweep for %s: %s", region, err)
+	if isAWSErr(err, codeartifact.ErrCodeResourceNotFoundException, "") {
 		return nil
 	}
 
 	if err != nil {
-		return fmt.Errorf("error listing CodeArtifact Domains: %w", err)
+		return fmt.Errorf("error listing CodeArtifact Repositories: %w", err)
 	}
 
 	return sweeperErrs.ErrorOrNil()
 }
 
+func TestIsKmsAliasArn(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "arn:aws:kms:us-west-2:123456789012:alias/aws/codeartifact", expected: true},
+		{value: "arn:aws-us-gov:kms:us-gov-west-1:123456789012:alias/example", expected: true},
+		{value: "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+		{value: "arn:aws:iam::123456789012:role/alias/example"},
+		{value: "alias/aws/codeartifact"},
+		{value: ""},
+	}
+
+	for _, testCase := range testCases {
+		if got := isKmsAliasArn(testCase.value); got != testCase.expected {
+			t.Errorf("%q: got %t, expected %t", testCase.value, got, testCase.expected)
+		}
+	}
+}
+
+func TestDecodeCodeArtifactDomainID(t *testing.T) {
+	testCases := []struct {
+		id            string
+		owner         string
+		domain        string
+		expectedError bool
+	}{
+		{id: "arn:aws:codeartifact:us-west-2:123456789012:domain/example", owner: "123456789012", domain: "example"},
+		{id: "arn:aws-us-gov:codeartifact:us-gov-west-1:123456789012:domain/example", owner: "123456789012", domain: "example"},
+		{id: "123456789012:example", owner: "123456789012", domain: "example"},
+		{id: "example", domain: "example"},
+		{id: "", expectedError: true},
+		{id: ":example", expectedError: true},
+		{id: "123456789012:", expectedError: true},
+		{id: "123456789012:example:extra", expectedError: true},
+		{id: "arn:aws:codeartifact:us-west-2:123456789012:repository/example/repo", expectedError: true},
+		{id: "arn:aws:codeartifact:us-west-2::domain/example", expectedError: true},
+		{id: "arn:aws:kms:us-west-2:123456789012:domain/example", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		owner, domain, err := decodeCodeArtifactDomainID(testCase.id)
+
+		if testCase.expectedError {
+			if err == nil {
+				t.Errorf("%q: expected error", testCase.id)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", testCase.id, err)
+			continue
+		}
+
+		if owner != testCase.owner || domain != testCase.domain {
+			t.Errorf("%q: got (%q, %q), expected (%q, %q)", testCase.id, owner, domain, testCase.owner, testCase.domain)
+		}
+	}
+}
+
 func TestAccAWSCodeArtifactDomain_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_codeartifact_domain.test"
@@ -83,8 +210,10 @@ func TestAccAWSCodeArtifactDomain_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "domain", rName),
 					resource.TestCheckResourceAttr(resourceName, "asset_size_bytes", "0"),
 					resource.TestCheckResourceAttr(resourceName, "repository_count", "0"),
+					testAccMatchResourceAttrGlobalARNNoAccount(resourceName, "s3_bucket_arn", "s3", regexp.MustCompile(`.+`)),
 					resource.TestCheckResourceAttrSet(resourceName, "created_time"),
 					resource.TestCheckResourceAttrPair(resourceName, "encryption_key", "aws_kms_key.test", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key_arn", "aws_kms_key.test", "arn"),
 					testAccCheckResourceAttrAccountID(resourceName, "owner"),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
 				),
@@ -94,6 +223,95 @@ func TestAccAWSCodeArtifactDomain_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     rName,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccAWSCodeArtifactDomainImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSCodeArtifactDomain_defaultEncryptionKey(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_codeartifact_domain.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(codeartifact.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCodeArtifactDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCodeArtifactDomainConfigDefaultEncryptionKey(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactDomainExists(resourceName),
+					testAccMatchResourceAttrRegionalARN(resourceName, "encryption_key", "kms", regexp.MustCompile(fmt.Sprintf("key/%s$", uuidRegexPattern))),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key_arn", resourceName, "encryption_key"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Changing from the AWS managed key to a customer managed key replaces the domain.
+				Config: testAccAWSCodeArtifactDomainBasicConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactDomainExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key", "aws_kms_key.test", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key_arn", "aws_kms_key.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCodeArtifactDomain_encryptionKeyAlias(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_codeartifact_domain.test"
+	var createdTime string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(codeartifact.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCodeArtifactDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCodeArtifactDomainConfigEncryptionKeyAlias(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactDomainExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key", "aws_kms_alias.test", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key_arn", "aws_kms_key.test", "arn"),
+					func(s *terraform.State) error {
+						createdTime = s.RootModule().Resources[resourceName].Primary.Attributes["created_time"]
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"encryption_key"},
+			},
+			{
+				// Switching between an alias ARN and the ARN of its key does not replace the domain.
+				Config: testAccAWSCodeArtifactDomainConfigEncryptionKeyAliasTarget(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactDomainExists(resourceName),
+					resource.TestCheckResourceAttrPtr(resourceName, "created_time", &createdTime),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key", "aws_kms_key.test", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "encryption_key_arn", "aws_kms_key.test", "arn"),
+				),
+			},
 		},
 	})
 }
@@ -222,6 +440,17 @@ func testAccCheckAWSCodeArtifactDomainDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testAccAWSCodeArtifactDomainImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["domain"]), nil
+	}
+}
+
 func testAccAWSCodeArtifactDomainBasicConfig(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_kms_key" "test" {
@@ -236,6 +465,46 @@ resource "aws_codeartifact_domain" "test" {
 `, rName)
 }
 
+func testAccAWSCodeArtifactDomainConfigDefaultEncryptionKey(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_codeartifact_domain" "test" {
+  domain = %[1]q
+}
+`, rName)
+}
+
+func testAccAWSCodeArtifactDomainConfigEncryptionKeyAliasBase(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
+resource "aws_kms_alias" "test" {
+  name          = "alias/%[1]s"
+  target_key_id = aws_kms_key.test.key_id
+}
+`, rName)
+}
+
+func testAccAWSCodeArtifactDomainConfigEncryptionKeyAlias(rName string) string {
+	return composeConfig(testAccAWSCodeArtifactDomainConfigEncryptionKeyAliasBase(rName), fmt.Sprintf(`
+resource "aws_codeartifact_domain" "test" {
+  domain         = %[1]q
+  encryption_key = aws_kms_alias.test.arn
+}
+`, rName))
+}
+
+func testAccAWSCodeArtifactDomainConfigEncryptionKeyAliasTarget(rName string) string {
+	return composeConfig(testAccAWSCodeArtifactDomainConfigEncryptionKeyAliasBase(rName), fmt.Sprintf(`
+resource "aws_codeartifact_domain" "test" {
+  domain         = %[1]q
+  encryption_key = aws_kms_key.test.arn
+}
+`, rName))
+}
+
 func testAccAWSCodeArtifactDomainConfigTags1(rName, tagKey1, tagValue1 string) string {
 	return fmt.Sprintf(`
 resource "aws_kms_key" "test" {
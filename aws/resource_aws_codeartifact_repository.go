@@ -157,11 +157,10 @@ func resourceAwsCodeArtifactRepositoryUpdate(d *schema.ResourceData, meta interf
 		}
 	}
 
+	// The upstreams are replaced as a whole, in order, and removed with an empty list.
 	if d.HasChange("upstream") {
-		if v, ok := d.GetOk("upstream"); ok {
-			params.Upstreams = expandCodeArtifactUpstreams(v.([]interface{}))
-			needsUpdate = true
-		}
+		params.Upstreams = expandCodeArtifactUpstreams(d.Get("upstream").([]interface{}))
+		needsUpdate = true
 	}
 
 	if needsUpdate {
@@ -172,32 +171,37 @@ func resourceAwsCodeArtifactRepositoryUpdate(d *schema.ResourceData, meta interf
 	}
 
 	if d.HasChange("external_connections") {
-		if v, ok := d.GetOk("external_connections"); ok {
-			externalConnection := v.([]interface{})[0].(map[string]interface{})
-			input := &codeartifact.AssociateExternalConnectionInput{
+		o, n := d.GetChange("external_connections")
+		oldName := codeArtifactExternalConnectionName(o.([]interface{}))
+		newName := codeArtifactExternalConnectionName(n.([]interface{}))
+
+		// A repository can only have one external connection, so the old one is
+		// disassociated before the new one is associated.
+		if oldName != "" && oldName != newName {
+			input := &codeartifact.DisassociateExternalConnectionInput{
 				Repository:         aws.String(d.Get("repository").(string)),
 				Domain:             aws.String(d.Get("domain").(string)),
 				DomainOwner:        aws.String(d.Get("domain_owner").(string)),
-				ExternalConnection: aws.String(externalConnection["external_connection_name"].(string)),
+				ExternalConnection: aws.String(oldName),
 			}
 
-			_, err := conn.AssociateExternalConnection(input)
+			_, err := conn.DisassociateExternalConnection(input)
 			if err != nil {
-				return fmt.Errorf("error associating external connection to CodeArtifact repository: %w", err)
+				return fmt.Errorf("error disassociating external connection to CodeArtifact repository: %w", err)
 			}
-		} else {
-			oldConn, _ := d.GetChange("external_connections")
-			externalConnection := oldConn.([]interface{})[0].(map[string]interface{})
-			input := &codeartifact.DisassociateExternalConnectionInput{
+		}
+
+		if newName != "" && newName != oldName {
+			input := &codeartifact.AssociateExternalConnectionInput{
 				Repository:         aws.String(d.Get("repository").(string)),
 				Domain:             aws.String(d.Get("domain").(string)),
 				DomainOwner:        aws.String(d.Get("domain_owner").(string)),
-				ExternalConnection: aws.String(externalConnection["external_connection_name"].(string)),
+				ExternalConnection: aws.String(newName),
 			}
 
-			_, err := conn.DisassociateExternalConnection(input)
+			_, err := conn.AssociateExternalConnection(input)
 			if err != nil {
-				return fmt.Errorf("error disassociating external connection to CodeArtifact repository: %w", err)
+				return fmt.Errorf("error associating external connection to CodeArtifact repository: %w", err)
 			}
 		}
 	}
@@ -244,16 +248,13 @@ func resourceAwsCodeArtifactRepositoryRead(d *schema.ResourceData, meta interfac
 	d.Set("administrator_account", sm.Repository.AdministratorAccount)
 	d.Set("description", sm.Repository.Description)
 
-	if sm.Repository.Upstreams != nil {
-		if err := d.Set("upstream", flattenCodeArtifactUpstreams(sm.Repository.Upstreams)); err != nil {
-			return fmt.Errorf("[WARN] Error setting upstream: %w", err)
-		}
+	// The API returns the upstreams in priority order.
+	if err := d.Set("upstream", flattenCodeArtifactUpstreams(sm.Repository.Upstreams)); err != nil {
+		return fmt.Errorf("error setting upstream: %w", err)
 	}
 
-	if sm.Repository.ExternalConnections != nil {
-		if err := d.Set("external_connections", flattenCodeArtifactExternalConnections(sm.Repository.ExternalConnections)); err != nil {
-			return fmt.Errorf("[WARN] Error setting external_connections: %w", err)
-		}
+	if err := d.Set("external_connections", flattenCodeArtifactExternalConnections(sm.Repository.ExternalConnections)); err != nil {
+		return fmt.Errorf("error setting external_connections: %w", err)
 	}
 
 	tags, err := keyvaluetags.CodeartifactListTags(conn, arn)
@@ -349,6 +350,16 @@ func flattenCodeArtifactExternalConnections(connections []*codeartifact.Reposito
 	return ls
 }
 
+// codeArtifactExternalConnectionName returns the name of the external connection in
+// external_connections, or an empty string if there is none.
+func codeArtifactExternalConnectionName(l []interface{}) string {
+	if len(l) == 0 || l[0] == nil {
+		return ""
+	}
+
+	return l[0].(map[string]interface{})["external_connection_name"].(string)
+}
+
 func decodeCodeArtifactRepositoryID(id string) (string, string, string, error) {
 	repoArn, err := arn.Parse(id)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/codeartifact"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -79,6 +80,39 @@ func TestAccAWSCodeArtifactRepositoryPermissionsPolicy_owner(t *testing.T) {
 	})
 }
 
+func TestAccAWSCodeArtifactRepositoryPermissionsPolicy_crossAccount(t *testing.T) {
+	var providers []*schema.Provider
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_codeartifact_repository_permissions_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPartitionHasServicePreCheck(codeartifact.EndpointsID, t)
+			testAccAlternateAccountPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactoriesAlternate(&providers),
+		CheckDestroy:      testAccCheckAWSCodeArtifactRepositoryPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCodeArtifactRepositoryPermissionsPolicyCrossAccountConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactRepositoryPermissionsExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_arn", "aws_codeartifact_repository.test", "arn"),
+					resource.TestMatchResourceAttr(resourceName, "policy_document", regexp.MustCompile("codeartifact:ReadFromRepository")),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_revision"),
+				),
+			},
+			{
+				Config:            testAccAWSCodeArtifactRepositoryPermissionsPolicyCrossAccountConfig(rName),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSCodeArtifactRepositoryPermissionsPolicy_disappears(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_codeartifact_repository_permissions_policy.test"
@@ -259,6 +293,61 @@ EOF
 `, rName)
 }
 
+func testAccAWSCodeArtifactRepositoryPermissionsPolicyCrossAccountConfig(rName string) string {
+	return testAccAlternateAccountProviderConfig() + fmt.Sprintf(`
+data "aws_caller_identity" "alternate" {
+  provider = "awsalternate"
+}
+
+data "aws_partition" "current" {}
+
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
+resource "aws_codeartifact_domain" "test" {
+  domain         = %[1]q
+  encryption_key = aws_kms_key.test.arn
+}
+
+resource "aws_codeartifact_repository" "test" {
+  repository = %[1]q
+  domain     = aws_codeartifact_domain.test.domain
+}
+
+resource "aws_codeartifact_repository_permissions_policy" "test" {
+  domain          = aws_codeartifact_domain.test.domain
+  repository      = aws_codeartifact_repository.test.repository
+  policy_document = <<EOF
+{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Action": [
+                "codeartifact:DescribePackageVersion",
+                "codeartifact:DescribeRepository",
+                "codeartifact:GetPackageVersionReadme",
+                "codeartifact:GetRepositoryEndpoint",
+                "codeartifact:ListPackageVersionAssets",
+                "codeartifact:ListPackageVersionDependencies",
+                "codeartifact:ListPackageVersions",
+                "codeartifact:ListPackages",
+                "codeartifact:ReadFromRepository"
+            ],
+            "Effect": "Allow",
+            "Principal": {
+                "AWS": "arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.alternate.account_id}:root"
+            },
+            "Resource": "*"
+        }
+    ]
+}
+EOF
+}
+`, rName)
+}
+
 func testAccAWSCodeArtifactRepositoryPermissionsPolicyUpdatedConfig(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_kms_key" "test" {
@@ -3,6 +3,7 @@ package aws
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -251,6 +252,122 @@ func TestAccAWSCodeArtifactRepository_upstreams(t *testing.T) {
 	})
 }
 
+func TestAccAWSCodeArtifactRepository_upstreamsReorder(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_codeartifact_repository.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(codeartifact.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCodeArtifactRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCodeArtifactRepositoryUpstreamsConfigOrdered(rName, "upstream1", "upstream2", "upstream3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactRepositoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.repository_name", fmt.Sprintf("%s-upstream1", rName)),
+					resource.TestCheckResourceAttr(resourceName, "upstream.1.repository_name", fmt.Sprintf("%s-upstream2", rName)),
+					resource.TestCheckResourceAttr(resourceName, "upstream.2.repository_name", fmt.Sprintf("%s-upstream3", rName)),
+					resource.TestCheckResourceAttr("aws_codeartifact_repository.upstream3", "external_connections.0.external_connection_name", "public:npmjs"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAWSCodeArtifactRepositoryUpstreamsConfigOrdered(rName, "upstream3", "upstream1", "upstream2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactRepositoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.repository_name", fmt.Sprintf("%s-upstream3", rName)),
+					resource.TestCheckResourceAttr(resourceName, "upstream.1.repository_name", fmt.Sprintf("%s-upstream1", rName)),
+					resource.TestCheckResourceAttr(resourceName, "upstream.2.repository_name", fmt.Sprintf("%s-upstream2", rName)),
+				),
+			},
+			{
+				Config:   testAccAWSCodeArtifactRepositoryUpstreamsConfigOrdered(rName, "upstream3", "upstream1", "upstream2"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAWSCodeArtifactRepositoryUpstreamsConfigNone(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeArtifactRepositoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandCodeArtifactUpstreams(t *testing.T) {
+	got := expandCodeArtifactUpstreams([]interface{}{
+		map[string]interface{}{"repository_name": "upstream3"},
+		map[string]interface{}{"repository_name": "upstream1"},
+		map[string]interface{}{"repository_name": "upstream2"},
+	})
+
+	var names []string
+	for _, upstream := range got {
+		names = append(names, aws.StringValue(upstream.RepositoryName))
+	}
+
+	if expected := []string{"upstream3", "upstream1", "upstream2"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got upstreams %v, expected %v", names, expected)
+	}
+
+	if got := expandCodeArtifactUpstreams(nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty list of upstreams, got %v", got)
+	}
+}
+
+func TestFlattenCodeArtifactUpstreams(t *testing.T) {
+	got := flattenCodeArtifactUpstreams([]*codeartifact.UpstreamRepositoryInfo{
+		{RepositoryName: aws.String("upstream3")},
+		{RepositoryName: aws.String("upstream1")},
+	})
+
+	expected := []interface{}{
+		map[string]interface{}{"repository_name": "upstream3"},
+		map[string]interface{}{"repository_name": "upstream1"},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestCodeArtifactExternalConnectionName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []interface{}
+		expected string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "nil",
+			input: []interface{}{nil},
+		},
+		{
+			name:     "connection",
+			input:    []interface{}{map[string]interface{}{"external_connection_name": "public:npmjs"}},
+			expected: "public:npmjs",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := codeArtifactExternalConnectionName(testCase.input); got != testCase.expected {
+				t.Errorf("got %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
 func TestAccAWSCodeArtifactRepository_externalConnection(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_codeartifact_repository.test"
@@ -465,6 +582,67 @@ resource "aws_codeartifact_repository" "test" {
 `, rName)
 }
 
+func testAccAWSCodeArtifactRepositoryUpstreamsConfigChain(rName string) string {
+	return testAccAWSCodeArtifactRepositoryBaseConfig(rName) + fmt.Sprintf(`
+resource "aws_codeartifact_repository" "upstream3" {
+  repository = "%[1]s-upstream3"
+  domain     = aws_codeartifact_domain.test.domain
+
+  external_connections {
+    external_connection_name = "public:npmjs"
+  }
+}
+
+resource "aws_codeartifact_repository" "upstream2" {
+  repository = "%[1]s-upstream2"
+  domain     = aws_codeartifact_domain.test.domain
+
+  upstream {
+    repository_name = aws_codeartifact_repository.upstream3.repository
+  }
+}
+
+resource "aws_codeartifact_repository" "upstream1" {
+  repository = "%[1]s-upstream1"
+  domain     = aws_codeartifact_domain.test.domain
+
+  upstream {
+    repository_name = aws_codeartifact_repository.upstream2.repository
+  }
+}
+`, rName)
+}
+
+func testAccAWSCodeArtifactRepositoryUpstreamsConfigOrdered(rName, upstream1, upstream2, upstream3 string) string {
+	return testAccAWSCodeArtifactRepositoryUpstreamsConfigChain(rName) + fmt.Sprintf(`
+resource "aws_codeartifact_repository" "test" {
+  repository = %[1]q
+  domain     = aws_codeartifact_domain.test.domain
+
+  upstream {
+    repository_name = aws_codeartifact_repository.%[2]s.repository
+  }
+
+  upstream {
+    repository_name = aws_codeartifact_repository.%[3]s.repository
+  }
+
+  upstream {
+    repository_name = aws_codeartifact_repository.%[4]s.repository
+  }
+}
+`, rName, upstream1, upstream2, upstream3)
+}
+
+func testAccAWSCodeArtifactRepositoryUpstreamsConfigNone(rName string) string {
+	return testAccAWSCodeArtifactRepositoryUpstreamsConfigChain(rName) + fmt.Sprintf(`
+resource "aws_codeartifact_repository" "test" {
+  repository = %[1]q
+  domain     = aws_codeartifact_domain.test.domain
+}
+`, rName)
+}
+
 func testAccAWSCodeArtifactRepositoryExternalConnectionConfig(rName string) string {
 	return testAccAWSCodeArtifactRepositoryBaseConfig(rName) + fmt.Sprintf(`
 resource "aws_codeartifact_repository" "test" {
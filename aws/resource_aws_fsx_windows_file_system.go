@@ -1,9 +1,12 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,6 +25,7 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 		Delete: resourceAwsFsxWindowsFileSystemDelete,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("allow_destructive_deployment_type_change", false)
 				d.Set("skip_final_backup", false)
 
 				return []*schema.ResourceData{d}, nil
@@ -41,6 +45,52 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"self_managed_active_directory"},
 			},
+			"administrative_actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"progress_percent": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"request_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target_storage_capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"target_throughput_capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"aliases": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 50,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.All(
+						validation.StringLenBetween(4, 253),
+						validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)+$`), "must be a fully qualified domain name"),
+						// Amazon FSx stores aliases in lowercase, so other values would always show a difference.
+						validation.StringDoesNotMatch(regexp.MustCompile(`[A-Z]`), "must be lowercase"),
+					),
+				},
+			},
 			"arn": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -58,18 +108,29 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 				ForceNew: true,
 			},
 			"daily_automatic_backup_start_time": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ValidateFunc: validation.All(
-					validation.StringLenBetween(5, 5),
-					validation.StringMatch(regexp.MustCompile(`^([01]\d|2[0-3]):?([0-5]\d)$`), "must be in the format HH:MM"),
-				),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringMatch(fsxDailyAutomaticBackupStartTimeRegexp, "must be in the format HH:MM, for example 05:00"),
+				DiffSuppressFunc: suppressFsxEquivalentDailyAutomaticBackupStartTime,
+			},
+			// backup_id is not returned by DescribeFileSystems, so an imported
+			// file system has no value in state. Don't replace it on that account.
+			"backup_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressFsxWindowsFileSystemImportedBackupId,
 			},
 			"dns_name": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"final_backup_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"kms_key_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -77,6 +138,10 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateArn,
 			},
+			"lifecycle_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"network_interface_ids": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -113,16 +178,19 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 						"domain_name": {
 							Type:     schema.TypeString,
 							Required: true,
+							ForceNew: true,
 						},
 						"file_system_administrators_group": {
 							Type:         schema.TypeString,
 							Optional:     true,
+							ForceNew:     true,
 							Default:      "Domain Admins",
 							ValidateFunc: validation.StringLenBetween(1, 256),
 						},
 						"organizational_unit_distinguished_name": {
 							Type:         schema.TypeString,
 							Optional:     true,
+							ForceNew:     true,
 							ValidateFunc: validation.StringLenBetween(1, 2000),
 						},
 						"password": {
@@ -139,15 +207,23 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 					},
 				},
 			},
+			"allow_destructive_deployment_type_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"skip_final_backup": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			// Not Computed, so that an omitted storage_capacity reads as zero at plan
+			// time. A file system restored from a backup takes the backup's capacity.
 			"storage_capacity": {
-				Type:         schema.TypeInt,
-				Required:     true,
-				ValidateFunc: validation.IntBetween(32, 65536),
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateFunc:     validation.IntBetween(32, 65536),
+				DiffSuppressFunc: suppressFsxWindowsFileSystemStorageCapacityFromBackup,
 			},
 			"subnet_ids": {
 				Type:     schema.TypeList,
@@ -167,29 +243,29 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 				Computed: true,
 			},
 			"weekly_maintenance_start_time": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ValidateFunc: validation.All(
-					validation.StringLenBetween(7, 7),
-					validation.StringMatch(regexp.MustCompile(`^[1-7]:([01]\d|2[0-3]):?([0-5]\d)$`), "must be in the format d:HH:MM"),
-				),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringMatch(fsxWeeklyMaintenanceStartTimeRegexp, "must be in the format d:HH:MM, where d is the day of the week from 1 (Monday) to 7 (Sunday), for example 1:05:00"),
+				DiffSuppressFunc: suppressFsxEquivalentWeeklyMaintenanceStartTime,
 			},
+			// The API defaults to SINGLE_AZ_1. It is suppressed rather than Computed,
+			// so that an omitted value is known to be the default at plan time and an
+			// explicit SINGLE_AZ_1 and an omitted value never diff.
 			"deployment_type": {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
-				Default:  fsx.WindowsDeploymentTypeSingleAz1,
 				ValidateFunc: validation.StringInSlice([]string{
 					fsx.WindowsDeploymentTypeMultiAz1,
 					fsx.WindowsDeploymentTypeSingleAz1,
 					fsx.WindowsDeploymentTypeSingleAz2,
 				}, false),
+				DiffSuppressFunc: suppressFsxWindowsFileSystemDefaultDeploymentType,
 			},
 			"preferred_subnet_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Computed: true,
 				ForceNew: true,
 			},
 			"preferred_file_server_ip": {
@@ -211,70 +287,355 @@ func resourceAwsFsxWindowsFileSystem() *schema.Resource {
 				}, false),
 			},
 		},
+
+		CustomizeDiff: resourceAwsFsxWindowsFileSystemCustomizeDiff,
 	}
 }
 
+func resourceAwsFsxWindowsFileSystemCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// deployment_type, preferred_subnet_id and subnet_ids are all ForceNew,
+	// so only validate when planning a new file system.
+	if d.Id() != "" {
+		if d.HasChange("deployment_type") && d.NewValueKnown("deployment_type") {
+			o, _ := d.GetChange("skip_final_backup")
+
+			// Only a new backup_id restores the replacement file system from a
+			// backup taken for the change, rather than from the one it was created from.
+			restoresBackup := d.HasChange("backup_id") && (d.Get("backup_id").(string) != "" || !d.NewValueKnown("backup_id"))
+
+			return fsxWindowsFileSystemDeploymentTypeChangeError(
+				o.(bool),
+				restoresBackup,
+				d.Get("allow_destructive_deployment_type_change").(bool),
+			)
+		}
+
+		if !d.HasChange("storage_capacity") || !d.NewValueKnown("storage_capacity") {
+			return nil
+		}
+
+		// A replacement file system is created with the new storage_capacity,
+		// which is checked when the diff is recomputed for the replacement.
+		if fsxWindowsFileSystemRequiresReplacement(d) {
+			return nil
+		}
+
+		o, n := d.GetChange("storage_capacity")
+
+		return validateFsxWindowsFileSystemStorageCapacityUpdate(o.(int), n.(int))
+	}
+
+	if err := validateFsxWindowsFileSystemBackupId(d); err != nil {
+		return err
+	}
+
+	if d.NewValueKnown("storage_capacity") {
+		if err := validateFsxWindowsFileSystemStorageCapacity(d.Get("storage_type").(string), d.Get("storage_capacity").(int)); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("deployment_type") || !d.NewValueKnown("preferred_subnet_id") || !d.NewValueKnown("subnet_ids") {
+		return nil
+	}
+
+	deploymentType := d.Get("deployment_type").(string)
+
+	if deploymentType == "" {
+		deploymentType = fsx.WindowsDeploymentTypeSingleAz1
+	}
+
+	return validateFsxWindowsFileSystemPreferredSubnetId(
+		deploymentType,
+		d.Get("preferred_subnet_id").(string),
+		expandStringList(d.Get("subnet_ids").([]interface{})),
+	)
+}
+
+// fsxWindowsFileSystemForceNewAttributes are the arguments whose change replaces the file system.
+var fsxWindowsFileSystemForceNewAttributes = []string{
+	"active_directory_id",
+	"backup_id",
+	"copy_tags_to_backups",
+	"deployment_type",
+	"kms_key_id",
+	"preferred_subnet_id",
+	"security_group_ids",
+	"self_managed_active_directory.0.domain_name",
+	"self_managed_active_directory.0.file_system_administrators_group",
+	"self_managed_active_directory.0.organizational_unit_distinguished_name",
+	"storage_type",
+	"subnet_ids",
+}
+
+// fsxWindowsFileSystemRequiresReplacement returns whether the diff replaces the file system.
+func fsxWindowsFileSystemRequiresReplacement(d *schema.ResourceDiff) bool {
+	for _, k := range fsxWindowsFileSystemForceNewAttributes {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fsxWindowsFileSystemDeploymentTypeChangeError returns an error if changing deployment_type, which
+// replaces the file system, would lose its data. The file system is deleted with the skip_final_backup
+// value already in state, so the setting must be applied before deployment_type is changed.
+func fsxWindowsFileSystemDeploymentTypeChangeError(skipFinalBackup, restoresBackup, allowDestructive bool) error {
+	if !skipFinalBackup || restoresBackup || allowDestructive {
+		return nil
+	}
+
+	return fmt.Errorf("changing deployment_type replaces the file system and skip_final_backup is true, so its data would be lost; " +
+		"either apply skip_final_backup = false before changing deployment_type so that a final backup is taken, " +
+		"set backup_id to a new backup to restore the new file system from, " +
+		"or set allow_destructive_deployment_type_change = true")
+}
+
+// fsxDailyAutomaticBackupStartTimeRegexp matches HH:MM. The hour may omit its leading zero.
+var fsxDailyAutomaticBackupStartTimeRegexp = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+
+// fsxWeeklyMaintenanceStartTimeRegexp matches d:HH:MM. The day may have a leading zero
+// and the hour may omit its leading zero.
+var fsxWeeklyMaintenanceStartTimeRegexp = regexp.MustCompile(`^0?([1-7]):([01]?\d|2[0-3]):([0-5]\d)$`)
+
+// normalizeFsxDailyAutomaticBackupStartTime returns a daily backup start time in the HH:MM form
+// used by the API. Values that do not match are returned unchanged.
+func normalizeFsxDailyAutomaticBackupStartTime(v string) string {
+	m := fsxDailyAutomaticBackupStartTimeRegexp.FindStringSubmatch(v)
+
+	if m == nil {
+		return v
+	}
+
+	return fmt.Sprintf("%s:%s", padFsxStartTimeHour(m[1]), m[2])
+}
+
+// normalizeFsxWeeklyMaintenanceStartTime returns a weekly maintenance start time in the d:HH:MM
+// form used by the API. Values that do not match are returned unchanged.
+func normalizeFsxWeeklyMaintenanceStartTime(v string) string {
+	m := fsxWeeklyMaintenanceStartTimeRegexp.FindStringSubmatch(v)
+
+	if m == nil {
+		return v
+	}
+
+	return fmt.Sprintf("%s:%s:%s", m[1], padFsxStartTimeHour(m[2]), m[3])
+}
+
+func padFsxStartTimeHour(hour string) string {
+	if len(hour) == 1 {
+		return "0" + hour
+	}
+
+	return hour
+}
+
+func suppressFsxEquivalentDailyAutomaticBackupStartTime(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeFsxDailyAutomaticBackupStartTime(old) == normalizeFsxDailyAutomaticBackupStartTime(new)
+}
+
+// suppressFsxWindowsFileSystemDefaultDeploymentType suppresses the removal of a
+// deployment_type that is the API default.
+func suppressFsxWindowsFileSystemDefaultDeploymentType(k, old, new string, d *schema.ResourceData) bool {
+	return old == fsx.WindowsDeploymentTypeSingleAz1 && new == ""
+}
+
+// suppressFsxWindowsFileSystemStorageCapacityFromBackup suppresses the removal of a
+// storage_capacity that was omitted when restoring the file system from a backup.
+func suppressFsxWindowsFileSystemStorageCapacityFromBackup(k, old, new string, d *schema.ResourceData) bool {
+	return new == "0" && d.Get("backup_id").(string) != ""
+}
+
+// suppressFsxWindowsFileSystemImportedBackupId suppresses adding a backup_id to
+// an existing file system that has none in state, as after import.
+func suppressFsxWindowsFileSystemImportedBackupId(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+func suppressFsxEquivalentWeeklyMaintenanceStartTime(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeFsxWeeklyMaintenanceStartTime(old) == normalizeFsxWeeklyMaintenanceStartTime(new)
+}
+
+// fsxWindowsFileSystemHddMinimumStorageCapacity is the smallest HDD file system in GiB.
+const fsxWindowsFileSystemHddMinimumStorageCapacity = 2000
+
+// validateFsxWindowsFileSystemStorageCapacity checks the storage_capacity of a new file system
+// against the minimum for its storage type. A zero capacity is left to the other checks.
+func validateFsxWindowsFileSystemStorageCapacity(storageType string, storageCapacity int) error {
+	if storageType == fsx.StorageTypeHdd && storageCapacity != 0 && storageCapacity < fsxWindowsFileSystemHddMinimumStorageCapacity {
+		return fmt.Errorf("storage_capacity (%d) must be at least %d GiB when storage_type is %s", storageCapacity, fsxWindowsFileSystemHddMinimumStorageCapacity, fsx.StorageTypeHdd)
+	}
+
+	return nil
+}
+
+// validateFsxWindowsFileSystemStorageCapacityUpdate checks that a storage_capacity change can be
+// applied in place. FSx cannot decrease storage, and increases must be at least 10 percent.
+func validateFsxWindowsFileSystemStorageCapacityUpdate(oldCapacity, newCapacity int) error {
+	if oldCapacity == 0 || newCapacity == oldCapacity {
+		return nil
+	}
+
+	if newCapacity < oldCapacity {
+		return fmt.Errorf("storage_capacity cannot be decreased from %d to %d GiB, FSx only supports increasing storage; replace the file system to reduce it", oldCapacity, newCapacity)
+	}
+
+	// The increase is rounded up to whole GiB.
+	minimum := oldCapacity + (oldCapacity+9)/10
+
+	if newCapacity < minimum {
+		return fmt.Errorf("storage_capacity must be increased by at least 10 percent, from %d to at least %d GiB, got %d", oldCapacity, minimum, newCapacity)
+	}
+
+	return nil
+}
+
+// validateFsxWindowsFileSystemBackupId checks that storage_capacity is set unless
+// the file system is restored from a backup, in which case the KMS key comes from
+// the backup and kms_key_id must not be set. An omitted storage_capacity is known
+// and zero, while one interpolated from an unknown value is not checked.
+func validateFsxWindowsFileSystemBackupId(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("backup_id") {
+		return nil
+	}
+
+	if d.Get("backup_id").(string) == "" {
+		if d.NewValueKnown("storage_capacity") && d.Get("storage_capacity").(int) == 0 {
+			return fmt.Errorf("storage_capacity is required when backup_id is not set")
+		}
+
+		return nil
+	}
+
+	if d.Get("kms_key_id").(string) != "" {
+		return fmt.Errorf("kms_key_id must not be set when backup_id is set, the file system uses the backup's KMS key")
+	}
+
+	return nil
+}
+
+// validateFsxWindowsFileSystemPreferredSubnetId checks that preferred_subnet_id
+// is set if and only if the deployment type is MULTI_AZ_1, and that it is one
+// of the file system's subnets.
+func validateFsxWindowsFileSystemPreferredSubnetId(deploymentType, preferredSubnetId string, subnetIds []*string) error {
+	if deploymentType != fsx.WindowsDeploymentTypeMultiAz1 {
+		if preferredSubnetId != "" {
+			return fmt.Errorf("preferred_subnet_id must not be set when deployment_type is not %s", fsx.WindowsDeploymentTypeMultiAz1)
+		}
+
+		return nil
+	}
+
+	if preferredSubnetId == "" {
+		return fmt.Errorf("preferred_subnet_id is required when deployment_type is %s", fsx.WindowsDeploymentTypeMultiAz1)
+	}
+
+	for _, subnetId := range subnetIds {
+		if aws.StringValue(subnetId) == preferredSubnetId {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("preferred_subnet_id (%s) must be one of subnet_ids", preferredSubnetId)
+}
+
 func resourceAwsFsxWindowsFileSystemCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).fsxconn
 
-	input := &fsx.CreateFileSystemInput{
-		ClientRequestToken: aws.String(resource.UniqueId()),
-		FileSystemType:     aws.String(fsx.FileSystemTypeWindows),
-		StorageCapacity:    aws.Int64(int64(d.Get("storage_capacity").(int))),
-		SubnetIds:          expandStringList(d.Get("subnet_ids").([]interface{})),
-		WindowsConfiguration: &fsx.CreateFileSystemWindowsConfiguration{
-			AutomaticBackupRetentionDays: aws.Int64(int64(d.Get("automatic_backup_retention_days").(int))),
-			CopyTagsToBackups:            aws.Bool(d.Get("copy_tags_to_backups").(bool)),
-			ThroughputCapacity:           aws.Int64(int64(d.Get("throughput_capacity").(int))),
-		},
+	windowsConfig := &fsx.CreateFileSystemWindowsConfiguration{
+		AutomaticBackupRetentionDays: aws.Int64(int64(d.Get("automatic_backup_retention_days").(int))),
+		CopyTagsToBackups:            aws.Bool(d.Get("copy_tags_to_backups").(bool)),
+		ThroughputCapacity:           aws.Int64(int64(d.Get("throughput_capacity").(int))),
 	}
 
 	if v, ok := d.GetOk("active_directory_id"); ok {
-		input.WindowsConfiguration.ActiveDirectoryId = aws.String(v.(string))
+		windowsConfig.ActiveDirectoryId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("aliases"); ok && v.(*schema.Set).Len() > 0 {
+		windowsConfig.Aliases = expandStringSet(v.(*schema.Set))
 	}
 
 	if v, ok := d.GetOk("deployment_type"); ok {
-		input.WindowsConfiguration.DeploymentType = aws.String(v.(string))
+		windowsConfig.DeploymentType = aws.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("preferred_subnet_id"); ok {
-		input.WindowsConfiguration.PreferredSubnetId = aws.String(v.(string))
+		windowsConfig.PreferredSubnetId = aws.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("daily_automatic_backup_start_time"); ok {
-		input.WindowsConfiguration.DailyAutomaticBackupStartTime = aws.String(v.(string))
+		windowsConfig.DailyAutomaticBackupStartTime = aws.String(normalizeFsxDailyAutomaticBackupStartTime(v.(string)))
 	}
 
-	if v, ok := d.GetOk("kms_key_id"); ok {
-		input.KmsKeyId = aws.String(v.(string))
+	if v, ok := d.GetOk("self_managed_active_directory"); ok {
+		windowsConfig.SelfManagedActiveDirectoryConfiguration = expandFsxSelfManagedActiveDirectoryConfigurationCreate(v.([]interface{}))
 	}
 
-	if v, ok := d.GetOk("security_group_ids"); ok {
-		input.SecurityGroupIds = expandStringSet(v.(*schema.Set))
+	if v, ok := d.GetOk("weekly_maintenance_start_time"); ok {
+		windowsConfig.WeeklyMaintenanceStartTime = aws.String(normalizeFsxWeeklyMaintenanceStartTime(v.(string)))
 	}
 
-	if v, ok := d.GetOk("self_managed_active_directory"); ok {
-		input.WindowsConfiguration.SelfManagedActiveDirectoryConfiguration = expandFsxSelfManagedActiveDirectoryConfigurationCreate(v.([]interface{}))
-	}
+	if v, ok := d.GetOk("backup_id"); ok {
+		input := &fsx.CreateFileSystemFromBackupInput{
+			BackupId:             aws.String(v.(string)),
+			ClientRequestToken:   aws.String(resource.UniqueId()),
+			SubnetIds:            expandStringList(d.Get("subnet_ids").([]interface{})),
+			WindowsConfiguration: windowsConfig,
+		}
 
-	if v, ok := d.GetOk("tags"); ok {
-		input.Tags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().FsxTags()
-	}
+		if v, ok := d.GetOk("security_group_ids"); ok {
+			input.SecurityGroupIds = expandStringSet(v.(*schema.Set))
+		}
 
-	if v, ok := d.GetOk("weekly_maintenance_start_time"); ok {
-		input.WindowsConfiguration.WeeklyMaintenanceStartTime = aws.String(v.(string))
-	}
+		if v, ok := d.GetOk("tags"); ok {
+			input.Tags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().FsxTags()
+		}
 
-	if v, ok := d.GetOk("storage_type"); ok {
-		input.StorageType = aws.String(v.(string))
-	}
+		if v, ok := d.GetOk("storage_type"); ok {
+			input.StorageType = aws.String(v.(string))
+		}
 
-	result, err := conn.CreateFileSystem(input)
-	if err != nil {
-		return fmt.Errorf("Error creating FSx filesystem: %s", err)
-	}
+		result, err := conn.CreateFileSystemFromBackup(input)
+		if err != nil {
+			return fmt.Errorf("error creating FSx Windows File System from backup (%s): %w", v.(string), err)
+		}
+
+		d.SetId(aws.StringValue(result.FileSystem.FileSystemId))
+	} else {
+		input := &fsx.CreateFileSystemInput{
+			ClientRequestToken:   aws.String(resource.UniqueId()),
+			FileSystemType:       aws.String(fsx.FileSystemTypeWindows),
+			StorageCapacity:      aws.Int64(int64(d.Get("storage_capacity").(int))),
+			SubnetIds:            expandStringList(d.Get("subnet_ids").([]interface{})),
+			WindowsConfiguration: windowsConfig,
+		}
+
+		if v, ok := d.GetOk("kms_key_id"); ok {
+			input.KmsKeyId = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("security_group_ids"); ok {
+			input.SecurityGroupIds = expandStringSet(v.(*schema.Set))
+		}
+
+		if v, ok := d.GetOk("tags"); ok {
+			input.Tags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().FsxTags()
+		}
 
-	d.SetId(aws.StringValue(result.FileSystem.FileSystemId))
+		if v, ok := d.GetOk("storage_type"); ok {
+			input.StorageType = aws.String(v.(string))
+		}
+
+		result, err := conn.CreateFileSystem(input)
+		if err != nil {
+			return fmt.Errorf("Error creating FSx filesystem: %s", err)
+		}
+
+		d.SetId(aws.StringValue(result.FileSystem.FileSystemId))
+	}
 
 	log.Println("[DEBUG] Waiting for filesystem to become available")
 
@@ -282,6 +643,13 @@ func resourceAwsFsxWindowsFileSystemCreate(d *schema.ResourceData, meta interfac
 		return fmt.Errorf("Error waiting for filesystem (%s) to become available: %s", d.Id(), err)
 	}
 
+	// Aliases are associated asynchronously after the file system becomes available.
+	for _, alias := range d.Get("aliases").(*schema.Set).List() {
+		if err := waitForFsxFileSystemAliasAvailable(conn, d.Id(), alias.(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("error waiting for FSx Windows File System (%s) alias (%s) to become available: %w", d.Id(), alias.(string), err)
+		}
+	}
+
 	return resourceAwsFsxWindowsFileSystemRead(d, meta)
 }
 
@@ -296,45 +664,43 @@ func resourceAwsFsxWindowsFileSystemUpdate(d *schema.ResourceData, meta interfac
 		}
 	}
 
-	requestUpdate := false
-	input := &fsx.UpdateFileSystemInput{
-		ClientRequestToken:   aws.String(resource.UniqueId()),
-		FileSystemId:         aws.String(d.Id()),
-		WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{},
+	if d.HasChange("aliases") {
+		if err := updateFsxWindowsFileSystemAliases(conn, d); err != nil {
+			return err
+		}
 	}
 
-	if d.HasChange("automatic_backup_retention_days") {
-		input.WindowsConfiguration.AutomaticBackupRetentionDays = aws.Int64(int64(d.Get("automatic_backup_retention_days").(int)))
-		requestUpdate = true
-	}
+	activeDirectoryConfigured := len(d.Get("self_managed_active_directory").([]interface{})) > 0
+	changes := make(map[string]interface{})
+	for _, k := range fsxWindowsFileSystemUpdatableAttributes {
+		if strings.HasPrefix(k, "self_managed_active_directory.") && !activeDirectoryConfigured {
+			continue
+		}
 
-	if d.HasChange("throughput_capacity") {
-		input.WindowsConfiguration.ThroughputCapacity = aws.Int64(int64(d.Get("throughput_capacity").(int)))
-		requestUpdate = true
+		if d.HasChange(k) {
+			changes[k] = d.Get(k)
+		}
 	}
 
-	if d.HasChange("storage_capacity") {
-		input.StorageCapacity = aws.Int64(int64(d.Get("storage_capacity").(int)))
-		requestUpdate = true
+	// Service account credentials are always updated together.
+	if _, ok := changes["self_managed_active_directory.0.username"]; ok {
+		changes["self_managed_active_directory.0.password"] = d.Get("self_managed_active_directory.0.password")
 	}
 
-	if d.HasChange("daily_automatic_backup_start_time") {
-		input.WindowsConfiguration.DailyAutomaticBackupStartTime = aws.String(d.Get("daily_automatic_backup_start_time").(string))
-		requestUpdate = true
-	}
+	if input := expandFsxWindowsFileSystemUpdateInput(d.Id(), changes); input != nil {
+		filesystem, err := describeFsxFileSystem(conn, d.Id())
 
-	if d.HasChange("self_managed_active_directory") {
-		input.WindowsConfiguration.SelfManagedActiveDirectoryConfiguration = expandFsxSelfManagedActiveDirectoryConfigurationUpdate(d.Get("self_managed_active_directory").([]interface{}))
-		requestUpdate = true
-	}
+		if err != nil {
+			return fmt.Errorf("error reading FSx Windows File System (%s): %w", d.Id(), err)
+		}
 
-	if d.HasChange("weekly_maintenance_start_time") {
-		input.WindowsConfiguration.WeeklyMaintenanceStartTime = aws.String(d.Get("weekly_maintenance_start_time").(string))
-		requestUpdate = true
-	}
+		if filesystem != nil {
+			if err := fsxWindowsFileSystemUpdateLifecycleError(d.Id(), aws.StringValue(filesystem.Lifecycle), d.HasChange("self_managed_active_directory")); err != nil {
+				return err
+			}
+		}
 
-	if requestUpdate {
-		_, err := conn.UpdateFileSystem(input)
+		_, err = conn.UpdateFileSystem(input)
 
 		if err != nil {
 			return fmt.Errorf("error updating FSx Windows File System (%s): %w", d.Id(), err)
@@ -348,6 +714,148 @@ func resourceAwsFsxWindowsFileSystemUpdate(d *schema.ResourceData, meta interfac
 	return resourceAwsFsxWindowsFileSystemRead(d, meta)
 }
 
+func updateFsxWindowsFileSystemAliases(conn *fsx.FSx, d *schema.ResourceData) error {
+	o, n := d.GetChange("aliases")
+	oldAliases, newAliases := o.(*schema.Set), n.(*schema.Set)
+	add, del := newAliases.Difference(oldAliases), oldAliases.Difference(newAliases)
+
+	if del.Len() > 0 {
+		input := &fsx.DisassociateFileSystemAliasesInput{
+			Aliases:      expandStringSet(del),
+			FileSystemId: aws.String(d.Id()),
+		}
+
+		if _, err := conn.DisassociateFileSystemAliases(input); err != nil {
+			return fmt.Errorf("error disassociating FSx Windows File System (%s) aliases: %w", d.Id(), err)
+		}
+
+		for _, alias := range del.List() {
+			if err := waitForFsxFileSystemAliasDeletion(conn, d.Id(), alias.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("error waiting for FSx Windows File System (%s) alias (%s) to be disassociated: %w", d.Id(), alias.(string), err)
+			}
+		}
+	}
+
+	if add.Len() > 0 {
+		input := &fsx.AssociateFileSystemAliasesInput{
+			Aliases:      expandStringSet(add),
+			FileSystemId: aws.String(d.Id()),
+		}
+
+		if _, err := conn.AssociateFileSystemAliases(input); err != nil {
+			return fmt.Errorf("error associating FSx Windows File System (%s) aliases: %w", d.Id(), err)
+		}
+
+		for _, alias := range add.List() {
+			if err := waitForFsxFileSystemAliasAvailable(conn, d.Id(), alias.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("error waiting for FSx Windows File System (%s) alias (%s) to become available: %w", d.Id(), alias.(string), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fsxWindowsFileSystemUpdatableAttributes are the attributes that can be changed with UpdateFileSystem.
+var fsxWindowsFileSystemUpdatableAttributes = []string{
+	"automatic_backup_retention_days",
+	"daily_automatic_backup_start_time",
+	"self_managed_active_directory.0.dns_ips",
+	"self_managed_active_directory.0.password",
+	"self_managed_active_directory.0.username",
+	"storage_capacity",
+	"throughput_capacity",
+	"weekly_maintenance_start_time",
+}
+
+// expandFsxWindowsFileSystemUpdateInput returns an UpdateFileSystem input containing only the
+// changed attributes, keyed as in fsxWindowsFileSystemUpdatableAttributes, so that settings not
+// modeled by this resource are left untouched. It returns nil if there are no changes.
+func expandFsxWindowsFileSystemUpdateInput(id string, changes map[string]interface{}) *fsx.UpdateFileSystemInput {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	input := &fsx.UpdateFileSystemInput{
+		ClientRequestToken: aws.String(resource.UniqueId()),
+		FileSystemId:       aws.String(id),
+	}
+
+	if v, ok := changes["storage_capacity"]; ok {
+		input.StorageCapacity = aws.Int64(int64(v.(int)))
+	}
+
+	windowsConfiguration := &fsx.UpdateFileSystemWindowsConfiguration{}
+	windowsConfigurationChanged := false
+
+	if v, ok := changes["automatic_backup_retention_days"]; ok {
+		windowsConfiguration.AutomaticBackupRetentionDays = aws.Int64(int64(v.(int)))
+		windowsConfigurationChanged = true
+	}
+
+	if v, ok := changes["daily_automatic_backup_start_time"]; ok {
+		windowsConfiguration.DailyAutomaticBackupStartTime = aws.String(normalizeFsxDailyAutomaticBackupStartTime(v.(string)))
+		windowsConfigurationChanged = true
+	}
+
+	if v, ok := changes["throughput_capacity"]; ok {
+		windowsConfiguration.ThroughputCapacity = aws.Int64(int64(v.(int)))
+		windowsConfigurationChanged = true
+	}
+
+	if v, ok := changes["weekly_maintenance_start_time"]; ok {
+		windowsConfiguration.WeeklyMaintenanceStartTime = aws.String(normalizeFsxWeeklyMaintenanceStartTime(v.(string)))
+		windowsConfigurationChanged = true
+	}
+
+	activeDirectoryConfiguration := &fsx.SelfManagedActiveDirectoryConfigurationUpdates{}
+	activeDirectoryConfigurationChanged := false
+
+	if v, ok := changes["self_managed_active_directory.0.dns_ips"]; ok {
+		activeDirectoryConfiguration.DnsIps = expandStringSet(v.(*schema.Set))
+		activeDirectoryConfigurationChanged = true
+	}
+
+	if v, ok := changes["self_managed_active_directory.0.password"]; ok {
+		activeDirectoryConfiguration.Password = aws.String(v.(string))
+		activeDirectoryConfigurationChanged = true
+	}
+
+	if v, ok := changes["self_managed_active_directory.0.username"]; ok {
+		activeDirectoryConfiguration.UserName = aws.String(v.(string))
+		activeDirectoryConfigurationChanged = true
+	}
+
+	if activeDirectoryConfigurationChanged {
+		windowsConfiguration.SelfManagedActiveDirectoryConfiguration = activeDirectoryConfiguration
+		windowsConfigurationChanged = true
+	}
+
+	if windowsConfigurationChanged {
+		input.WindowsConfiguration = windowsConfiguration
+	}
+
+	return input
+}
+
+// fsxWindowsFileSystemUpdateLifecycleError returns an error if a file system in the
+// specified lifecycle state cannot be updated. A MISCONFIGURED file system may
+// only be updated to correct its self-managed Active Directory configuration.
+func fsxWindowsFileSystemUpdateLifecycleError(id, lifecycle string, updatingActiveDirectory bool) error {
+	switch lifecycle {
+	case fsx.FileSystemLifecycleMisconfigured:
+		if updatingActiveDirectory {
+			return nil
+		}
+
+		return fmt.Errorf("FSx Windows File System (%s) is %s, most likely because its self-managed Active Directory credentials are no longer valid: correct the self_managed_active_directory configuration before making other changes", id, lifecycle)
+	case fsx.FileSystemLifecycleFailed:
+		return fmt.Errorf("FSx Windows File System (%s) is %s and cannot be updated: check the self_managed_active_directory configuration and recreate the file system", id, lifecycle)
+	}
+
+	return nil
+}
+
 func resourceAwsFsxWindowsFileSystemRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).fsxconn
 	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
@@ -379,16 +887,38 @@ func resourceAwsFsxWindowsFileSystemRead(d *schema.ResourceData, meta interface{
 	}
 
 	d.Set("active_directory_id", filesystem.WindowsConfiguration.ActiveDirectoryId)
+
+	if err := d.Set("administrative_actions", flattenFsxAdministrativeActions(filesystem.AdministrativeActions)); err != nil {
+		return fmt.Errorf("error setting administrative_actions: %s", err)
+	}
+
+	aliases, err := describeFsxFileSystemAliases(conn, d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading FSx Windows File System (%s) aliases: %w", d.Id(), err)
+	}
+
+	if err := d.Set("aliases", flattenFsxAliases(aliases)); err != nil {
+		return fmt.Errorf("error setting aliases: %s", err)
+	}
+
 	d.Set("arn", filesystem.ResourceARN)
 	d.Set("automatic_backup_retention_days", filesystem.WindowsConfiguration.AutomaticBackupRetentionDays)
 	d.Set("copy_tags_to_backups", filesystem.WindowsConfiguration.CopyTagsToBackups)
 	d.Set("daily_automatic_backup_start_time", filesystem.WindowsConfiguration.DailyAutomaticBackupStartTime)
 	d.Set("deployment_type", filesystem.WindowsConfiguration.DeploymentType)
-	d.Set("preferred_subnet_id", filesystem.WindowsConfiguration.PreferredSubnetId)
+	// The API also reports the only subnet of single-AZ file systems as the
+	// preferred subnet, but it may only be configured for MULTI_AZ_1.
+	if aws.StringValue(filesystem.WindowsConfiguration.DeploymentType) == fsx.WindowsDeploymentTypeMultiAz1 {
+		d.Set("preferred_subnet_id", filesystem.WindowsConfiguration.PreferredSubnetId)
+	} else {
+		d.Set("preferred_subnet_id", "")
+	}
 	d.Set("preferred_file_server_ip", filesystem.WindowsConfiguration.PreferredFileServerIp)
 	d.Set("remote_administration_endpoint", filesystem.WindowsConfiguration.RemoteAdministrationEndpoint)
 	d.Set("dns_name", filesystem.DNSName)
 	d.Set("kms_key_id", filesystem.KmsKeyId)
+	d.Set("lifecycle_status", filesystem.Lifecycle)
 	d.Set("storage_type", filesystem.StorageType)
 
 	if err := d.Set("network_interface_ids", aws.StringValueSlice(filesystem.NetworkInterfaceIds)); err != nil {
@@ -421,15 +951,21 @@ func resourceAwsFsxWindowsFileSystemRead(d *schema.ResourceData, meta interface{
 func resourceAwsFsxWindowsFileSystemDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).fsxconn
 
+	skipFinalBackup := d.Get("skip_final_backup").(bool)
+
 	input := &fsx.DeleteFileSystemInput{
 		ClientRequestToken: aws.String(resource.UniqueId()),
 		FileSystemId:       aws.String(d.Id()),
 		WindowsConfiguration: &fsx.DeleteFileSystemWindowsConfiguration{
-			SkipFinalBackup: aws.Bool(d.Get("skip_final_backup").(bool)),
+			SkipFinalBackup: aws.Bool(skipFinalBackup),
 		},
 	}
 
-	_, err := conn.DeleteFileSystem(input)
+	if v, ok := d.GetOk("final_backup_tags"); ok && !skipFinalBackup {
+		input.WindowsConfiguration.FinalBackupTags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().FsxTags()
+	}
+
+	output, err := conn.DeleteFileSystem(input)
 
 	if isAWSErr(err, fsx.ErrCodeFileSystemNotFound, "") {
 		return nil
@@ -439,15 +975,51 @@ func resourceAwsFsxWindowsFileSystemDelete(d *schema.ResourceData, meta interfac
 		return fmt.Errorf("Error deleting FSx filesystem: %s", err)
 	}
 
+	var finalBackupID string
+
+	if output != nil && output.WindowsResponse != nil {
+		finalBackupID = aws.StringValue(output.WindowsResponse.FinalBackupId)
+	}
+
+	if finalBackupID != "" {
+		log.Printf("[INFO] FSx Windows File System (%s) final backup: %s", d.Id(), finalBackupID)
+	}
+
 	log.Println("[DEBUG] Waiting for filesystem to delete")
 
 	if err := waitForFsxFileSystemDeletion(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
-		return fmt.Errorf("Error waiting for filesystem (%s) to delete: %s", d.Id(), err)
+		return fsxWindowsFileSystemDeletionError(conn, d.Id(), finalBackupID, err)
 	}
 
 	return nil
 }
 
+// fsxWindowsFileSystemDeletionError returns an error describing why a file system
+// was not deleted, distinguishing a failure to create its final backup.
+func fsxWindowsFileSystemDeletionError(conn *fsx.FSx, id, finalBackupID string, err error) error {
+	if finalBackupID != "" {
+		backup, backupErr := describeFsxBackup(conn, finalBackupID)
+
+		if backupErr == nil && backup != nil && aws.StringValue(backup.Lifecycle) == fsx.BackupLifecycleFailed {
+			var message string
+
+			if backup.FailureDetails != nil {
+				message = aws.StringValue(backup.FailureDetails.Message)
+			}
+
+			return fmt.Errorf("error creating final backup (%s) of FSx Windows File System (%s): %s: %w", finalBackupID, id, message, err)
+		}
+	}
+
+	filesystem, describeErr := describeFsxFileSystem(conn, id)
+
+	if describeErr == nil && filesystem != nil && filesystem.FailureDetails != nil {
+		return fmt.Errorf("error waiting for FSx Windows File System (%s) to delete: %s (%s): %w", id, aws.StringValue(filesystem.Lifecycle), aws.StringValue(filesystem.FailureDetails.Message), err)
+	}
+
+	return fmt.Errorf("Error waiting for filesystem (%s) to delete: %w", id, err)
+}
+
 func expandFsxSelfManagedActiveDirectoryConfigurationCreate(l []interface{}) *fsx.SelfManagedActiveDirectoryConfiguration {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -472,21 +1044,6 @@ func expandFsxSelfManagedActiveDirectoryConfigurationCreate(l []interface{}) *fs
 	return req
 }
 
-func expandFsxSelfManagedActiveDirectoryConfigurationUpdate(l []interface{}) *fsx.SelfManagedActiveDirectoryConfigurationUpdates {
-	if len(l) == 0 || l[0] == nil {
-		return nil
-	}
-
-	data := l[0].(map[string]interface{})
-	req := &fsx.SelfManagedActiveDirectoryConfigurationUpdates{
-		DnsIps:   expandStringSet(data["dns_ips"].(*schema.Set)),
-		Password: aws.String(data["password"].(string)),
-		UserName: aws.String(data["username"].(string)),
-	}
-
-	return req
-}
-
 func flattenFsxSelfManagedActiveDirectoryConfiguration(d *schema.ResourceData, adopts *fsx.SelfManagedActiveDirectoryAttributes) []map[string]interface{} {
 	if adopts == nil {
 		return []map[string]interface{}{}
@@ -509,3 +1066,60 @@ func flattenFsxSelfManagedActiveDirectoryConfiguration(d *schema.ResourceData, a
 
 	return []map[string]interface{}{m}
 }
+
+// flattenFsxAliases returns the names of aliases that are not being disassociated.
+func flattenFsxAliases(aliases []*fsx.Alias) []interface{} {
+	l := make([]interface{}, 0, len(aliases))
+
+	for _, alias := range aliases {
+		if alias == nil || aws.StringValue(alias.Lifecycle) == fsx.AliasLifecycleDeleting {
+			continue
+		}
+
+		l = append(l, aws.StringValue(alias.Name))
+	}
+
+	return l
+}
+
+// flattenFsxAdministrativeActions returns the administrative actions ordered by request time.
+func flattenFsxAdministrativeActions(actions []*fsx.AdministrativeAction) []interface{} {
+	sorted := make([]*fsx.AdministrativeAction, 0, len(actions))
+
+	for _, action := range actions {
+		if action != nil {
+			sorted = append(sorted, action)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return aws.TimeValue(sorted[i].RequestTime).Before(aws.TimeValue(sorted[j].RequestTime))
+	})
+
+	l := make([]interface{}, 0, len(sorted))
+
+	for _, action := range sorted {
+		m := map[string]interface{}{
+			"progress_percent": int(aws.Int64Value(action.ProgressPercent)),
+			"request_time":     "",
+			"status":           aws.StringValue(action.Status),
+			"type":             aws.StringValue(action.AdministrativeActionType),
+		}
+
+		if action.RequestTime != nil {
+			m["request_time"] = aws.TimeValue(action.RequestTime).Format(time.RFC3339)
+		}
+
+		if target := action.TargetFileSystemValues; target != nil {
+			m["target_storage_capacity"] = int(aws.Int64Value(target.StorageCapacity))
+
+			if target.WindowsConfiguration != nil {
+				m["target_throughput_capacity"] = int(aws.Int64Value(target.WindowsConfiguration.ThroughputCapacity))
+			}
+		}
+
+		l = append(l, m)
+	}
+
+	return l
+}
@@ -1,15 +1,20 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/fsx"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -34,6 +39,14 @@ func testSweepFSXWindowsFileSystems(region string) error {
 				continue
 			}
 
+			// MISCONFIGURED file systems, e.g. with invalid self-managed Active Directory credentials, can still be deleted.
+			switch lifecycle := aws.StringValue(fs.Lifecycle); lifecycle {
+			case fsx.FileSystemLifecycleAvailable, fsx.FileSystemLifecycleFailed, fsx.FileSystemLifecycleMisconfigured:
+			default:
+				log.Printf("[INFO] Skipping FSx windows filesystem %s in lifecycle state %s", aws.StringValue(fs.FileSystemId), lifecycle)
+				continue
+			}
+
 			input := &fsx.DeleteFileSystemInput{
 				ClientRequestToken: aws.String(resource.UniqueId()),
 				FileSystemId:       fs.FileSystemId,
@@ -71,6 +84,726 @@ func testSweepFSXWindowsFileSystems(region string) error {
 
 }
 
+func TestValidateFsxWindowsFileSystemPreferredSubnetId(t *testing.T) {
+	testCases := []struct {
+		name              string
+		deploymentType    string
+		preferredSubnetId string
+		subnetIds         []string
+		expectError       bool
+	}{
+		{
+			name:           "single AZ 1 without preferred subnet",
+			deploymentType: fsx.WindowsDeploymentTypeSingleAz1,
+			subnetIds:      []string{"subnet-1"},
+		},
+		{
+			name:           "omitted deployment type without preferred subnet",
+			deploymentType: "",
+			subnetIds:      []string{"subnet-1"},
+		},
+		{
+			name:              "single AZ 1 with preferred subnet",
+			deploymentType:    fsx.WindowsDeploymentTypeSingleAz1,
+			preferredSubnetId: "subnet-1",
+			subnetIds:         []string{"subnet-1"},
+			expectError:       true,
+		},
+		{
+			name:              "single AZ 2 with preferred subnet",
+			deploymentType:    fsx.WindowsDeploymentTypeSingleAz2,
+			preferredSubnetId: "subnet-1",
+			subnetIds:         []string{"subnet-1"},
+			expectError:       true,
+		},
+		{
+			name:           "multi AZ without preferred subnet",
+			deploymentType: fsx.WindowsDeploymentTypeMultiAz1,
+			subnetIds:      []string{"subnet-1", "subnet-2"},
+			expectError:    true,
+		},
+		{
+			name:              "multi AZ with preferred subnet not in subnet_ids",
+			deploymentType:    fsx.WindowsDeploymentTypeMultiAz1,
+			preferredSubnetId: "subnet-3",
+			subnetIds:         []string{"subnet-1", "subnet-2"},
+			expectError:       true,
+		},
+		{
+			name:              "multi AZ with preferred subnet in subnet_ids",
+			deploymentType:    fsx.WindowsDeploymentTypeMultiAz1,
+			preferredSubnetId: "subnet-2",
+			subnetIds:         []string{"subnet-1", "subnet-2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateFsxWindowsFileSystemPreferredSubnetId(testCase.deploymentType, testCase.preferredSubnetId, aws.StringSlice(testCase.subnetIds))
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateFsxWindowsFileSystemStorageCapacity(t *testing.T) {
+	testCases := []struct {
+		name            string
+		storageType     string
+		storageCapacity int
+		expectError     bool
+	}{
+		{
+			name:            "SSD minimum",
+			storageType:     fsx.StorageTypeSsd,
+			storageCapacity: 32,
+		},
+		{
+			name:            "HDD below minimum",
+			storageType:     fsx.StorageTypeHdd,
+			storageCapacity: 1999,
+			expectError:     true,
+		},
+		{
+			name:            "HDD minimum",
+			storageType:     fsx.StorageTypeHdd,
+			storageCapacity: 2000,
+		},
+		{
+			name:        "HDD from backup",
+			storageType: fsx.StorageTypeHdd,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateFsxWindowsFileSystemStorageCapacity(testCase.storageType, testCase.storageCapacity)
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestResourceAwsFsxWindowsFileSystemCustomizeDiff(t *testing.T) {
+	existing := func() *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "fs-12345678901234567",
+			Attributes: map[string]string{
+				"id":                   "fs-12345678901234567",
+				"copy_tags_to_backups": "false",
+				"deployment_type":      fsx.WindowsDeploymentTypeSingleAz1,
+				"skip_final_backup":    "false",
+				"storage_capacity":     "64",
+				"storage_type":         fsx.StorageTypeSsd,
+				"subnet_ids.#":         "1",
+				"subnet_ids.0":         "subnet-12345678",
+				"throughput_capacity":  "8",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		state         *terraform.InstanceState
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name: "storage_capacity omitted",
+			config: map[string]interface{}{
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+			expectedError: "storage_capacity is required when backup_id is not set",
+		},
+		{
+			name: "storage_capacity set",
+			config: map[string]interface{}{
+				"storage_capacity":    32,
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+		},
+		{
+			name: "storage_capacity omitted with backup_id",
+			config: map[string]interface{}{
+				"backup_id":           "backup-12345678901234567",
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+		},
+		{
+			name: "preferred_subnet_id with deployment_type omitted",
+			config: map[string]interface{}{
+				"preferred_subnet_id": "subnet-12345678",
+				"storage_capacity":    32,
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+			expectedError: "preferred_subnet_id must not be set when deployment_type is not MULTI_AZ_1",
+		},
+		{
+			name: "kms_key_id with backup_id",
+			config: map[string]interface{}{
+				"backup_id":           "backup-12345678901234567",
+				"kms_key_id":          "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+			expectedError: "kms_key_id must not be set when backup_id is set",
+		},
+		{
+			name:  "storage_capacity decreased",
+			state: existing(),
+			config: map[string]interface{}{
+				"storage_capacity":    32,
+				"subnet_ids":          []interface{}{"subnet-12345678"},
+				"throughput_capacity": 8,
+			},
+			expectedError: "storage_capacity cannot be decreased",
+		},
+		{
+			name:  "storage_capacity decreased with replacement",
+			state: existing(),
+			config: map[string]interface{}{
+				"storage_capacity":    32,
+				"subnet_ids":          []interface{}{"subnet-87654321"},
+				"throughput_capacity": 8,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := resourceAwsFsxWindowsFileSystem().Diff(context.Background(), testCase.state, terraform.NewResourceConfigRaw(testCase.config), nil)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Fatalf("expected error containing %q, got: %s", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateFsxWindowsFileSystemStorageCapacityUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		oldCapacity   int
+		newCapacity   int
+		expectedError string
+	}{
+		{
+			name:        "unchanged",
+			oldCapacity: 32,
+			newCapacity: 32,
+		},
+		{
+			name:        "new file system",
+			newCapacity: 32,
+		},
+		{
+			name:          "decrease",
+			oldCapacity:   36,
+			newCapacity:   35,
+			expectedError: "cannot be decreased from 36 to 35 GiB",
+		},
+		{
+			name:          "increase below minimum",
+			oldCapacity:   32,
+			newCapacity:   35,
+			expectedError: "from 32 to at least 36 GiB, got 35",
+		},
+		{
+			name:        "increase at minimum",
+			oldCapacity: 32,
+			newCapacity: 36,
+		},
+		{
+			name:          "increase below rounded up minimum",
+			oldCapacity:   2001,
+			newCapacity:   2201,
+			expectedError: "from 2001 to at least 2202 GiB, got 2201",
+		},
+		{
+			name:        "increase at rounded up minimum",
+			oldCapacity: 2001,
+			newCapacity: 2202,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateFsxWindowsFileSystemStorageCapacityUpdate(testCase.oldCapacity, testCase.newCapacity)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", testCase.expectedError)
+			}
+
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("got error %q, expected it to contain %q", err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestFsxWindowsFileSystemDeploymentTypeChangeError(t *testing.T) {
+	testCases := []struct {
+		name             string
+		skipFinalBackup  bool
+		restoresBackup   bool
+		allowDestructive bool
+		expectError      bool
+	}{
+		{
+			name: "final backup taken",
+		},
+		{
+			name:            "final backup skipped",
+			skipFinalBackup: true,
+			expectError:     true,
+		},
+		{
+			name:            "final backup skipped restoring from backup",
+			skipFinalBackup: true,
+			restoresBackup:  true,
+		},
+		{
+			name:             "final backup skipped destructive change allowed",
+			skipFinalBackup:  true,
+			allowDestructive: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fsxWindowsFileSystemDeploymentTypeChangeError(testCase.skipFinalBackup, testCase.restoresBackup, testCase.allowDestructive)
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestFsxWindowsFileSystemUpdateLifecycleError(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		lifecycle               string
+		updatingActiveDirectory bool
+		expectError             bool
+	}{
+		{
+			name:      "available",
+			lifecycle: fsx.FileSystemLifecycleAvailable,
+		},
+		{
+			name:        "misconfigured",
+			lifecycle:   fsx.FileSystemLifecycleMisconfigured,
+			expectError: true,
+		},
+		{
+			name:                    "misconfigured updating active directory",
+			lifecycle:               fsx.FileSystemLifecycleMisconfigured,
+			updatingActiveDirectory: true,
+		},
+		{
+			name:        "failed",
+			lifecycle:   fsx.FileSystemLifecycleFailed,
+			expectError: true,
+		},
+		{
+			name:                    "failed updating active directory",
+			lifecycle:               fsx.FileSystemLifecycleFailed,
+			updatingActiveDirectory: true,
+			expectError:             true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fsxWindowsFileSystemUpdateLifecycleError("fs-12345678", testCase.lifecycle, testCase.updatingActiveDirectory)
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSuppressFsxEquivalentStartTimes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		suppress schema.SchemaDiffSuppressFunc
+		old      string
+		new      string
+		expected bool
+	}{
+		{"weekly equal", suppressFsxEquivalentWeeklyMaintenanceStartTime, "1:01:01", "1:01:01", true},
+		{"weekly zero-padded day", suppressFsxEquivalentWeeklyMaintenanceStartTime, "1:01:01", "01:01:01", true},
+		{"weekly unpadded hour", suppressFsxEquivalentWeeklyMaintenanceStartTime, "7:07:00", "7:7:00", true},
+		{"weekly zero-padded day and unpadded hour", suppressFsxEquivalentWeeklyMaintenanceStartTime, "2:05:30", "02:5:30", true},
+		{"weekly different day", suppressFsxEquivalentWeeklyMaintenanceStartTime, "1:01:01", "2:01:01", false},
+		{"weekly different time", suppressFsxEquivalentWeeklyMaintenanceStartTime, "1:01:01", "1:01:02", false},
+		{"weekly new value", suppressFsxEquivalentWeeklyMaintenanceStartTime, "", "1:01:01", false},
+		{"daily equal", suppressFsxEquivalentDailyAutomaticBackupStartTime, "07:00", "07:00", true},
+		{"daily unpadded hour", suppressFsxEquivalentDailyAutomaticBackupStartTime, "07:00", "7:00", true},
+		{"daily different time", suppressFsxEquivalentDailyAutomaticBackupStartTime, "07:00", "17:00", false},
+		{"daily new value", suppressFsxEquivalentDailyAutomaticBackupStartTime, "", "07:00", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.suppress("", testCase.old, testCase.new, nil); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestSuppressFsxWindowsFileSystemImportedBackupId(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       string
+		old      string
+		new      string
+		expected bool
+	}{
+		{"new file system", "", "", "backup-00000000000000001", false},
+		{"imported file system", "fs-00000000000000001", "", "backup-00000000000000001", true},
+		{"changed backup", "fs-00000000000000001", "backup-00000000000000001", "backup-00000000000000002", false},
+		{"removed backup", "fs-00000000000000001", "backup-00000000000000001", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := resourceAwsFsxWindowsFileSystem().Data(nil)
+			d.SetId(testCase.id)
+
+			if got := suppressFsxWindowsFileSystemImportedBackupId("backup_id", testCase.old, testCase.new, d); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestValidateFsxStartTimes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		regexp   *regexp.Regexp
+		value    string
+		expected bool
+	}{
+		{"weekly", fsxWeeklyMaintenanceStartTimeRegexp, "1:01:01", true},
+		{"weekly zero-padded day", fsxWeeklyMaintenanceStartTimeRegexp, "01:01:01", true},
+		{"weekly unpadded hour", fsxWeeklyMaintenanceStartTimeRegexp, "7:7:00", true},
+		{"weekly day 0", fsxWeeklyMaintenanceStartTimeRegexp, "0:01:01", false},
+		{"weekly day 8", fsxWeeklyMaintenanceStartTimeRegexp, "8:01:01", false},
+		{"weekly hour 24", fsxWeeklyMaintenanceStartTimeRegexp, "1:24:00", false},
+		{"weekly without day", fsxWeeklyMaintenanceStartTimeRegexp, "7:00", false},
+		{"daily", fsxDailyAutomaticBackupStartTimeRegexp, "07:00", true},
+		{"daily unpadded hour", fsxDailyAutomaticBackupStartTimeRegexp, "7:00", true},
+		{"daily minute 60", fsxDailyAutomaticBackupStartTimeRegexp, "07:60", false},
+		{"daily with day", fsxDailyAutomaticBackupStartTimeRegexp, "1:07:00", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.regexp.MatchString(testCase.value); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestExpandFsxWindowsFileSystemUpdateInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		changes  map[string]interface{}
+		expected *fsx.UpdateFileSystemInput
+	}{
+		{
+			name:    "no changes",
+			changes: map[string]interface{}{},
+		},
+		{
+			name: "storage capacity only",
+			changes: map[string]interface{}{
+				"storage_capacity": 64,
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId:    aws.String("fs-12345678"),
+				StorageCapacity: aws.Int64(64),
+			},
+		},
+		{
+			name: "throughput capacity only",
+			changes: map[string]interface{}{
+				"throughput_capacity": 16,
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId: aws.String("fs-12345678"),
+				WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{
+					ThroughputCapacity: aws.Int64(16),
+				},
+			},
+		},
+		{
+			name: "weekly maintenance start time only",
+			changes: map[string]interface{}{
+				"weekly_maintenance_start_time": "1:01:01",
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId: aws.String("fs-12345678"),
+				WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{
+					WeeklyMaintenanceStartTime: aws.String("1:01:01"),
+				},
+			},
+		},
+		{
+			name: "weekly maintenance start time normalized",
+			changes: map[string]interface{}{
+				"weekly_maintenance_start_time": "01:1:01",
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId: aws.String("fs-12345678"),
+				WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{
+					WeeklyMaintenanceStartTime: aws.String("1:01:01"),
+				},
+			},
+		},
+		{
+			name: "active directory DNS IPs only",
+			changes: map[string]interface{}{
+				"self_managed_active_directory.0.dns_ips": schema.NewSet(schema.HashString, []interface{}{"10.0.0.111"}),
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId: aws.String("fs-12345678"),
+				WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{
+					SelfManagedActiveDirectoryConfiguration: &fsx.SelfManagedActiveDirectoryConfigurationUpdates{
+						DnsIps: aws.StringSlice([]string{"10.0.0.111"}),
+					},
+				},
+			},
+		},
+		{
+			name: "backup settings",
+			changes: map[string]interface{}{
+				"automatic_backup_retention_days":   0,
+				"daily_automatic_backup_start_time": "01:02",
+			},
+			expected: &fsx.UpdateFileSystemInput{
+				FileSystemId: aws.String("fs-12345678"),
+				WindowsConfiguration: &fsx.UpdateFileSystemWindowsConfiguration{
+					AutomaticBackupRetentionDays:  aws.Int64(0),
+					DailyAutomaticBackupStartTime: aws.String("01:02"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := expandFsxWindowsFileSystemUpdateInput("fs-12345678", testCase.changes)
+
+			if got != nil {
+				if aws.StringValue(got.ClientRequestToken) == "" {
+					t.Errorf("expected ClientRequestToken to be set")
+				}
+
+				got.ClientRequestToken = nil
+			}
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %s, expected %s", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenFsxAdministrativeActions(t *testing.T) {
+	requestTime := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		actions  []*fsx.AdministrativeAction
+		expected []interface{}
+	}{
+		{
+			name:     "none",
+			expected: []interface{}{},
+		},
+		{
+			name: "ordered by request time",
+			actions: []*fsx.AdministrativeAction{
+				{
+					AdministrativeActionType: aws.String(fsx.AdministrativeActionTypeStorageOptimization),
+					ProgressPercent:          aws.Int64(40),
+					RequestTime:              aws.Time(requestTime.Add(time.Minute)),
+					Status:                   aws.String(fsx.StatusInProgress),
+					TargetFileSystemValues: &fsx.FileSystem{
+						StorageCapacity: aws.Int64(64),
+					},
+				},
+				{
+					AdministrativeActionType: aws.String(fsx.AdministrativeActionTypeFileSystemUpdate),
+					RequestTime:              aws.Time(requestTime),
+					Status:                   aws.String(fsx.StatusUpdatedOptimizing),
+					TargetFileSystemValues: &fsx.FileSystem{
+						StorageCapacity: aws.Int64(64),
+						WindowsConfiguration: &fsx.WindowsFileSystemConfiguration{
+							ThroughputCapacity: aws.Int64(16),
+						},
+					},
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"progress_percent":           0,
+					"request_time":               "2021-01-01T12:00:00Z",
+					"status":                     fsx.StatusUpdatedOptimizing,
+					"target_storage_capacity":    64,
+					"target_throughput_capacity": 16,
+					"type":                       fsx.AdministrativeActionTypeFileSystemUpdate,
+				},
+				map[string]interface{}{
+					"progress_percent":        40,
+					"request_time":            "2021-01-01T12:01:00Z",
+					"status":                  fsx.StatusInProgress,
+					"target_storage_capacity": 64,
+					"type":                    fsx.AdministrativeActionTypeStorageOptimization,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := flattenFsxAdministrativeActions(testCase.actions)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %#v, expected %#v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenFsxAliases(t *testing.T) {
+	aliases := []*fsx.Alias{
+		{
+			Lifecycle: aws.String(fsx.AliasLifecycleAvailable),
+			Name:      aws.String("fs1.example.com"),
+		},
+		nil,
+		{
+			Lifecycle: aws.String(fsx.AliasLifecycleCreating),
+			Name:      aws.String("fs2.example.com"),
+		},
+		{
+			Lifecycle: aws.String(fsx.AliasLifecycleDeleting),
+			Name:      aws.String("fs3.example.com"),
+		},
+	}
+
+	got := flattenFsxAliases(aliases)
+	expected := []interface{}{"fs1.example.com", "fs2.example.com"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+}
+
+func TestFsxLatestAdministrativeAction(t *testing.T) {
+	requestTime := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	completed := &fsx.AdministrativeAction{
+		AdministrativeActionType: aws.String(fsx.AdministrativeActionTypeFileSystemUpdate),
+		RequestTime:              aws.Time(requestTime),
+		Status:                   aws.String(fsx.StatusCompleted),
+	}
+	inProgress := &fsx.AdministrativeAction{
+		AdministrativeActionType: aws.String(fsx.AdministrativeActionTypeFileSystemUpdate),
+		RequestTime:              aws.Time(requestTime.Add(time.Hour)),
+		Status:                   aws.String(fsx.StatusInProgress),
+	}
+	optimization := &fsx.AdministrativeAction{
+		AdministrativeActionType: aws.String(fsx.AdministrativeActionTypeStorageOptimization),
+		RequestTime:              aws.Time(requestTime.Add(2 * time.Hour)),
+		Status:                   aws.String(fsx.StatusInProgress),
+	}
+
+	testCases := []struct {
+		name     string
+		actions  []*fsx.AdministrativeAction
+		expected *fsx.AdministrativeAction
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:    "other type",
+			actions: []*fsx.AdministrativeAction{optimization},
+		},
+		{
+			name:     "latest listed last",
+			actions:  []*fsx.AdministrativeAction{completed, optimization, inProgress},
+			expected: inProgress,
+		},
+		{
+			name:     "latest listed first",
+			actions:  []*fsx.AdministrativeAction{inProgress, nil, completed},
+			expected: inProgress,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := fsxLatestAdministrativeAction(testCase.actions, fsx.AdministrativeActionTypeFileSystemUpdate)
+
+			if got != testCase.expected {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
 func TestAccAWSFsxWindowsFileSystem_basic(t *testing.T) {
 	var filesystem fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
@@ -89,6 +822,7 @@ func TestAccAWSFsxWindowsFileSystem_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_backups", "false"),
 					resource.TestMatchResourceAttr(resourceName, "daily_automatic_backup_start_time", regexp.MustCompile(`^\d\d:\d\d$`)),
 					resource.TestMatchResourceAttr(resourceName, "dns_name", regexp.MustCompile(`fs-.+\..+`)),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_status", fsx.FileSystemLifecycleAvailable),
 					testAccMatchResourceAttrRegionalARN(resourceName, "kms_key_id", "kms", regexp.MustCompile(`key/.+`)),
 					resource.TestCheckResourceAttr(resourceName, "network_interface_ids.#", "1"),
 					testAccCheckResourceAttrAccountID(resourceName, "owner_id"),
@@ -103,6 +837,8 @@ func TestAccAWSFsxWindowsFileSystem_basic(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "weekly_maintenance_start_time", regexp.MustCompile(`^\d:\d\d:\d\d$`)),
 					resource.TestCheckResourceAttr(resourceName, "deployment_type", "SINGLE_AZ_1"),
 					resource.TestCheckResourceAttr(resourceName, "storage_type", "SSD"),
+					resource.TestMatchResourceAttr(resourceName, "preferred_file_server_ip", regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)),
+					resource.TestCheckResourceAttrPair(resourceName, "remote_administration_endpoint", resourceName, "dns_name"),
 				),
 			},
 			{
@@ -154,6 +890,68 @@ func TestAccAWSFsxWindowsFileSystem_singleAz2(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "weekly_maintenance_start_time", regexp.MustCompile(`^\d:\d\d:\d\d$`)),
 					resource.TestCheckResourceAttr(resourceName, "deployment_type", "SINGLE_AZ_2"),
 					resource.TestCheckResourceAttr(resourceName, "storage_type", "SSD"),
+					resource.TestMatchResourceAttr(resourceName, "preferred_file_server_ip", regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)),
+					resource.TestCheckResourceAttrPair(resourceName, "remote_administration_endpoint", resourceName, "dns_name"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"security_group_ids",
+					"skip_final_backup",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSFsxWindowsFileSystem_DeploymentTypeChange(t *testing.T) {
+	var filesystem1, filesystem2, filesystem3 fsx.FileSystem
+	var backupID string
+	resourceName := "aws_fsx_windows_file_system.test"
+
+	defer testAccDeleteFsxBackup(&backupID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(fsx.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFsxWindowsFileSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChange(fsx.WindowsDeploymentTypeSingleAz1, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem1),
+					resource.TestCheckResourceAttr(resourceName, "allow_destructive_deployment_type_change", "false"),
+					resource.TestCheckResourceAttr(resourceName, "deployment_type", fsx.WindowsDeploymentTypeSingleAz1),
+					testAccCreateFsxBackup(&filesystem1, &backupID),
+				),
+			},
+			{
+				Config:      testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChange(fsx.WindowsDeploymentTypeSingleAz2, false),
+				ExpectError: regexp.MustCompile(`changing deployment_type replaces the file system`),
+			},
+			{
+				PreConfig: func() {
+					os.Setenv("TF_VAR_fsx_backup_id", backupID)
+				},
+				Config: testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChangeBackupId(fsx.WindowsDeploymentTypeSingleAz2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem2),
+					testAccCheckFsxWindowsFileSystemRecreated(&filesystem1, &filesystem2),
+					resource.TestCheckResourceAttr(resourceName, "allow_destructive_deployment_type_change", "false"),
+					resource.TestCheckResourceAttrPtr(resourceName, "backup_id", &backupID),
+					resource.TestCheckResourceAttr(resourceName, "deployment_type", fsx.WindowsDeploymentTypeSingleAz2),
+				),
+			},
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChange(fsx.WindowsDeploymentTypeSingleAz1, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem3),
+					testAccCheckFsxWindowsFileSystemRecreated(&filesystem2, &filesystem3),
+					resource.TestCheckResourceAttr(resourceName, "allow_destructive_deployment_type_change", "true"),
+					resource.TestCheckResourceAttr(resourceName, "deployment_type", fsx.WindowsDeploymentTypeSingleAz1),
 				),
 			},
 			{
@@ -161,6 +959,7 @@ func TestAccAWSFsxWindowsFileSystem_singleAz2(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"allow_destructive_deployment_type_change",
 					"security_group_ids",
 					"skip_final_backup",
 				},
@@ -230,6 +1029,53 @@ func TestAccAWSFsxWindowsFileSystem_multiAz(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "weekly_maintenance_start_time", regexp.MustCompile(`^\d:\d\d:\d\d$`)),
 					resource.TestCheckResourceAttr(resourceName, "deployment_type", "MULTI_AZ_1"),
 					resource.TestCheckResourceAttr(resourceName, "storage_type", "SSD"),
+					resource.TestMatchResourceAttr(resourceName, "preferred_file_server_ip", regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)),
+					resource.TestMatchResourceAttr(resourceName, "remote_administration_endpoint", regexp.MustCompile(`^amznfsx\w{8}\.corp\.notexample\.com$`)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"security_group_ids",
+					"skip_final_backup",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSFsxWindowsFileSystem_BackupId(t *testing.T) {
+	var filesystem1, filesystem2 fsx.FileSystem
+	var backupID string
+	sourceResourceName := "aws_fsx_windows_file_system.test"
+	resourceName := "aws_fsx_windows_file_system.restored"
+
+	defer testAccDeleteFsxBackup(&backupID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(fsx.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFsxWindowsFileSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigSubnetIds1(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(sourceResourceName, &filesystem1),
+					testAccCreateFsxBackup(&filesystem1, &backupID),
+				),
+			},
+			{
+				PreConfig: func() {
+					os.Setenv("TF_VAR_fsx_backup_id", backupID)
+				},
+				Config: testAccAwsFsxWindowsFileSystemConfigBackupId(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem2),
+					resource.TestCheckResourceAttrPtr(resourceName, "backup_id", &backupID),
+					resource.TestCheckResourceAttr(resourceName, "storage_capacity", "32"),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", sourceResourceName, "kms_key_id"),
 				),
 			},
 			{
@@ -237,6 +1083,7 @@ func TestAccAWSFsxWindowsFileSystem_multiAz(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"backup_id",
 					"security_group_ids",
 					"skip_final_backup",
 				},
@@ -245,6 +1092,37 @@ func TestAccAWSFsxWindowsFileSystem_multiAz(t *testing.T) {
 	})
 }
 
+func TestAccAWSFsxWindowsFileSystem_FinalBackupTags(t *testing.T) {
+	var filesystem fsx.FileSystem
+	resourceName := "aws_fsx_windows_file_system.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(fsx.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFsxWindowsFileSystemFinalBackupTagged(&filesystem, "key1", "value1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigFinalBackupTags("key1", "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem),
+					resource.TestCheckResourceAttr(resourceName, "skip_final_backup", "false"),
+					resource.TestCheckResourceAttr(resourceName, "final_backup_tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "final_backup_tags.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"final_backup_tags",
+					"security_group_ids",
+				},
+			},
+		},
+	})
+}
+
 func TestAccAWSFsxWindowsFileSystem_disappears(t *testing.T) {
 	var filesystem fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
@@ -266,6 +1144,59 @@ func TestAccAWSFsxWindowsFileSystem_disappears(t *testing.T) {
 	})
 }
 
+func TestAccAWSFsxWindowsFileSystem_Aliases(t *testing.T) {
+	var filesystem1, filesystem2, filesystem3 fsx.FileSystem
+	resourceName := "aws_fsx_windows_file_system.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(fsx.EndpointsID, t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFsxWindowsFileSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAwsFsxWindowsFileSystemConfigAliases1("FileSystem1.example.com"),
+				ExpectError: regexp.MustCompile(`must be lowercase`),
+			},
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigAliases1("filesystem1.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem1),
+					resource.TestCheckResourceAttr(resourceName, "aliases.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "aliases.*", "filesystem1.example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"security_group_ids",
+					"skip_final_backup",
+				},
+			},
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigAliases2("filesystem2.example.com", "filesystem3.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem2),
+					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem1, &filesystem2),
+					resource.TestCheckResourceAttr(resourceName, "aliases.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "aliases.*", "filesystem2.example.com"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "aliases.*", "filesystem3.example.com"),
+				),
+			},
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigAliases1("filesystem3.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem3),
+					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem2, &filesystem3),
+					resource.TestCheckResourceAttr(resourceName, "aliases.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "aliases.*", "filesystem3.example.com"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSFsxWindowsFileSystem_AutomaticBackupRetentionDays(t *testing.T) {
 	var filesystem1, filesystem2, filesystem3 fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
@@ -328,6 +1259,8 @@ func TestAccAWSFsxWindowsFileSystem_CopyTagsToBackups(t *testing.T) {
 				),
 			},
 			{
+				// copy_tags_to_backups is ForceNew, so it must be read back on import
+				// to avoid a plan that replaces the file system.
 				ResourceName:      resourceName,
 				ImportState:       true,
 				ImportStateVerify: true,
@@ -336,6 +1269,10 @@ func TestAccAWSFsxWindowsFileSystem_CopyTagsToBackups(t *testing.T) {
 					"skip_final_backup",
 				},
 			},
+			{
+				Config:   testAccAwsFsxWindowsFileSystemConfigCopyTagsToBackups(true),
+				PlanOnly: true,
+			},
 			{
 				Config: testAccAwsFsxWindowsFileSystemConfigCopyTagsToBackups(false),
 				Check: resource.ComposeTestCheckFunc(
@@ -492,7 +1429,7 @@ func TestAccAWSFsxWindowsFileSystem_SelfManagedActiveDirectory(t *testing.T) {
 }
 
 func TestAccAWSFsxWindowsFileSystem_SelfManagedActiveDirectory_Username(t *testing.T) {
-	var filesystem fsx.FileSystem
+	var filesystem1, filesystem2 fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -503,7 +1440,7 @@ func TestAccAWSFsxWindowsFileSystem_SelfManagedActiveDirectory_Username(t *testi
 			{
 				Config: testAccAwsFsxWindowsFileSystemConfigSelfManagedActiveDirectoryUsername("Admin"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem),
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem1),
 					resource.TestCheckResourceAttr(resourceName, "self_managed_active_directory.#", "1"),
 				),
 			},
@@ -520,8 +1457,10 @@ func TestAccAWSFsxWindowsFileSystem_SelfManagedActiveDirectory_Username(t *testi
 			{
 				Config: testAccAwsFsxWindowsFileSystemConfigSelfManagedActiveDirectoryUsername("Administrator"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem),
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem2),
+					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem1, &filesystem2),
 					resource.TestCheckResourceAttr(resourceName, "self_managed_active_directory.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "self_managed_active_directory.0.username", "Administrator"),
 				),
 			},
 		},
@@ -529,7 +1468,7 @@ func TestAccAWSFsxWindowsFileSystem_SelfManagedActiveDirectory_Username(t *testi
 }
 
 func TestAccAWSFsxWindowsFileSystem_StorageCapacity(t *testing.T) {
-	var filesystem1, filesystem2 fsx.FileSystem
+	var filesystem1, filesystem2, filesystem3 fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -541,6 +1480,7 @@ func TestAccAWSFsxWindowsFileSystem_StorageCapacity(t *testing.T) {
 				Config: testAccAwsFsxWindowsFileSystemConfigStorageCapacity(32),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem1),
+					resource.TestCheckResourceAttr(resourceName, "administrative_actions.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "storage_capacity", "32"),
 				),
 			},
@@ -559,8 +1499,30 @@ func TestAccAWSFsxWindowsFileSystem_StorageCapacity(t *testing.T) {
 					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem2),
 					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem1, &filesystem2),
 					resource.TestCheckResourceAttr(resourceName, "storage_capacity", "36"),
+					resource.TestMatchResourceAttr(resourceName, "administrative_actions.#", regexp.MustCompile(`^[1-9]`)),
+					resource.TestCheckResourceAttr(resourceName, "administrative_actions.0.type", fsx.AdministrativeActionTypeFileSystemUpdate),
+					resource.TestCheckResourceAttr(resourceName, "administrative_actions.0.target_storage_capacity", "36"),
+					resource.TestMatchResourceAttr(resourceName, "administrative_actions.0.status", regexp.MustCompile(`^(COMPLETED|UPDATED_OPTIMIZING)$`)),
+				),
+			},
+			// A following update must not fail with an update already in progress
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigStorageAndThroughputCapacity(36, 32),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem3),
+					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem2, &filesystem3),
+					resource.TestCheckResourceAttr(resourceName, "storage_capacity", "36"),
+					resource.TestCheckResourceAttr(resourceName, "throughput_capacity", "32"),
 				),
 			},
+			{
+				Config:      testAccAwsFsxWindowsFileSystemConfigStorageAndThroughputCapacity(32, 32),
+				ExpectError: regexp.MustCompile(`storage_capacity cannot be decreased from 36 to 32 GiB`),
+			},
+			{
+				Config:      testAccAwsFsxWindowsFileSystemConfigStorageAndThroughputCapacity(38, 32),
+				ExpectError: regexp.MustCompile(`from 36 to at least 40 GiB, got 38`),
+			},
 		},
 	})
 }
@@ -615,7 +1577,7 @@ func TestAccAWSFsxWindowsFileSystem_Tags(t *testing.T) {
 }
 
 func TestAccAWSFsxWindowsFileSystem_ThroughputCapacity(t *testing.T) {
-	var filesystem1, filesystem2 fsx.FileSystem
+	var filesystem1, filesystem2, filesystem3 fsx.FileSystem
 	resourceName := "aws_fsx_windows_file_system.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -647,6 +1609,15 @@ func TestAccAWSFsxWindowsFileSystem_ThroughputCapacity(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "throughput_capacity", "32"),
 				),
 			},
+			// Back-to-back update must wait for the previous update to complete
+			{
+				Config: testAccAwsFsxWindowsFileSystemConfigThroughputCapacity(64),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFsxWindowsFileSystemExists(resourceName, &filesystem3),
+					testAccCheckFsxWindowsFileSystemNotRecreated(&filesystem2, &filesystem3),
+					resource.TestCheckResourceAttr(resourceName, "throughput_capacity", "64"),
+				),
+			},
 		},
 	})
 }
@@ -684,6 +1655,10 @@ func TestAccAWSFsxWindowsFileSystem_WeeklyMaintenanceStartTime(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "weekly_maintenance_start_time", "2:02:02"),
 				),
 			},
+			{
+				Config:   testAccAwsFsxWindowsFileSystemConfigWeeklyMaintenanceStartTime("02:02:02"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -738,6 +1713,116 @@ func testAccCheckFsxWindowsFileSystemDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCreateFsxBackup takes a user-initiated backup of the file system and
+// waits for it to become available, as there is no FSx backup resource.
+func testAccCreateFsxBackup(fs *fsx.FileSystem, backupID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).fsxconn
+
+		output, err := conn.CreateBackup(&fsx.CreateBackupInput{
+			ClientRequestToken: aws.String(resource.UniqueId()),
+			FileSystemId:       fs.FileSystemId,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating FSx Backup for File System (%s): %w", aws.StringValue(fs.FileSystemId), err)
+		}
+
+		*backupID = aws.StringValue(output.Backup.BackupId)
+
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{fsx.BackupLifecyclePending, fsx.BackupLifecycleCreating},
+			Target:  []string{fsx.BackupLifecycleAvailable},
+			Refresh: func() (interface{}, string, error) {
+				output, err := conn.DescribeBackups(&fsx.DescribeBackupsInput{
+					BackupIds: []*string{aws.String(*backupID)},
+				})
+
+				if err != nil {
+					return nil, "", err
+				}
+
+				if len(output.Backups) == 0 || output.Backups[0] == nil {
+					return nil, "", nil
+				}
+
+				return output.Backups[0], aws.StringValue(output.Backups[0].Lifecycle), nil
+			},
+			Timeout: 30 * time.Minute,
+			Delay:   30 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("error waiting for FSx Backup (%s) to become available: %w", *backupID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccDeleteFsxBackup(backupID *string) {
+	os.Unsetenv("TF_VAR_fsx_backup_id")
+
+	if *backupID == "" {
+		return
+	}
+
+	conn := testAccProvider.Meta().(*AWSClient).fsxconn
+
+	_, err := conn.DeleteBackup(&fsx.DeleteBackupInput{
+		BackupId: aws.String(*backupID),
+	})
+
+	if err != nil && !isAWSErr(err, fsx.ErrCodeBackupNotFound, "") {
+		log.Printf("[WARN] Unable to delete FSx Backup (%s): %s", *backupID, err)
+	}
+}
+
+// testAccCheckFsxWindowsFileSystemFinalBackupTagged checks that the file system was
+// deleted with a final backup carrying the specified tag, then deletes the backup.
+func testAccCheckFsxWindowsFileSystemFinalBackupTagged(fs *fsx.FileSystem, tagKey, tagValue string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if err := testAccCheckFsxWindowsFileSystemDestroy(s); err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).fsxconn
+
+		output, err := conn.DescribeBackups(&fsx.DescribeBackupsInput{
+			Filters: []*fsx.Filter{
+				{
+					Name:   aws.String(fsx.FilterNameFileSystemId),
+					Values: []*string{fs.FileSystemId},
+				},
+			},
+		})
+
+		if err != nil {
+			return fmt.Errorf("error listing FSx Backups for File System (%s): %w", aws.StringValue(fs.FileSystemId), err)
+		}
+
+		var found bool
+
+		for _, backup := range output.Backups {
+			if _, err := conn.DeleteBackup(&fsx.DeleteBackupInput{BackupId: backup.BackupId}); err != nil && !isAWSErr(err, fsx.ErrCodeBackupNotFound, "") {
+				log.Printf("[WARN] Unable to delete FSx Backup (%s): %s", aws.StringValue(backup.BackupId), err)
+			}
+
+			for _, tag := range backup.Tags {
+				if aws.StringValue(tag.Key) == tagKey && aws.StringValue(tag.Value) == tagValue {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("FSx File System (%s) final backup with tag %s=%s not found", aws.StringValue(fs.FileSystemId), tagKey, tagValue)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckFsxWindowsFileSystemNotRecreated(i, j *fsx.FileSystem) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if aws.StringValue(i.FileSystemId) != aws.StringValue(j.FileSystemId) {
@@ -799,6 +1884,32 @@ resource "aws_directory_service_directory" "test" {
 `
 }
 
+func testAccAwsFsxWindowsFileSystemConfigAliases1(alias1 string) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id = aws_directory_service_directory.test.id
+  aliases             = [%[1]q]
+  skip_final_backup   = true
+  storage_capacity    = 32
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = 8
+}
+`, alias1)
+}
+
+func testAccAwsFsxWindowsFileSystemConfigAliases2(alias1, alias2 string) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id = aws_directory_service_directory.test.id
+  aliases             = [%[1]q, %[2]q]
+  skip_final_backup   = true
+  storage_capacity    = 32
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = 8
+}
+`, alias1, alias2)
+}
+
 func testAccAwsFsxWindowsFileSystemConfigAutomaticBackupRetentionDays(automaticBackupRetentionDays int) string {
 	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
 resource "aws_fsx_windows_file_system" "test" {
@@ -825,6 +1936,22 @@ resource "aws_fsx_windows_file_system" "test" {
 `, copyTagsToBackups)
 }
 
+func testAccAwsFsxWindowsFileSystemConfigBackupId() string {
+	return testAccAwsFsxWindowsFileSystemConfigSubnetIds1() + `
+variable "fsx_backup_id" {
+  type = string
+}
+
+resource "aws_fsx_windows_file_system" "restored" {
+  active_directory_id = aws_directory_service_directory.test.id
+  backup_id           = var.fsx_backup_id
+  skip_final_backup   = true
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = 8
+}
+`
+}
+
 func testAccAwsFsxWindowsFileSystemConfigDailyAutomaticBackupStartTime(dailyAutomaticBackupStartTime string) string {
 	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
 resource "aws_fsx_windows_file_system" "test" {
@@ -838,6 +1965,22 @@ resource "aws_fsx_windows_file_system" "test" {
 `, dailyAutomaticBackupStartTime)
 }
 
+func testAccAwsFsxWindowsFileSystemConfigFinalBackupTags(tagKey1, tagValue1 string) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id = aws_directory_service_directory.test.id
+  skip_final_backup   = false
+  storage_capacity    = 32
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = 8
+
+  final_backup_tags = {
+    %[1]q = %[2]q
+  }
+}
+`, tagKey1, tagValue1)
+}
+
 func testAccAwsFsxWindowsFileSystemConfigKmsKeyId1() string {
 	return testAccAwsFsxWindowsFileSystemConfigBase() + `
 resource "aws_kms_key" "test1" {
@@ -1005,6 +2148,18 @@ resource "aws_fsx_windows_file_system" "test" {
 `, storageCapacity)
 }
 
+func testAccAwsFsxWindowsFileSystemConfigStorageAndThroughputCapacity(storageCapacity, throughputCapacity int) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id = aws_directory_service_directory.test.id
+  skip_final_backup   = true
+  storage_capacity    = %[1]d
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = %[2]d
+}
+`, storageCapacity, throughputCapacity)
+}
+
 func testAccAwsFsxWindowsFileSystemConfigSubnetIds1() string {
 	return testAccAwsFsxWindowsFileSystemConfigBase() + `
 resource "aws_fsx_windows_file_system" "test" {
@@ -1030,6 +2185,38 @@ resource "aws_fsx_windows_file_system" "test" {
 `, azType)
 }
 
+func testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChange(deploymentType string, allowDestructive bool) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id                      = aws_directory_service_directory.test.id
+  allow_destructive_deployment_type_change = %[2]t
+  deployment_type                          = %[1]q
+  skip_final_backup                        = true
+  storage_capacity                         = 32
+  subnet_ids                               = [aws_subnet.test1.id]
+  throughput_capacity                      = 8
+}
+`, deploymentType, allowDestructive)
+}
+
+func testAccAwsFsxWindowsFileSystemConfigDeploymentTypeChangeBackupId(deploymentType string) string {
+	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
+variable "fsx_backup_id" {
+  type = string
+}
+
+resource "aws_fsx_windows_file_system" "test" {
+  active_directory_id = aws_directory_service_directory.test.id
+  backup_id           = var.fsx_backup_id
+  deployment_type     = %[1]q
+  skip_final_backup   = true
+  storage_capacity    = 32
+  subnet_ids          = [aws_subnet.test1.id]
+  throughput_capacity = 8
+}
+`, deploymentType)
+}
+
 func testAccAwsFsxWindowsFileSystemConfigSubnetIds1WithStorageType(azType, storageType string) string {
 	return testAccAwsFsxWindowsFileSystemConfigBase() + fmt.Sprintf(`
 resource "aws_fsx_windows_file_system" "test" {
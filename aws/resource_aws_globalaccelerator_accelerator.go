@@ -1,18 +1,33 @@
 package aws
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	shieldfinder "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/shield/finder"
 )
 
+// Service principal used by Global Accelerator to deliver flow logs to S3.
+const globalAcceleratorFlowLogsDeliveryServicePrincipal = "delivery.logs.amazonaws.com"
+
 // Global Route53 Zone ID for Global Accelerators, exported as a
 // convenience attribute for Route53 aliases (see
 // https://docs.aws.amazon.com/Route53/latest/APIReference/API_AliasTarget.html).
@@ -94,12 +109,18 @@ func resourceAwsGlobalAcceleratorAccelerator() *schema.Resource {
 							Optional: true,
 						},
 						"flow_logs_s3_prefix": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile(`^/`), "must not start with \"/\""),
 						},
 					},
 				},
 			},
+			"enable_shield_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"tags": tagsSchema(),
 		},
 	}
@@ -108,6 +129,12 @@ func resourceAwsGlobalAcceleratorAccelerator() *schema.Resource {
 func resourceAwsGlobalAcceleratorAcceleratorCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).globalacceleratorconn
 
+	if v := d.Get("attributes").([]interface{}); len(v) > 0 && v[0] != nil {
+		if err := resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket(meta, v[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
 	opts := &globalaccelerator.CreateAcceleratorInput{
 		Name:             aws.String(d.Get("name").(string)),
 		IdempotencyToken: aws.String(resource.UniqueId()),
@@ -140,6 +167,12 @@ func resourceAwsGlobalAcceleratorAcceleratorCreate(d *schema.ResourceData, meta
 		}
 	}
 
+	if d.Get("enable_shield_protection").(bool) {
+		if err := resourceAwsGlobalAcceleratorAcceleratorCreateShieldProtection(meta.(*AWSClient).shieldconn, d.Id(), d.Get("name").(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsGlobalAcceleratorAcceleratorRead(d, meta)
 }
 
@@ -180,6 +213,18 @@ func resourceAwsGlobalAcceleratorAcceleratorRead(d *schema.ResourceData, meta in
 		return fmt.Errorf("Error setting Global Accelerator accelerator attributes: %s", err)
 	}
 
+	// Only query Shield when protection is managed by this resource so that
+	// accounts without a Shield Advanced subscription are unaffected.
+	if d.Get("enable_shield_protection").(bool) {
+		protection, err := resourceAwsGlobalAcceleratorAcceleratorRetrieveShieldProtection(meta.(*AWSClient).shieldconn, d.Id())
+
+		if err != nil {
+			return fmt.Errorf("Error reading Global Accelerator accelerator (%s) Shield protection: %s", d.Id(), err)
+		}
+
+		d.Set("enable_shield_protection", protection != nil)
+	}
+
 	tags, err := keyvaluetags.GlobalacceleratorListTags(conn, d.Id())
 	if err != nil {
 		return fmt.Errorf("error listing tags for Global Accelerator accelerator (%s): %s", d.Id(), err)
@@ -287,6 +332,10 @@ func resourceAwsGlobalAcceleratorAcceleratorUpdate(d *schema.ResourceData, meta
 
 	if d.HasChange("attributes") {
 		if v := d.Get("attributes").([]interface{}); len(v) > 0 {
+			if err := resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket(meta, v[0].(map[string]interface{})); err != nil {
+				return err
+			}
+
 			err := resourceAwsGlobalAcceleratorAcceleratorUpdateAttributes(conn, d.Id(), v[0].(map[string]interface{}))
 			if err != nil {
 				return err
@@ -294,6 +343,20 @@ func resourceAwsGlobalAcceleratorAcceleratorUpdate(d *schema.ResourceData, meta
 		}
 	}
 
+	if d.HasChange("enable_shield_protection") {
+		conn := meta.(*AWSClient).shieldconn
+
+		if d.Get("enable_shield_protection").(bool) {
+			if err := resourceAwsGlobalAcceleratorAcceleratorCreateShieldProtection(conn, d.Id(), d.Get("name").(string)); err != nil {
+				return err
+			}
+		} else {
+			if err := resourceAwsGlobalAcceleratorAcceleratorDeleteShieldProtection(conn, d.Id()); err != nil {
+				return err
+			}
+		}
+	}
+
 	if d.HasChange("tags") {
 		o, n := d.GetChange("tags")
 
@@ -346,9 +409,264 @@ func resourceAwsGlobalAcceleratorAcceleratorUpdateAttributes(conn *globalacceler
 	return nil
 }
 
+// resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket verifies that flow logs can be
+// delivered to the configured S3 bucket, as delivery failures are otherwise silent.
+func resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket(meta interface{}, attributes map[string]interface{}) error {
+	bucket, _ := attributes["flow_logs_s3_bucket"].(string)
+
+	if !attributes["flow_logs_enabled"].(bool) || bucket == "" {
+		return nil
+	}
+
+	conn, headErr := globalAcceleratorFlowLogsBucketConn(meta.(*AWSClient).s3conn, bucket)
+
+	// HeadBucket fails with 403 Forbidden if the bucket is owned by another account,
+	// or if the caller is not allowed to list it.
+	if headErr == nil {
+		_, headErr = conn.HeadBucket(&s3.HeadBucketInput{
+			Bucket:              aws.String(bucket),
+			ExpectedBucketOwner: aws.String(meta.(*AWSClient).accountid),
+		})
+	}
+
+	getPolicy := func() (string, error) {
+		output, err := conn.GetBucketPolicy(&s3.GetBucketPolicyInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		return aws.StringValue(output.Policy), nil
+	}
+
+	warning, err := globalAcceleratorFlowLogsBucketCheck(bucket, headErr, getPolicy)
+
+	if warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+
+	return err
+}
+
+// globalAcceleratorFlowLogsBucketConn returns an S3 client for the region of the flow logs
+// bucket, which need not be the provider region. Otherwise HeadBucket fails with 301 Moved
+// Permanently. The bucket region is looked up like for the aws_s3_bucket data source, which
+// also works for buckets owned by other accounts. A missing bucket is returned as an error.
+func globalAcceleratorFlowLogsBucketConn(conn *s3.S3, bucket string) (*s3.S3, error) {
+	region, err := s3manager.GetBucketRegionWithClient(context.Background(), conn, bucket, func(r *request.Request) {
+		r.Config.S3ForcePathStyle = conn.Config.S3ForcePathStyle
+		r.Config.Credentials = conn.Config.Credentials
+	})
+
+	if tfawserr.ErrCodeEquals(err, "NotFound") {
+		return nil, err
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Unable to look up the region of Global Accelerator flow logs S3 bucket (%s): %s", bucket, err)
+		return conn, nil
+	}
+
+	if region == aws.StringValue(conn.Config.Region) {
+		return conn, nil
+	}
+
+	sess, err := session.NewSession(conn.Config.Copy().WithRegion(region))
+
+	if err != nil {
+		log.Printf("[WARN] Unable to create S3 client for region (%s): %s", region, err)
+		return conn, nil
+	}
+
+	return s3.New(sess), nil
+}
+
+// globalAcceleratorFlowLogsBucketCheck decides the outcome of the flow logs S3 bucket pre-check.
+// headErr is the result of looking up the bucket region, then of HeadBucket in that region with
+// the provider account as the expected bucket owner.
+// HeadBucket is also forbidden without s3:ListBucket permission, so a 403 does not prove that
+// the bucket is owned by another account. Only a missing bucket is an error: otherwise the
+// bucket policy is checked for a delivery grant and a non-empty warning is returned when
+// delivery could not be verified.
+func globalAcceleratorFlowLogsBucketCheck(bucket string, headErr error, getPolicy func() (string, error)) (string, error) {
+	if headErr == nil {
+		return "", nil
+	}
+
+	if isAWSErrRequestFailureStatusCode(headErr, 404) || isAWSErr(headErr, s3.ErrCodeNoSuchBucket, "") || isAWSErr(headErr, "NotFound", "") {
+		return "", fmt.Errorf("Global Accelerator flow logs S3 bucket (%s) does not exist", bucket)
+	}
+
+	if !isAWSErrRequestFailureStatusCode(headErr, 403) {
+		return fmt.Sprintf("unable to verify Global Accelerator flow logs S3 bucket (%s): %s", bucket, headErr), nil
+	}
+
+	policy, err := getPolicy()
+
+	if tfawserr.ErrCodeEquals(err, "NoSuchBucketPolicy") {
+		return fmt.Sprintf("Global Accelerator flow logs S3 bucket (%s) may be owned by another account and has no bucket policy granting %s access", bucket, globalAcceleratorFlowLogsDeliveryServicePrincipal), nil
+	}
+
+	if err != nil {
+		return fmt.Sprintf("unable to verify that Global Accelerator flow logs S3 bucket (%s) grants %s access: %s", bucket, globalAcceleratorFlowLogsDeliveryServicePrincipal, err), nil
+	}
+
+	granted, err := globalAcceleratorFlowLogsDeliveryGranted(policy)
+
+	if err != nil {
+		return fmt.Sprintf("unable to verify that Global Accelerator flow logs S3 bucket (%s) grants %s access: %s", bucket, globalAcceleratorFlowLogsDeliveryServicePrincipal, err), nil
+	}
+
+	if !granted {
+		return fmt.Sprintf("Global Accelerator flow logs S3 bucket (%s) may be owned by another account and its bucket policy does not grant %s s3:PutObject access", bucket, globalAcceleratorFlowLogsDeliveryServicePrincipal), nil
+	}
+
+	return "", nil
+}
+
+// globalAcceleratorFlowLogsDeliveryGranted returns whether a bucket policy allows the flow
+// logs delivery service principal to put objects. Conditions are not evaluated.
+func globalAcceleratorFlowLogsDeliveryGranted(policy string) (bool, error) {
+	var doc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return false, fmt.Errorf("error parsing bucket policy: %w", err)
+	}
+
+	for _, statement := range doc.Statements {
+		if statement == nil || statement.Effect != "Allow" {
+			continue
+		}
+
+		principalGranted := false
+		for _, principal := range statement.Principals {
+			if principal.Type == "*" {
+				principalGranted = true
+				break
+			}
+
+			if principal.Type != "Service" {
+				continue
+			}
+
+			for _, identifier := range globalAcceleratorPolicyElementValues(principal.Identifiers) {
+				if identifier == globalAcceleratorFlowLogsDeliveryServicePrincipal {
+					principalGranted = true
+					break
+				}
+			}
+		}
+
+		if !principalGranted {
+			continue
+		}
+
+		// Actions are case insensitive and may contain wildcards, e.g. "s3:Put*".
+		for _, action := range globalAcceleratorPolicyElementValues(statement.Actions) {
+			if ok, _ := path.Match(strings.ToLower(action), "s3:putobject"); ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// globalAcceleratorPolicyElementValues returns the values of a policy element that is
+// either a string or a list of strings.
+func globalAcceleratorPolicyElementValues(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, e := range v {
+			if e, ok := e.(string); ok {
+				values = append(values, e)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorAcceleratorRetrieveShieldProtection(conn *shield.Shield, acceleratorArn string) (*shield.Protection, error) {
+	protection, err := shieldfinder.ProtectionByResourceARN(conn, acceleratorArn)
+
+	if isAWSErr(err, shield.ErrCodeResourceNotFoundException, "") {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return protection, nil
+}
+
+func resourceAwsGlobalAcceleratorAcceleratorCreateShieldProtection(conn *shield.Shield, acceleratorArn, name string) error {
+	opts := &shield.CreateProtectionInput{
+		Name:        aws.String(name),
+		ResourceArn: aws.String(acceleratorArn),
+	}
+
+	log.Printf("[DEBUG] Create Global Accelerator accelerator Shield protection: %s", opts)
+
+	_, err := conn.CreateProtection(opts)
+
+	if isAWSErr(err, shield.ErrCodeResourceAlreadyExistsException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error creating Global Accelerator accelerator (%s) Shield protection: %s", acceleratorArn, err)
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorAcceleratorDeleteShieldProtection(conn *shield.Shield, acceleratorArn string) error {
+	protection, err := resourceAwsGlobalAcceleratorAcceleratorRetrieveShieldProtection(conn, acceleratorArn)
+
+	if err != nil {
+		return fmt.Errorf("Error reading Global Accelerator accelerator (%s) Shield protection: %s", acceleratorArn, err)
+	}
+
+	if protection == nil {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Deleting Global Accelerator accelerator (%s) Shield protection: %s", acceleratorArn, aws.StringValue(protection.Id))
+
+	_, err = conn.DeleteProtection(&shield.DeleteProtectionInput{
+		ProtectionId: protection.Id,
+	})
+
+	if isAWSErr(err, shield.ErrCodeResourceNotFoundException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error deleting Global Accelerator accelerator (%s) Shield protection: %s", acceleratorArn, err)
+	}
+
+	return nil
+}
+
 func resourceAwsGlobalAcceleratorAcceleratorDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).globalacceleratorconn
 
+	if d.Get("enable_shield_protection").(bool) {
+		if err := resourceAwsGlobalAcceleratorAcceleratorDeleteShieldProtection(meta.(*AWSClient).shieldconn, d.Id()); err != nil {
+			return err
+		}
+	}
+
 	{
 		opts := &globalaccelerator.UpdateAcceleratorInput{
 			AcceleratorArn: aws.String(d.Id()),
@@ -7,10 +7,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/globalaccelerator"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -170,6 +172,189 @@ func sweepGlobalAcceleratorEndpointGroups(conn *globalaccelerator.GlobalAccelera
 	return sweeperErrs
 }
 
+func TestGlobalAcceleratorFlowLogsS3PrefixValidation(t *testing.T) {
+	validateFunc := resourceAwsGlobalAcceleratorAccelerator().Schema["attributes"].Elem.(*schema.Resource).Schema["flow_logs_s3_prefix"].ValidateFunc
+
+	testCases := []struct {
+		prefix      string
+		expectError bool
+	}{
+		{prefix: "flow-logs/"},
+		{prefix: "flow-logs/accelerator"},
+		{prefix: "/flow-logs/", expectError: true},
+		{prefix: "/", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validateFunc(testCase.prefix, "flow_logs_s3_prefix")
+
+		if got := len(errs) > 0; got != testCase.expectError {
+			t.Errorf("prefix %q: got errors %v, expected error: %t", testCase.prefix, errs, testCase.expectError)
+		}
+	}
+}
+
+func TestGlobalAcceleratorFlowLogsBucketCheck(t *testing.T) {
+	grantingPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "delivery.logs.amazonaws.com"},
+    "Action": "s3:PutObject",
+    "Resource": "arn:aws:s3:::example/*"
+  }]
+}`
+	notGrantingPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+    "Action": "s3:GetObject",
+    "Resource": "arn:aws:s3:::example/*"
+  }]
+}`
+
+	policy := func(policy string, err error) func() (string, error) {
+		return func() (string, error) {
+			return policy, err
+		}
+	}
+	failOnPolicy := func() (string, error) {
+		t.Fatal("unexpected GetBucketPolicy call")
+		return "", nil
+	}
+	headErr := func(code string, statusCode int) error {
+		return awserr.NewRequestFailure(awserr.New(code, code, nil), statusCode, "")
+	}
+
+	testCases := []struct {
+		name          string
+		headErr       error
+		getPolicy     func() (string, error)
+		expectWarning bool
+		expectError   bool
+	}{
+		{
+			name:      "same account",
+			getPolicy: failOnPolicy,
+		},
+		{
+			name:        "bucket not found",
+			headErr:     headErr("NotFound", 404),
+			getPolicy:   failOnPolicy,
+			expectError: true,
+		},
+		{
+			name:        "bucket region not found",
+			headErr:     awserr.New("NotFound", "bucket not found", nil),
+			getPolicy:   failOnPolicy,
+			expectError: true,
+		},
+		{
+			name:          "unexpected HeadBucket error",
+			headErr:       headErr("BadRequest", 400),
+			getPolicy:     failOnPolicy,
+			expectWarning: true,
+		},
+		{
+			name:      "forbidden, policy grants delivery",
+			headErr:   headErr("Forbidden", 403),
+			getPolicy: policy(grantingPolicy, nil),
+		},
+		{
+			name:          "forbidden, policy does not grant delivery",
+			headErr:       headErr("Forbidden", 403),
+			getPolicy:     policy(notGrantingPolicy, nil),
+			expectWarning: true,
+		},
+		{
+			name:          "forbidden, no bucket policy",
+			headErr:       headErr("Forbidden", 403),
+			getPolicy:     policy("", awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)),
+			expectWarning: true,
+		},
+		{
+			name:          "forbidden, policy not readable",
+			headErr:       headErr("Forbidden", 403),
+			getPolicy:     policy("", awserr.New("AccessDenied", "Access Denied", nil)),
+			expectWarning: true,
+		},
+		{
+			name:          "forbidden, invalid policy",
+			headErr:       headErr("Forbidden", 403),
+			getPolicy:     policy("{", nil),
+			expectWarning: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			warning, err := globalAcceleratorFlowLogsBucketCheck("example", testCase.headErr, testCase.getPolicy)
+
+			if got := warning != ""; got != testCase.expectWarning {
+				t.Errorf("got warning %q, expected warning: %t", warning, testCase.expectWarning)
+			}
+
+			if got := err != nil; got != testCase.expectError {
+				t.Errorf("got error %v, expected error: %t", err, testCase.expectError)
+			}
+		})
+	}
+}
+
+func TestGlobalAcceleratorFlowLogsDeliveryGranted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   string
+		expected bool
+	}{
+		{
+			name:     "service principal list, action list",
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":{"Service":["ec2.amazonaws.com","delivery.logs.amazonaws.com"]},"Action":["s3:GetBucketAcl","s3:PutObject"],"Resource":"*"}]}`,
+			expected: true,
+		},
+		{
+			name:     "wildcard action",
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":{"Service":"delivery.logs.amazonaws.com"},"Action":"s3:Put*","Resource":"*"}]}`,
+			expected: true,
+		},
+		{
+			name:     "wildcard principal",
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:*","Resource":"*"}]}`,
+			expected: true,
+		},
+		{
+			name:     "deny",
+			policy:   `{"Statement":[{"Effect":"Deny","Principal":{"Service":"delivery.logs.amazonaws.com"},"Action":"s3:PutObject","Resource":"*"}]}`,
+			expected: false,
+		},
+		{
+			name:     "other service principal",
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":{"Service":"cloudtrail.amazonaws.com"},"Action":"s3:PutObject","Resource":"*"}]}`,
+			expected: false,
+		},
+		{
+			name:     "other action",
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":{"Service":"delivery.logs.amazonaws.com"},"Action":"s3:GetBucketAcl","Resource":"*"}]}`,
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := globalAcceleratorFlowLogsDeliveryGranted(testCase.policy)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
 func TestAccAwsGlobalAcceleratorAccelerator_basic(t *testing.T) {
 	resourceName := "aws_globalaccelerator_accelerator.example"
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -317,6 +502,41 @@ func TestAccAwsGlobalAcceleratorAccelerator_tags(t *testing.T) {
 	})
 }
 
+func TestAccAwsGlobalAcceleratorAccelerator_shieldProtection(t *testing.T) {
+	resourceName := "aws_globalaccelerator_accelerator.example"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t); testAccPreCheckAWSShield(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorAcceleratorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorAccelerator_shieldProtection(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorAcceleratorExists(resourceName),
+					testAccCheckGlobalAcceleratorAcceleratorShieldProtection(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "enable_shield_protection", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"enable_shield_protection"},
+			},
+			{
+				Config: testAccGlobalAcceleratorAccelerator_shieldProtection(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorAcceleratorExists(resourceName),
+					testAccCheckGlobalAcceleratorAcceleratorShieldProtection(resourceName, false),
+					resource.TestCheckResourceAttr(resourceName, "enable_shield_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccPreCheckGlobalAccelerator(t *testing.T) {
 	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
 
@@ -359,6 +579,28 @@ func testAccCheckGlobalAcceleratorAcceleratorExists(name string) resource.TestCh
 	}
 }
 
+func testAccCheckGlobalAcceleratorAcceleratorShieldProtection(name string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).shieldconn
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		protection, err := resourceAwsGlobalAcceleratorAcceleratorRetrieveShieldProtection(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if exists := protection != nil; exists != expected {
+			return fmt.Errorf("Global Accelerator accelerator (%s) Shield protection exists: %t, expected: %t", rs.Primary.ID, exists, expected)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckGlobalAcceleratorAcceleratorDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
 
@@ -424,3 +666,19 @@ resource "aws_globalaccelerator_accelerator" "example" {
 }
 `, rName, enabled, tagKey, tagValue)
 }
+
+func testAccGlobalAcceleratorAccelerator_shieldProtection(rName string, enableShieldProtection bool) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "example" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+
+  enable_shield_protection = %[2]t
+}
+
+output "accelerator_arn" {
+  value = aws_globalaccelerator_accelerator.example.id
+}
+`, rName, enableShieldProtection)
+}
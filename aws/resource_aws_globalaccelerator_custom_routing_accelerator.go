@@ -0,0 +1,338 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/waiter"
+)
+
+func resourceAwsGlobalAcceleratorCustomRoutingAccelerator() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsGlobalAcceleratorCustomRoutingAcceleratorCreate,
+		Read:   resourceAwsGlobalAcceleratorCustomRoutingAcceleratorRead,
+		Update: resourceAwsGlobalAcceleratorCustomRoutingAcceleratorUpdate,
+		Delete: resourceAwsGlobalAcceleratorCustomRoutingAcceleratorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ip_address_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  globalaccelerator.IpAddressTypeIpv4,
+				ValidateFunc: validation.StringInSlice([]string{
+					globalaccelerator.IpAddressTypeIpv4,
+				}, false),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hosted_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ip_sets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_addresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ip_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					if old == "1" && new == "0" {
+						return true
+					}
+					return false
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"flow_logs_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"flow_logs_s3_bucket": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"flow_logs_s3_prefix": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile(`^/`), "must not start with \"/\""),
+						},
+					},
+				},
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	if v := d.Get("attributes").([]interface{}); len(v) > 0 && v[0] != nil {
+		if err := resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket(meta, v[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	opts := &globalaccelerator.CreateCustomRoutingAcceleratorInput{
+		Name:             aws.String(d.Get("name").(string)),
+		IdempotencyToken: aws.String(resource.UniqueId()),
+		Enabled:          aws.Bool(d.Get("enabled").(bool)),
+		Tags:             keyvaluetags.New(d.Get("tags").(map[string]interface{})).IgnoreAws().GlobalacceleratorTags(),
+	}
+
+	if v, ok := d.GetOk("ip_address_type"); ok {
+		opts.IpAddressType = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Create Global Accelerator custom routing accelerator: %s", opts)
+
+	resp, err := conn.CreateCustomRoutingAccelerator(opts)
+	if err != nil {
+		return fmt.Errorf("error creating Global Accelerator custom routing accelerator: %w", err)
+	}
+
+	d.SetId(aws.StringValue(resp.Accelerator.AcceleratorArn))
+
+	if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, d.Id()); err != nil {
+		return err
+	}
+
+	if v := d.Get("attributes").([]interface{}); len(v) > 0 {
+		if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorUpdateAttributes(conn, d.Id(), v[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingAcceleratorRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	accelerator, err := finder.CustomRoutingAcceleratorByARN(conn, d.Id())
+
+	if isAWSErr(err, globalaccelerator.ErrCodeAcceleratorNotFoundException, "") {
+		log.Printf("[WARN] Global Accelerator custom routing accelerator (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator custom routing accelerator (%s): %w", d.Id(), err)
+	}
+
+	if accelerator == nil {
+		log.Printf("[WARN] Global Accelerator custom routing accelerator (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", accelerator.Name)
+	d.Set("ip_address_type", accelerator.IpAddressType)
+	d.Set("enabled", accelerator.Enabled)
+	d.Set("dns_name", accelerator.DnsName)
+	d.Set("hosted_zone_id", globalAcceleratorRoute53ZoneID)
+	if err := d.Set("ip_sets", resourceAwsGlobalAcceleratorAcceleratorFlattenIpSets(accelerator.IpSets)); err != nil {
+		return fmt.Errorf("error setting ip_sets: %w", err)
+	}
+
+	resp, err := conn.DescribeCustomRoutingAcceleratorAttributes(&globalaccelerator.DescribeCustomRoutingAcceleratorAttributesInput{
+		AcceleratorArn: aws.String(d.Id()),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator custom routing accelerator (%s) attributes: %w", d.Id(), err)
+	}
+
+	if err := d.Set("attributes", resourceAwsGlobalAcceleratorCustomRoutingAcceleratorFlattenAttributes(resp.AcceleratorAttributes)); err != nil {
+		return fmt.Errorf("error setting attributes: %w", err)
+	}
+
+	tags, err := keyvaluetags.GlobalacceleratorListTags(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("error listing tags for Global Accelerator custom routing accelerator (%s): %w", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorFlattenAttributes(attributes *globalaccelerator.CustomRoutingAcceleratorAttributes) []interface{} {
+	if attributes == nil {
+		return nil
+	}
+
+	out := make([]interface{}, 1)
+	m := make(map[string]interface{})
+	m["flow_logs_enabled"] = aws.BoolValue(attributes.FlowLogsEnabled)
+	m["flow_logs_s3_bucket"] = aws.StringValue(attributes.FlowLogsS3Bucket)
+	m["flow_logs_s3_prefix"] = aws.StringValue(attributes.FlowLogsS3Prefix)
+	out[0] = m
+
+	return out
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	if d.HasChanges("name", "ip_address_type", "enabled") {
+		opts := &globalaccelerator.UpdateCustomRoutingAcceleratorInput{
+			AcceleratorArn: aws.String(d.Id()),
+			Name:           aws.String(d.Get("name").(string)),
+			Enabled:        aws.Bool(d.Get("enabled").(bool)),
+		}
+
+		if v, ok := d.GetOk("ip_address_type"); ok {
+			opts.IpAddressType = aws.String(v.(string))
+		}
+
+		log.Printf("[DEBUG] Update Global Accelerator custom routing accelerator: %s", opts)
+
+		if _, err := conn.UpdateCustomRoutingAccelerator(opts); err != nil {
+			return fmt.Errorf("error updating Global Accelerator custom routing accelerator (%s): %w", d.Id(), err)
+		}
+
+		if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, d.Id()); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("attributes") {
+		if v := d.Get("attributes").([]interface{}); len(v) > 0 {
+			if err := resourceAwsGlobalAcceleratorAcceleratorCheckFlowLogsBucket(meta, v[0].(map[string]interface{})); err != nil {
+				return err
+			}
+
+			if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorUpdateAttributes(conn, d.Id(), v[0].(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.GlobalacceleratorUpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating Global Accelerator custom routing accelerator (%s) tags: %w", d.Id(), err)
+		}
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingAcceleratorRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn *globalaccelerator.GlobalAccelerator, acceleratorArn string) error {
+	log.Printf("[DEBUG] Waiting for Global Accelerator custom routing accelerator (%s) availability", acceleratorArn)
+
+	if _, err := waiter.CustomRoutingAcceleratorDeployed(conn, acceleratorArn, 10*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Global Accelerator custom routing accelerator (%s) availability: %w", acceleratorArn, err)
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorUpdateAttributes(conn *globalaccelerator.GlobalAccelerator, acceleratorArn string, attributes map[string]interface{}) error {
+	opts := &globalaccelerator.UpdateCustomRoutingAcceleratorAttributesInput{
+		AcceleratorArn:  aws.String(acceleratorArn),
+		FlowLogsEnabled: aws.Bool(attributes["flow_logs_enabled"].(bool)),
+	}
+
+	if v := attributes["flow_logs_s3_bucket"]; v != nil {
+		opts.FlowLogsS3Bucket = aws.String(v.(string))
+	}
+
+	if v := attributes["flow_logs_s3_prefix"]; v != nil {
+		opts.FlowLogsS3Prefix = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Update Global Accelerator custom routing accelerator attributes: %s", opts)
+
+	if _, err := conn.UpdateCustomRoutingAcceleratorAttributes(opts); err != nil {
+		return fmt.Errorf("error updating Global Accelerator custom routing accelerator (%s) attributes: %w", acceleratorArn, err)
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingAcceleratorDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	// Accelerators must be disabled before they can be deleted.
+	opts := &globalaccelerator.UpdateCustomRoutingAcceleratorInput{
+		AcceleratorArn: aws.String(d.Id()),
+		Enabled:        aws.Bool(false),
+	}
+
+	log.Printf("[DEBUG] Disabling Global Accelerator custom routing accelerator: %s", opts)
+
+	_, err := conn.UpdateCustomRoutingAccelerator(opts)
+
+	if isAWSErr(err, globalaccelerator.ErrCodeAcceleratorNotFoundException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error disabling Global Accelerator custom routing accelerator (%s): %w", d.Id(), err)
+	}
+
+	if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, d.Id()); err != nil {
+		return err
+	}
+
+	_, err = conn.DeleteCustomRoutingAccelerator(&globalaccelerator.DeleteCustomRoutingAcceleratorInput{
+		AcceleratorArn: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, globalaccelerator.ErrCodeAcceleratorNotFoundException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Global Accelerator custom routing accelerator (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func init() {
+	resource.AddTestSweepers("aws_globalaccelerator_custom_routing_accelerator", &resource.Sweeper{
+		Name: "aws_globalaccelerator_custom_routing_accelerator",
+		F:    testSweepGlobalAcceleratorCustomRoutingAccelerators,
+	})
+}
+
+func testSweepGlobalAcceleratorCustomRoutingAccelerators(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("Error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).globalacceleratorconn
+
+	input := &globalaccelerator.ListCustomRoutingAcceleratorsInput{}
+	var sweeperErrs *multierror.Error
+
+	for {
+		output, err := conn.ListCustomRoutingAccelerators(input)
+
+		if testSweepSkipSweepError(err) {
+			log.Printf("[WARN] Skipping Global Accelerator Custom Routing Accelerator sweep for %s: %s", region, err)
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("Error retrieving Global Accelerator Custom Routing Accelerators: %s", err)
+		}
+
+		for _, accelerator := range output.Accelerators {
+			arn := aws.StringValue(accelerator.AcceleratorArn)
+
+			errs := sweepGlobalAcceleratorCustomRoutingListeners(conn, accelerator.AcceleratorArn)
+			if errs != nil {
+				sweeperErrs = multierror.Append(sweeperErrs, errs)
+			}
+
+			if aws.BoolValue(accelerator.Enabled) {
+				input := &globalaccelerator.UpdateCustomRoutingAcceleratorInput{
+					AcceleratorArn: accelerator.AcceleratorArn,
+					Enabled:        aws.Bool(false),
+				}
+
+				log.Printf("[INFO] Disabling Global Accelerator Custom Routing Accelerator: %s", arn)
+
+				_, err := conn.UpdateCustomRoutingAccelerator(input)
+
+				if err != nil {
+					sweeperErr := fmt.Errorf("error disabling Global Accelerator Custom Routing Accelerator (%s): %s", arn, err)
+					log.Printf("[ERROR] %s", sweeperErr)
+					sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+					continue
+				}
+			}
+
+			// Removing listeners or disabling can both set the state to `IN_PROGRESS`.
+			if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, arn); err != nil {
+				sweeperErr := fmt.Errorf("error waiting for Global Accelerator Custom Routing Accelerator (%s): %s", arn, err)
+				log.Printf("[ERROR] %s", sweeperErr)
+				sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+				continue
+			}
+
+			input := &globalaccelerator.DeleteCustomRoutingAcceleratorInput{
+				AcceleratorArn: accelerator.AcceleratorArn,
+			}
+
+			log.Printf("[INFO] Deleting Global Accelerator Custom Routing Accelerator: %s", arn)
+			_, err := conn.DeleteCustomRoutingAccelerator(input)
+
+			if err != nil {
+				sweeperErr := fmt.Errorf("error deleting Global Accelerator Custom Routing Accelerator (%s): %s", arn, err)
+				log.Printf("[ERROR] %s", sweeperErr)
+				sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+				continue
+			}
+		}
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+func sweepGlobalAcceleratorCustomRoutingListeners(conn *globalaccelerator.GlobalAccelerator, acceleratorArn *string) *multierror.Error {
+	var sweeperErrs *multierror.Error
+
+	log.Printf("[INFO] deleting Custom Routing Listeners for Accelerator %s", *acceleratorArn)
+	listenersInput := &globalaccelerator.ListCustomRoutingListenersInput{
+		AcceleratorArn: acceleratorArn,
+	}
+	listenersOutput, err := conn.ListCustomRoutingListeners(listenersInput)
+	if err != nil {
+		sweeperErr := fmt.Errorf("error listing Global Accelerator Custom Routing Listeners for Accelerator (%s): %s", *acceleratorArn, err)
+		log.Printf("[ERROR] %s", sweeperErr)
+		return multierror.Append(sweeperErrs, sweeperErr)
+	}
+
+	for _, listener := range listenersOutput.Listeners {
+		errs := sweepGlobalAcceleratorCustomRoutingEndpointGroups(conn, listener.ListenerArn)
+		if errs != nil {
+			sweeperErrs = multierror.Append(sweeperErrs, errs)
+		}
+
+		input := &globalaccelerator.DeleteCustomRoutingListenerInput{
+			ListenerArn: listener.ListenerArn,
+		}
+		_, err := conn.DeleteCustomRoutingListener(input)
+
+		if err != nil {
+			sweeperErr := fmt.Errorf("error deleting Global Accelerator Custom Routing Listener (%s): %s", *listener.ListenerArn, err)
+			log.Printf("[ERROR] %s", sweeperErr)
+			sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+			continue
+		}
+	}
+
+	return sweeperErrs
+}
+
+func sweepGlobalAcceleratorCustomRoutingEndpointGroups(conn *globalaccelerator.GlobalAccelerator, listenerArn *string) *multierror.Error {
+	var sweeperErrs *multierror.Error
+
+	log.Printf("[INFO] deleting Custom Routing Endpoint Groups for Listener %s", *listenerArn)
+	input := &globalaccelerator.ListCustomRoutingEndpointGroupsInput{
+		ListenerArn: listenerArn,
+	}
+	output, err := conn.ListCustomRoutingEndpointGroups(input)
+	if err != nil {
+		sweeperErr := fmt.Errorf("error listing Global Accelerator Custom Routing Endpoint Groups for Listener (%s): %s", *listenerArn, err)
+		log.Printf("[ERROR] %s", sweeperErr)
+		return multierror.Append(sweeperErrs, sweeperErr)
+	}
+
+	for _, endpointGroup := range output.EndpointGroups {
+		input := &globalaccelerator.DeleteCustomRoutingEndpointGroupInput{
+			EndpointGroupArn: endpointGroup.EndpointGroupArn,
+		}
+		_, err := conn.DeleteCustomRoutingEndpointGroup(input)
+
+		if err != nil {
+			sweeperErr := fmt.Errorf("error deleting Global Accelerator Custom Routing Endpoint Group (%s): %s", *endpointGroup.EndpointGroupArn, err)
+			log.Printf("[ERROR] %s", sweeperErr)
+			sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+			continue
+		}
+	}
+
+	return sweeperErrs
+}
+
+func TestAccAwsGlobalAcceleratorCustomRoutingAccelerator_basic(t *testing.T) {
+	resourceName := "aws_globalaccelerator_custom_routing_accelerator.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	ipRegex := regexp.MustCompile(`\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}`)
+	dnsNameRegex := regexp.MustCompile(`^a[a-f0-9]{16}\.awsglobalaccelerator\.com$`)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorCustomRoutingAcceleratorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingAcceleratorConfig(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "ip_address_type", "IPV4"),
+					resource.TestCheckResourceAttr(resourceName, "attributes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "attributes.0.flow_logs_enabled", "false"),
+					resource.TestMatchResourceAttr(resourceName, "dns_name", dnsNameRegex),
+					resource.TestCheckResourceAttr(resourceName, "hosted_zone_id", "Z2BJ6XQ5FK7U4H"),
+					resource.TestCheckResourceAttr(resourceName, "ip_sets.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ip_sets.0.ip_addresses.#", "2"),
+					resource.TestMatchResourceAttr(resourceName, "ip_sets.0.ip_addresses.0", ipRegex),
+					resource.TestCheckResourceAttr(resourceName, "ip_sets.0.ip_family", "IPv4"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorCustomRoutingAccelerator_update(t *testing.T) {
+	resourceName := "aws_globalaccelerator_custom_routing_accelerator.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	newName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorCustomRoutingAcceleratorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingAcceleratorConfig(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(resourceName),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingAcceleratorConfig(newName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", newName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorCustomRoutingAccelerator_tags(t *testing.T) {
+	resourceName := "aws_globalaccelerator_custom_routing_accelerator.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorCustomRoutingAcceleratorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingAcceleratorConfigTags1(rName, "key1", "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingAcceleratorConfigTags1(rName, "key1", "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGlobalAcceleratorCustomRoutingAcceleratorExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		accelerator, err := finder.CustomRoutingAcceleratorByARN(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if accelerator == nil {
+			return fmt.Errorf("Global Accelerator custom routing accelerator not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGlobalAcceleratorCustomRoutingAcceleratorDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_globalaccelerator_custom_routing_accelerator" {
+			continue
+		}
+
+		accelerator, err := finder.CustomRoutingAcceleratorByARN(conn, rs.Primary.ID)
+
+		if isAWSErr(err, globalaccelerator.ErrCodeAcceleratorNotFoundException, "") {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if accelerator != nil {
+			return fmt.Errorf("Global Accelerator custom routing accelerator still exists")
+		}
+	}
+	return nil
+}
+
+func testAccGlobalAcceleratorCustomRoutingAcceleratorConfig(rName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_custom_routing_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = %[2]t
+}
+`, rName, enabled)
+}
+
+func testAccGlobalAcceleratorCustomRoutingAcceleratorConfigTags1(rName, tagKey1, tagValue1 string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_custom_routing_accelerator" "test" {
+  name    = %[1]q
+  enabled = false
+
+  tags = {
+    %[2]q = %[3]q
+  }
+}
+`, rName, tagKey1, tagValue1)
+}
@@ -0,0 +1,359 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupCreate,
+		Read:   resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRead,
+		Update: resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupUpdate,
+		Delete: resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("allow_cross_account", false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		CustomizeDiff: resourceAwsGlobalAcceleratorArnOwnershipCustomizeDiff("listener_arn"),
+
+		Schema: map[string]*schema.Schema{
+			"allow_cross_account": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Custom routing endpoint groups cannot change their destinations in place.
+			"destination_configuration": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from_port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+
+						"protocols": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							MinItems: 1,
+							MaxItems: 2,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									globalaccelerator.CustomRoutingProtocolTcp,
+									globalaccelerator.CustomRoutingProtocolUdp,
+								}, false),
+							},
+						},
+
+						"to_port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+
+			"endpoint_configuration": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 255),
+						},
+					},
+				},
+			},
+
+			"endpoint_group_region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+			},
+
+			"listener_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+	region := meta.(*AWSClient).region
+
+	opts := &globalaccelerator.CreateCustomRoutingEndpointGroupInput{
+		DestinationConfigurations: expandGlobalAcceleratorCustomRoutingDestinationConfigurations(d.Get("destination_configuration").(*schema.Set).List()),
+		EndpointGroupRegion:       aws.String(region),
+		IdempotencyToken:          aws.String(resource.UniqueId()),
+		ListenerArn:               aws.String(d.Get("listener_arn").(string)),
+	}
+
+	if v, ok := d.GetOk("endpoint_group_region"); ok {
+		opts.EndpointGroupRegion = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Create Global Accelerator custom routing endpoint group: %s", opts)
+
+	resp, err := conn.CreateCustomRoutingEndpointGroup(opts)
+
+	if err != nil {
+		return fmt.Errorf("error creating Global Accelerator custom routing endpoint group: %w", err)
+	}
+
+	d.SetId(aws.StringValue(resp.EndpointGroup.EndpointGroupArn))
+
+	acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+		return err
+	}
+
+	// Endpoints are managed separately from the endpoint group's destinations.
+	if v, ok := d.GetOk("endpoint_configuration"); ok && v.(*schema.Set).Len() > 0 {
+		if err := resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupAddEndpoints(conn, d.Id(), acceleratorArn, v.(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	endpointGroup, err := finder.CustomRoutingEndpointGroupByARN(conn, d.Id())
+
+	if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+		log.Printf("[WARN] Global Accelerator custom routing endpoint group (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator custom routing endpoint group (%s): %w", d.Id(), err)
+	}
+
+	if endpointGroup == nil {
+		log.Printf("[WARN] Global Accelerator custom routing endpoint group (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	listenerArn, err := resourceAwsGlobalAcceleratorEndpointGroupParseListenerArn(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("arn", endpointGroup.EndpointGroupArn)
+	if err := d.Set("destination_configuration", flattenGlobalAcceleratorCustomRoutingDestinationDescriptions(endpointGroup.DestinationDescriptions)); err != nil {
+		return fmt.Errorf("error setting destination_configuration: %w", err)
+	}
+	if err := d.Set("endpoint_configuration", flattenGlobalAcceleratorCustomRoutingEndpointDescriptions(endpointGroup.EndpointDescriptions)); err != nil {
+		return fmt.Errorf("error setting endpoint_configuration: %w", err)
+	}
+	d.Set("endpoint_group_region", endpointGroup.EndpointGroupRegion)
+	d.Set("listener_arn", listenerArn)
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	if d.HasChange("endpoint_configuration") {
+		acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(d.Id())
+
+		if err != nil {
+			return err
+		}
+
+		o, n := d.GetChange("endpoint_configuration")
+		os, ns := o.(*schema.Set), n.(*schema.Set)
+
+		if del := os.Difference(ns).List(); len(del) > 0 {
+			if err := resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRemoveEndpoints(conn, d.Id(), acceleratorArn, del); err != nil {
+				return err
+			}
+		}
+
+		if add := ns.Difference(os).List(); len(add) > 0 {
+			if err := resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupAddEndpoints(conn, d.Id(), acceleratorArn, add); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	_, err := conn.DeleteCustomRoutingEndpointGroup(&globalaccelerator.DeleteCustomRoutingEndpointGroupInput{
+		EndpointGroupArn: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Global Accelerator custom routing endpoint group (%s): %w", d.Id(), err)
+	}
+
+	acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if err := resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupAddEndpoints(conn *globalaccelerator.GlobalAccelerator, endpointGroupArn, acceleratorArn string, configurations []interface{}) error {
+	opts := &globalaccelerator.AddCustomRoutingEndpointsInput{
+		EndpointConfigurations: expandGlobalAcceleratorCustomRoutingEndpointConfigurations(configurations),
+		EndpointGroupArn:       aws.String(endpointGroupArn),
+	}
+
+	log.Printf("[DEBUG] Add Global Accelerator custom routing endpoints: %s", opts)
+
+	if _, err := conn.AddCustomRoutingEndpoints(opts); err != nil {
+		return fmt.Errorf("error adding Global Accelerator custom routing endpoint group (%s) endpoints: %w", endpointGroupArn, err)
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, acceleratorArn)
+}
+
+func resourceAwsGlobalAcceleratorCustomRoutingEndpointGroupRemoveEndpoints(conn *globalaccelerator.GlobalAccelerator, endpointGroupArn, acceleratorArn string, configurations []interface{}) error {
+	opts := &globalaccelerator.RemoveCustomRoutingEndpointsInput{
+		EndpointGroupArn: aws.String(endpointGroupArn),
+	}
+
+	for _, raw := range configurations {
+		opts.EndpointIds = append(opts.EndpointIds, aws.String(raw.(map[string]interface{})["endpoint_id"].(string)))
+	}
+
+	log.Printf("[DEBUG] Remove Global Accelerator custom routing endpoints: %s", opts)
+
+	_, err := conn.RemoveCustomRoutingEndpoints(opts)
+
+	if isAWSErr(err, globalaccelerator.ErrCodeEndpointNotFoundException, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error removing Global Accelerator custom routing endpoint group (%s) endpoints: %w", endpointGroupArn, err)
+	}
+
+	return resourceAwsGlobalAcceleratorCustomRoutingAcceleratorWaitForDeployedState(conn, acceleratorArn)
+}
+
+func expandGlobalAcceleratorCustomRoutingDestinationConfigurations(configurations []interface{}) []*globalaccelerator.CustomRoutingDestinationConfiguration {
+	out := make([]*globalaccelerator.CustomRoutingDestinationConfiguration, len(configurations))
+
+	for i, raw := range configurations {
+		configuration := raw.(map[string]interface{})
+
+		out[i] = &globalaccelerator.CustomRoutingDestinationConfiguration{
+			FromPort:  aws.Int64(int64(configuration["from_port"].(int))),
+			Protocols: expandStringSet(configuration["protocols"].(*schema.Set)),
+			ToPort:    aws.Int64(int64(configuration["to_port"].(int))),
+		}
+	}
+
+	return out
+}
+
+func expandGlobalAcceleratorCustomRoutingEndpointConfigurations(configurations []interface{}) []*globalaccelerator.CustomRoutingEndpointConfiguration {
+	out := make([]*globalaccelerator.CustomRoutingEndpointConfiguration, len(configurations))
+
+	for i, raw := range configurations {
+		configuration := raw.(map[string]interface{})
+
+		out[i] = &globalaccelerator.CustomRoutingEndpointConfiguration{
+			EndpointId: aws.String(configuration["endpoint_id"].(string)),
+		}
+	}
+
+	return out
+}
+
+func flattenGlobalAcceleratorCustomRoutingDestinationDescriptions(descriptions []*globalaccelerator.CustomRoutingDestinationDescription) []interface{} {
+	out := make([]interface{}, 0, len(descriptions))
+
+	for _, description := range descriptions {
+		if description == nil {
+			continue
+		}
+
+		out = append(out, map[string]interface{}{
+			"from_port": aws.Int64Value(description.FromPort),
+			"protocols": flattenStringSet(description.Protocols),
+			"to_port":   aws.Int64Value(description.ToPort),
+		})
+	}
+
+	return out
+}
+
+func flattenGlobalAcceleratorCustomRoutingEndpointDescriptions(descriptions []*globalaccelerator.CustomRoutingEndpointDescription) []interface{} {
+	out := make([]interface{}, 0, len(descriptions))
+
+	for _, description := range descriptions {
+		if description == nil {
+			continue
+		}
+
+		out = append(out, map[string]interface{}{
+			"endpoint_id": aws.StringValue(description.EndpointId),
+		})
+	}
+
+	return out
+}
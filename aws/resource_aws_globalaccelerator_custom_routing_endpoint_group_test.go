@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func TestAccAwsGlobalAcceleratorCustomRoutingEndpointGroup_basic(t *testing.T) {
+	resourceName := "aws_globalaccelerator_custom_routing_endpoint_group.test"
+	listenerResourceName := "aws_globalaccelerator_custom_routing_listener.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingEndpointGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupExists(resourceName),
+					testAccMatchResourceAttrGlobalARN(resourceName, "arn", "globalaccelerator", regexp.MustCompile(`accelerator/[^/]+/listener/[^/]+/endpoint-group/[^/]+`)),
+					resource.TestCheckResourceAttr(resourceName, "destination_configuration.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "destination_configuration.*", map[string]string{
+						"from_port":   "443",
+						"to_port":     "8443",
+						"protocols.#": "1",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_group_region", testAccGetRegion()),
+					resource.TestCheckResourceAttrPair(resourceName, "listener_arn", listenerResourceName, "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorCustomRoutingEndpointGroup_EndpointConfiguration(t *testing.T) {
+	resourceName := "aws_globalaccelerator_custom_routing_endpoint_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigEndpointConfiguration(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "endpoint_configuration.*.endpoint_id", "aws_subnet.test.0", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigEndpointConfiguration(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "endpoint_configuration.*.endpoint_id", "aws_subnet.test.1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		endpointGroup, err := finder.CustomRoutingEndpointGroupByARN(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if endpointGroup == nil {
+			return fmt.Errorf("Global Accelerator custom routing endpoint group not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGlobalAcceleratorCustomRoutingEndpointGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_globalaccelerator_custom_routing_endpoint_group" {
+			continue
+		}
+
+		endpointGroup, err := finder.CustomRoutingEndpointGroupByARN(conn, rs.Primary.ID)
+
+		if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if endpointGroup != nil {
+			return fmt.Errorf("Global Accelerator custom routing endpoint group still exists")
+		}
+	}
+	return nil
+}
+
+func testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigBase(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_custom_routing_accelerator" "test" {
+  name    = %[1]q
+  enabled = false
+}
+
+resource "aws_globalaccelerator_custom_routing_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_custom_routing_accelerator.test.id
+
+  port_range {
+    from_port = 5000
+    to_port   = 6000
+  }
+}
+`, rName)
+}
+
+func testAccGlobalAcceleratorCustomRoutingEndpointGroupConfig(rName string) string {
+	return composeConfig(
+		testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigBase(rName),
+		`
+resource "aws_globalaccelerator_custom_routing_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_custom_routing_listener.test.id
+
+  destination_configuration {
+    from_port = 443
+    to_port   = 8443
+    protocols = ["TCP"]
+  }
+}
+`)
+}
+
+func testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigEndpointConfiguration(rName string, subnetIndex int) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		testAccGlobalAcceleratorCustomRoutingEndpointGroupConfigBase(rName),
+		fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  count = 2
+
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = cidrsubnet(aws_vpc.test.cidr_block, 8, count.index)
+  availability_zone = data.aws_availability_zones.available.names[count.index]
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_globalaccelerator_custom_routing_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_custom_routing_listener.test.id
+
+  destination_configuration {
+    from_port = 80
+    to_port   = 8080
+    protocols = ["TCP", "UDP"]
+  }
+
+  endpoint_configuration {
+    endpoint_id = aws_subnet.test[%[2]d].id
+  }
+}
+`, rName, subnetIndex))
+}
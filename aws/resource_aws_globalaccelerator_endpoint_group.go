@@ -1,16 +1,26 @@
 package aws
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/hashcode"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/waiter"
 )
 
 func resourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
@@ -21,19 +31,57 @@ func resourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
 		Delete: resourceAwsGlobalAcceleratorEndpointGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("allow_cross_account", false)
+				d.Set("delete_managed_security_group", false)
+				d.Set("wait_for_endpoint_health", false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsGlobalAcceleratorArnOwnershipCustomizeDiff("listener_arn"),
+			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				if !diff.NewValueKnown("port_override") {
+					return nil
+				}
+
+				return validateGlobalAcceleratorPortOverrides(diff.Get("port_override").(*schema.Set).List())
+			},
+		),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
+			"allow_cross_account": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"arn": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"delete_managed_security_group": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Endpoints may instead be managed by aws_globalaccelerator_endpoint_group_attachment
+			// resources, so endpoints are left untouched when none are configured.
 			"endpoint_configuration": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				MaxItems: 10,
+				Set:      resourceAwsGlobalAcceleratorEndpointConfigurationHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"client_ip_preservation_enabled": {
@@ -48,6 +96,11 @@ func resourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
 							ValidateFunc: validation.StringLenBetween(1, 255),
 						},
 
+						"health_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
 						"weight": {
 							Type:         schema.TypeInt,
 							Optional:     true,
@@ -86,10 +139,12 @@ func resourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
 				ValidateFunc: validation.IsPortNumber,
 			},
 
+			// The API defaults the health check protocol to TCP and the port to the
+			// first listener port, so both are computed when not configured.
 			"health_check_protocol": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Default:      globalaccelerator.HealthCheckProtocolTcp,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice(globalaccelerator.HealthCheckProtocol_Values(), false),
 			},
 
@@ -134,6 +189,12 @@ func resourceAwsGlobalAcceleratorEndpointGroup() *schema.Resource {
 				Default:      100.0,
 				ValidateFunc: validation.FloatBetween(0.0, 100.0),
 			},
+
+			"wait_for_endpoint_health": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -160,7 +221,7 @@ func resourceAwsGlobalAcceleratorEndpointGroupCreate(d *schema.ResourceData, met
 		opts.HealthCheckIntervalSeconds = aws.Int64(int64(v.(int)))
 	}
 
-	if v, ok := d.GetOk("health_check_path"); ok {
+	if v, ok := d.GetOk("health_check_path"); ok && globalAcceleratorHealthCheckPathApplies(d.Get("health_check_protocol").(string)) {
 		opts.HealthCheckPath = aws.String(v.(string))
 	}
 
@@ -187,6 +248,33 @@ func resourceAwsGlobalAcceleratorEndpointGroupCreate(d *schema.ResourceData, met
 	log.Printf("[DEBUG] Create Global Accelerator endpoint group: %s", opts)
 
 	resp, err := conn.CreateEndpointGroup(opts)
+
+	// A create that timed out client-side may have succeeded server-side.
+	// Adopt the endpoint group already created for this listener and region.
+	if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupAlreadyExistsException, "") {
+		endpointGroups, findErr := finder.EndpointGroupsByListenerARN(conn, aws.StringValue(opts.ListenerArn))
+
+		if findErr != nil {
+			return fmt.Errorf("error creating Global Accelerator endpoint group: %s: error listing existing endpoint groups: %w", err, findErr)
+		}
+
+		endpointGroup := resourceAwsGlobalAcceleratorEndpointGroupForRegion(endpointGroups, aws.StringValue(opts.EndpointGroupRegion))
+
+		if endpointGroup == nil {
+			return fmt.Errorf("error creating Global Accelerator endpoint group: %w", err)
+		}
+
+		// Only a group created by an earlier attempt of this request is adopted.
+		// A differently configured group belongs to some other configuration.
+		if !resourceAwsGlobalAcceleratorEndpointGroupMatchesCreateInput(endpointGroup, opts) {
+			return fmt.Errorf("error creating Global Accelerator endpoint group: %w", err)
+		}
+
+		log.Printf("[WARN] Global Accelerator endpoint group for listener (%s) and region (%s) already exists, adopting: %s", aws.StringValue(opts.ListenerArn), aws.StringValue(opts.EndpointGroupRegion), aws.StringValue(endpointGroup.EndpointGroupArn))
+
+		resp, err = &globalaccelerator.CreateEndpointGroupOutput{EndpointGroup: endpointGroup}, nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("error creating Global Accelerator endpoint group: %w", err)
 	}
@@ -205,6 +293,12 @@ func resourceAwsGlobalAcceleratorEndpointGroupCreate(d *schema.ResourceData, met
 		return err
 	}
 
+	if d.Get("wait_for_endpoint_health").(bool) {
+		if _, err := waiter.EndpointGroupHealthy(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("error waiting for Global Accelerator endpoint group (%s) endpoints to become healthy: %w", d.Id(), err)
+		}
+	}
+
 	return resourceAwsGlobalAcceleratorEndpointGroupRead(d, meta)
 }
 
@@ -213,7 +307,7 @@ func resourceAwsGlobalAcceleratorEndpointGroupRead(d *schema.ResourceData, meta
 
 	endpointGroup, err := finder.EndpointGroupByARN(conn, d.Id())
 
-	if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+	if isGlobalAcceleratorEndpointGroupNotFoundError(err) {
 		log.Printf("[WARN] Global Accelerator endpoint group (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -257,21 +351,23 @@ func resourceAwsGlobalAcceleratorEndpointGroupRead(d *schema.ResourceData, meta
 func resourceAwsGlobalAcceleratorEndpointGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).globalacceleratorconn
 
+	// Serialize with aws_globalaccelerator_endpoint_group_attachment resources.
+	awsMutexKV.Lock(d.Id())
+	defer awsMutexKV.Unlock(d.Id())
+
 	opts := &globalaccelerator.UpdateEndpointGroupInput{
 		EndpointGroupArn: aws.String(d.Id()),
 	}
 
-	if v, ok := d.GetOk("endpoint_configuration"); ok {
-		opts.EndpointConfigurations = expandGlobalAcceleratorEndpointConfigurations(v.(*schema.Set).List())
-	} else {
-		opts.EndpointConfigurations = []*globalaccelerator.EndpointConfiguration{}
+	if d.HasChange("endpoint_configuration") {
+		opts.EndpointConfigurations = expandGlobalAcceleratorEndpointConfigurations(d.Get("endpoint_configuration").(*schema.Set).List())
 	}
 
 	if v, ok := d.GetOk("health_check_interval_seconds"); ok {
 		opts.HealthCheckIntervalSeconds = aws.Int64(int64(v.(int)))
 	}
 
-	if v, ok := d.GetOk("health_check_path"); ok {
+	if v, ok := d.GetOk("health_check_path"); ok && globalAcceleratorHealthCheckPathApplies(d.Get("health_check_protocol").(string)) {
 		opts.HealthCheckPath = aws.String(v.(string))
 	}
 
@@ -297,18 +393,43 @@ func resourceAwsGlobalAcceleratorEndpointGroupUpdate(d *schema.ResourceData, met
 		opts.TrafficDialPercentage = aws.Float64(v)
 	}
 
-	log.Printf("[DEBUG] Update Global Accelerator endpoint group: %s", opts)
-
-	_, err := conn.UpdateEndpointGroup(opts)
+	acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(d.Id())
 
 	if err != nil {
-		return fmt.Errorf("error updating Global Accelerator endpoint group (%s): %w", d.Id(), err)
+		return err
 	}
 
-	acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(d.Id())
+	// Changes to the accelerator or its other listeners and endpoint groups
+	// may still be deploying.
+	if err := resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Update Global Accelerator endpoint group: %s", opts)
+
+	err = resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+		_, err := conn.UpdateEndpointGroup(opts)
+
+		if isAWSErr(err, globalaccelerator.ErrCodeConflictException, "") {
+			if err := resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.UpdateEndpointGroup(opts)
+	}
 
 	if err != nil {
-		return err
+		return fmt.Errorf("error updating Global Accelerator endpoint group (%s): %w", d.Id(), err)
 	}
 
 	err = resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn)
@@ -317,19 +438,50 @@ func resourceAwsGlobalAcceleratorEndpointGroupUpdate(d *schema.ResourceData, met
 		return err
 	}
 
+	if d.Get("wait_for_endpoint_health").(bool) {
+		if _, err := waiter.EndpointGroupHealthy(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for Global Accelerator endpoint group (%s) endpoints to become healthy: %w", d.Id(), err)
+		}
+	}
+
 	return resourceAwsGlobalAcceleratorEndpointGroupRead(d, meta)
 }
 
 func resourceAwsGlobalAcceleratorEndpointGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).globalacceleratorconn
 
+	// Resolve the endpoints' VPCs while the endpoints are still registered.
+	var vpcIDs []string
+	region := d.Get("endpoint_group_region").(string)
+
+	if d.Get("delete_managed_security_group").(bool) {
+		if region != meta.(*AWSClient).region {
+			log.Printf("[WARN] Not deleting Global Accelerator managed security group for endpoint group (%s) in region (%s) outside the provider region", d.Id(), region)
+		} else {
+			var endpointIDs []string
+
+			for _, v := range d.Get("endpoint_configuration").(*schema.Set).List() {
+				if m, ok := v.(map[string]interface{}); ok && m["client_ip_preservation_enabled"].(bool) {
+					endpointIDs = append(endpointIDs, m["endpoint_id"].(string))
+				}
+			}
+
+			var err error
+			vpcIDs, err = globalAcceleratorEndpointVpcIDs(meta, endpointIDs)
+
+			if err != nil {
+				return fmt.Errorf("error reading Global Accelerator endpoint group (%s) endpoint VPCs: %w", d.Id(), err)
+			}
+		}
+	}
+
 	opts := &globalaccelerator.DeleteEndpointGroupInput{
 		EndpointGroupArn: aws.String(d.Id()),
 	}
 
 	_, err := conn.DeleteEndpointGroup(opts)
 
-	if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+	if isGlobalAcceleratorEndpointGroupNotFoundError(err) {
 		return nil
 	}
 
@@ -349,9 +501,392 @@ func resourceAwsGlobalAcceleratorEndpointGroupDelete(d *schema.ResourceData, met
 		return err
 	}
 
+	if len(vpcIDs) == 0 {
+		return nil
+	}
+
+	// The security group is shared by all endpoint groups with endpoints in the VPC.
+	inUseVpcIDs, err := globalAcceleratorManagedSecurityGroupVpcIDsInUse(meta, region, vpcIDs)
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator endpoint VPCs in region (%s): %w", region, err)
+	}
+
+	ec2conn := meta.(*AWSClient).ec2conn
+
+	for _, vpcID := range globalAcceleratorManagedSecurityGroupVpcIDs(vpcIDs, inUseVpcIDs) {
+		if err := deleteGlobalAcceleratorManagedSecurityGroup(ec2conn, vpcID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("error deleting Global Accelerator managed security group in VPC (%s): %w", vpcID, err)
+		}
+	}
+
+	return nil
+}
+
+// globalAcceleratorManagedSecurityGroupName is the name of the security group that Global Accelerator
+// creates in the VPC of endpoints with client IP address preservation and never deletes.
+const globalAcceleratorManagedSecurityGroupName = "GlobalAccelerator"
+
+// globalAcceleratorEndpointVpcIDs returns the unique VPCs of Application Load Balancer, EC2
+// instance and subnet endpoints in the provider region. Other endpoints, and endpoints that
+// no longer exist, are ignored.
+func globalAcceleratorEndpointVpcIDs(meta interface{}, endpointIDs []string) ([]string, error) {
+	var vpcIDs []string
+	seen := make(map[string]bool)
+
+	for _, endpointID := range endpointIDs {
+		var vpcID string
+
+		switch {
+		case strings.HasPrefix(endpointID, "i-"):
+			instance, err := resourceAwsInstanceFindByID(meta.(*AWSClient).ec2conn, endpointID)
+
+			if isAWSErr(err, "InvalidInstanceID.NotFound", "") {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error reading EC2 Instance (%s): %w", endpointID, err)
+			}
+
+			if instance != nil {
+				vpcID = aws.StringValue(instance.VpcId)
+			}
+		case strings.Contains(endpointID, ":loadbalancer/app/"):
+			output, err := meta.(*AWSClient).elbv2conn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+				LoadBalancerArns: aws.StringSlice([]string{endpointID}),
+			})
+
+			if isAWSErr(err, elbv2.ErrCodeLoadBalancerNotFoundException, "") {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error reading ELBv2 Load Balancer (%s): %w", endpointID, err)
+			}
+
+			if output != nil && len(output.LoadBalancers) > 0 && output.LoadBalancers[0] != nil {
+				vpcID = aws.StringValue(output.LoadBalancers[0].VpcId)
+			}
+		case strings.HasPrefix(endpointID, "subnet-"):
+			output, err := meta.(*AWSClient).ec2conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+				SubnetIds: aws.StringSlice([]string{endpointID}),
+			})
+
+			if isAWSErr(err, "InvalidSubnetID.NotFound", "") {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error reading EC2 Subnet (%s): %w", endpointID, err)
+			}
+
+			if output != nil && len(output.Subnets) > 0 && output.Subnets[0] != nil {
+				vpcID = aws.StringValue(output.Subnets[0].VpcId)
+			}
+		}
+
+		if vpcID != "" && !seen[vpcID] {
+			seen[vpcID] = true
+			vpcIDs = append(vpcIDs, vpcID)
+		}
+	}
+
+	return vpcIDs, nil
+}
+
+// globalAcceleratorManagedSecurityGroupVpcIDsInUse returns those of the specified VPCs that
+// contain endpoints of other endpoint groups in the region. Global Accelerator can only list all
+// accelerators, their listeners and then their endpoint groups, so this takes a call per listener
+// and endpoint group of every accelerator in the account, and one per endpoint to resolve its VPC.
+// To limit this, only endpoints that can use the security group are resolved, i.e. standard
+// endpoints with client IP address preservation and custom routing subnets, and the walk stops
+// once all of the VPCs are known to be in use.
+func globalAcceleratorManagedSecurityGroupVpcIDsInUse(meta interface{}, region string, vpcIDs []string) ([]string, error) {
+	conn := meta.(*AWSClient).globalacceleratorconn
+	candidates := make(map[string]bool)
+
+	for _, vpcID := range vpcIDs {
+		candidates[vpcID] = true
+	}
+
+	var inUseVpcIDs []string
+
+	// inUse records the VPCs of the endpoints and returns whether all of the VPCs are in use.
+	inUse := func(endpointIDs []string) (bool, error) {
+		endpointVpcIDs, err := globalAcceleratorEndpointVpcIDs(meta, endpointIDs)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, vpcID := range endpointVpcIDs {
+			if candidates[vpcID] {
+				delete(candidates, vpcID)
+				inUseVpcIDs = append(inUseVpcIDs, vpcID)
+			}
+		}
+
+		return len(candidates) == 0, nil
+	}
+
+	accelerators, err := finder.Accelerators(conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, accelerator := range accelerators {
+		listeners, err := finder.ListenersByAcceleratorARN(conn, aws.StringValue(accelerator.AcceleratorArn))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listener := range listeners {
+			endpointGroups, err := finder.EndpointGroupsByListenerARN(conn, aws.StringValue(listener.ListenerArn))
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, endpointGroup := range endpointGroups {
+				if endpointGroup == nil || aws.StringValue(endpointGroup.EndpointGroupRegion) != region {
+					continue
+				}
+
+				var endpointIDs []string
+
+				for _, endpoint := range endpointGroup.EndpointDescriptions {
+					if endpoint != nil && aws.BoolValue(endpoint.ClientIPPreservationEnabled) {
+						endpointIDs = append(endpointIDs, aws.StringValue(endpoint.EndpointId))
+					}
+				}
+
+				if done, err := inUse(endpointIDs); err != nil || done {
+					return inUseVpcIDs, err
+				}
+			}
+		}
+	}
+
+	customRoutingAccelerators, err := finder.CustomRoutingAccelerators(conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, accelerator := range customRoutingAccelerators {
+		listeners, err := finder.CustomRoutingListenersByAcceleratorARN(conn, aws.StringValue(accelerator.AcceleratorArn))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listener := range listeners {
+			endpointGroups, err := finder.CustomRoutingEndpointGroupsByListenerARN(conn, aws.StringValue(listener.ListenerArn))
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, endpointGroup := range endpointGroups {
+				if endpointGroup == nil || aws.StringValue(endpointGroup.EndpointGroupRegion) != region {
+					continue
+				}
+
+				var endpointIDs []string
+
+				for _, endpoint := range endpointGroup.EndpointDescriptions {
+					if endpoint != nil {
+						endpointIDs = append(endpointIDs, aws.StringValue(endpoint.EndpointId))
+					}
+				}
+
+				if done, err := inUse(endpointIDs); err != nil || done {
+					return inUseVpcIDs, err
+				}
+			}
+		}
+	}
+
+	return inUseVpcIDs, nil
+}
+
+// globalAcceleratorManagedSecurityGroupVpcIDs returns the VPCs whose managed security group can be
+// deleted, i.e. those that no longer contain endpoints of any endpoint group.
+func globalAcceleratorManagedSecurityGroupVpcIDs(vpcIDs, inUseVpcIDs []string) []string {
+	inUse := make(map[string]bool)
+
+	for _, vpcID := range inUseVpcIDs {
+		inUse[vpcID] = true
+	}
+
+	var result []string
+
+	for _, vpcID := range vpcIDs {
+		if !inUse[vpcID] {
+			result = append(result, vpcID)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// deleteGlobalAcceleratorManagedSecurityGroup deletes the managed security group in the VPC, if any,
+// retrying while the network interfaces of the deleted endpoint group are detached.
+func deleteGlobalAcceleratorManagedSecurityGroup(conn *ec2.EC2, vpcID string, timeout time.Duration) error {
+	output, err := conn.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"group-name": globalAcceleratorManagedSecurityGroupName,
+			"vpc-id":     vpcID,
+		}),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading security groups: %w", err)
+	}
+
+	if output == nil || len(output.SecurityGroups) == 0 || output.SecurityGroups[0] == nil {
+		return nil
+	}
+
+	input := &ec2.DeleteSecurityGroupInput{
+		GroupId: output.SecurityGroups[0].GroupId,
+	}
+
+	log.Printf("[DEBUG] Deleting Global Accelerator managed security group: %s", input)
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.DeleteSecurityGroup(input)
+
+		if isAWSErr(err, "DependencyViolation", "") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.DeleteSecurityGroup(input)
+	}
+
+	if isAWSErr(err, tfec2.InvalidGroupNotFound, "") {
+		return nil
+	}
+
+	return err
+}
+
+// resourceAwsGlobalAcceleratorEndpointGroupForRegion returns the endpoint group
+// in the specified region, or nil if there is none. A listener has at most one
+// endpoint group per region.
+func resourceAwsGlobalAcceleratorEndpointGroupForRegion(endpointGroups []*globalaccelerator.EndpointGroup, region string) *globalaccelerator.EndpointGroup {
+	for _, endpointGroup := range endpointGroups {
+		if endpointGroup == nil {
+			continue
+		}
+
+		if aws.StringValue(endpointGroup.EndpointGroupRegion) == region {
+			return endpointGroup
+		}
+	}
+
 	return nil
 }
 
+// resourceAwsGlobalAcceleratorEndpointGroupMatchesCreateInput returns whether an
+// existing endpoint group has the settings requested on create. Settings omitted
+// from the request are left to the API defaults and not compared.
+func resourceAwsGlobalAcceleratorEndpointGroupMatchesCreateInput(endpointGroup *globalaccelerator.EndpointGroup, input *globalaccelerator.CreateEndpointGroupInput) bool {
+	if endpointGroup == nil || input == nil {
+		return false
+	}
+
+	if input.HealthCheckIntervalSeconds != nil && aws.Int64Value(input.HealthCheckIntervalSeconds) != aws.Int64Value(endpointGroup.HealthCheckIntervalSeconds) {
+		return false
+	}
+
+	if input.HealthCheckPath != nil && aws.StringValue(input.HealthCheckPath) != aws.StringValue(endpointGroup.HealthCheckPath) {
+		return false
+	}
+
+	if input.HealthCheckPort != nil && aws.Int64Value(input.HealthCheckPort) != aws.Int64Value(endpointGroup.HealthCheckPort) {
+		return false
+	}
+
+	if input.HealthCheckProtocol != nil && aws.StringValue(input.HealthCheckProtocol) != aws.StringValue(endpointGroup.HealthCheckProtocol) {
+		return false
+	}
+
+	if input.ThresholdCount != nil && aws.Int64Value(input.ThresholdCount) != aws.Int64Value(endpointGroup.ThresholdCount) {
+		return false
+	}
+
+	if input.TrafficDialPercentage != nil && aws.Float64Value(input.TrafficDialPercentage) != aws.Float64Value(endpointGroup.TrafficDialPercentage) {
+		return false
+	}
+
+	if len(input.EndpointConfigurations) != len(endpointGroup.EndpointDescriptions) {
+		return false
+	}
+
+	endpointDescriptions := make(map[string]*globalaccelerator.EndpointDescription, len(endpointGroup.EndpointDescriptions))
+
+	for _, endpointDescription := range endpointGroup.EndpointDescriptions {
+		if endpointDescription == nil {
+			continue
+		}
+
+		endpointDescriptions[aws.StringValue(endpointDescription.EndpointId)] = endpointDescription
+	}
+
+	for _, endpointConfiguration := range input.EndpointConfigurations {
+		endpointDescription, ok := endpointDescriptions[aws.StringValue(endpointConfiguration.EndpointId)]
+
+		if !ok {
+			return false
+		}
+
+		if aws.Int64Value(endpointConfiguration.Weight) != aws.Int64Value(endpointDescription.Weight) {
+			return false
+		}
+
+		if aws.BoolValue(endpointConfiguration.ClientIPPreservationEnabled) != aws.BoolValue(endpointDescription.ClientIPPreservationEnabled) {
+			return false
+		}
+	}
+
+	if len(input.PortOverrides) != len(endpointGroup.PortOverrides) {
+		return false
+	}
+
+	endpointPorts := make(map[int64]int64, len(endpointGroup.PortOverrides))
+
+	for _, portOverride := range endpointGroup.PortOverrides {
+		if portOverride == nil {
+			continue
+		}
+
+		endpointPorts[aws.Int64Value(portOverride.ListenerPort)] = aws.Int64Value(portOverride.EndpointPort)
+	}
+
+	for _, portOverride := range input.PortOverrides {
+		endpointPort, ok := endpointPorts[aws.Int64Value(portOverride.ListenerPort)]
+
+		if !ok || endpointPort != aws.Int64Value(portOverride.EndpointPort) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func resourceAwsGlobalAcceleratorEndpointGroupParseListenerArn(endpointGroupArn string) (string, error) {
 	parts := strings.Split(endpointGroupArn, "/")
 	if len(parts) < 6 {
@@ -360,6 +895,20 @@ func resourceAwsGlobalAcceleratorEndpointGroupParseListenerArn(endpointGroupArn
 	return strings.Join(parts[0:4], "/"), nil
 }
 
+// isGlobalAcceleratorEndpointGroupNotFoundError returns whether an error means the endpoint
+// group no longer exists, either itself or because its listener or accelerator was deleted.
+func isGlobalAcceleratorEndpointGroupNotFoundError(err error) bool {
+	return isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") ||
+		isAWSErr(err, globalaccelerator.ErrCodeListenerNotFoundException, "") ||
+		isAWSErr(err, globalaccelerator.ErrCodeAcceleratorNotFoundException, "")
+}
+
+// globalAcceleratorHealthCheckPathApplies returns whether a health check path is used
+// with the health check protocol. It is not sent for TCP health checks.
+func globalAcceleratorHealthCheckPathApplies(protocol string) bool {
+	return protocol == globalaccelerator.HealthCheckProtocolHttp || protocol == globalaccelerator.HealthCheckProtocolHttps
+}
+
 func expandGlobalAcceleratorEndpointConfigurations(configurations []interface{}) []*globalaccelerator.EndpointConfiguration {
 	out := make([]*globalaccelerator.EndpointConfiguration, len(configurations))
 
@@ -368,6 +917,7 @@ func expandGlobalAcceleratorEndpointConfigurations(configurations []interface{})
 		m := globalaccelerator.EndpointConfiguration{}
 
 		m.EndpointId = aws.String(configuration["endpoint_id"].(string))
+		// A weight of 0 is sent explicitly, as it stops traffic to the endpoint.
 		m.Weight = aws.Int64(int64(configuration["weight"].(int)))
 		m.ClientIPPreservationEnabled = aws.Bool(configuration["client_ip_preservation_enabled"].(bool))
 
@@ -395,9 +945,43 @@ func expandGlobalAcceleratorPortOverrides(vPortOverrides []interface{}) []*globa
 		portOverrides = append(portOverrides, portOverride)
 	}
 
+	// Set order is not stable, so order the request by listener port.
+	sort.Slice(portOverrides, func(i, j int) bool {
+		return aws.Int64Value(portOverrides[i].ListenerPort) < aws.Int64Value(portOverrides[j].ListenerPort)
+	})
+
 	return portOverrides
 }
 
+// validateGlobalAcceleratorPortOverrides returns an error if more than one
+// port override is configured for a listener port.
+func validateGlobalAcceleratorPortOverrides(vPortOverrides []interface{}) error {
+	listenerPorts := make(map[int]bool)
+
+	for _, vPortOverride := range vPortOverrides {
+		mPortOverride, ok := vPortOverride.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		listenerPort, ok := mPortOverride["listener_port"].(int)
+
+		// Unknown ports are zero.
+		if !ok || listenerPort == 0 {
+			continue
+		}
+
+		if listenerPorts[listenerPort] {
+			return fmt.Errorf("port_override: duplicate listener_port (%d)", listenerPort)
+		}
+
+		listenerPorts[listenerPort] = true
+	}
+
+	return nil
+}
+
 func flattenGlobalAcceleratorEndpointDescriptions(configurations []*globalaccelerator.EndpointDescription) []interface{} {
 	out := make([]interface{}, len(configurations))
 
@@ -407,6 +991,7 @@ func flattenGlobalAcceleratorEndpointDescriptions(configurations []*globalaccele
 		m["endpoint_id"] = aws.StringValue(configuration.EndpointId)
 		m["weight"] = aws.Int64Value(configuration.Weight)
 		m["client_ip_preservation_enabled"] = aws.BoolValue(configuration.ClientIPPreservationEnabled)
+		m["health_state"] = aws.StringValue(configuration.HealthState)
 
 		out[i] = m
 	}
@@ -414,6 +999,27 @@ func flattenGlobalAcceleratorEndpointDescriptions(configurations []*globalaccele
 	return out
 }
 
+// resourceAwsGlobalAcceleratorEndpointConfigurationHash hashes the configurable
+// endpoint attributes only, so that health state changes do not cause a diff.
+func resourceAwsGlobalAcceleratorEndpointConfigurationHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+
+	if v, ok := m["client_ip_preservation_enabled"]; ok {
+		buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
+	}
+
+	if v, ok := m["endpoint_id"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+
+	if v, ok := m["weight"]; ok {
+		buf.WriteString(fmt.Sprintf("%v-", v))
+	}
+
+	return hashcode.String(buf.String())
+}
+
 func flattenGlobalAcceleratorPortOverrides(portOverrides []*globalaccelerator.PortOverride) []interface{} {
 	if len(portOverrides) == 0 || portOverrides[0] == nil {
 		return []interface{}{}
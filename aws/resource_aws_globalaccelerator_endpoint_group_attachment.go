@@ -0,0 +1,336 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsGlobalAcceleratorEndpointGroupAttachmentCreate,
+		Read:   resourceAwsGlobalAcceleratorEndpointGroupAttachmentRead,
+		Update: resourceAwsGlobalAcceleratorEndpointGroupAttachmentUpdate,
+		Delete: resourceAwsGlobalAcceleratorEndpointGroupAttachmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"client_ip_preservation_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"endpoint_group_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"endpoint_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+			},
+
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+		},
+	}
+}
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+	endpointGroupArn := d.Get("endpoint_group_arn").(string)
+	endpointID := d.Get("endpoint_id").(string)
+
+	endpoint := &globalaccelerator.EndpointConfiguration{
+		EndpointId: aws.String(endpointID),
+	}
+
+	if v, ok := d.GetOkExists("client_ip_preservation_enabled"); ok {
+		endpoint.ClientIPPreservationEnabled = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOkExists("weight"); ok {
+		endpoint.Weight = aws.Int64(int64(v.(int)))
+	}
+
+	err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentModify(conn, endpointGroupArn, d.Timeout(schema.TimeoutCreate), func(endpoints []*globalaccelerator.EndpointConfiguration) ([]*globalaccelerator.EndpointConfiguration, error) {
+		if globalAcceleratorEndpointConfigurationIndex(endpoints, endpointID) >= 0 {
+			return nil, fmt.Errorf("endpoint (%s) is already in endpoint group (%s)", endpointID, endpointGroupArn)
+		}
+
+		return append(endpoints, endpoint), nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating Global Accelerator endpoint group attachment: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", endpointGroupArn, endpointID))
+
+	return resourceAwsGlobalAcceleratorEndpointGroupAttachmentRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	endpointGroup, err := finder.EndpointGroupByARN(conn, endpointGroupArn)
+
+	if isGlobalAcceleratorEndpointGroupNotFoundError(err) {
+		log.Printf("[WARN] Global Accelerator endpoint group (%s) not found, removing attachment (%s) from state", endpointGroupArn, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator endpoint group (%s): %w", endpointGroupArn, err)
+	}
+
+	var endpoint *globalaccelerator.EndpointDescription
+
+	if endpointGroup != nil {
+		for _, v := range endpointGroup.EndpointDescriptions {
+			if v != nil && aws.StringValue(v.EndpointId) == endpointID {
+				endpoint = v
+				break
+			}
+		}
+	}
+
+	if endpoint == nil {
+		log.Printf("[WARN] Global Accelerator endpoint group attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("client_ip_preservation_enabled", endpoint.ClientIPPreservationEnabled)
+	d.Set("endpoint_group_arn", endpointGroupArn)
+	d.Set("endpoint_id", endpoint.EndpointId)
+	d.Set("weight", endpoint.Weight)
+
+	return nil
+}
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	err = resourceAwsGlobalAcceleratorEndpointGroupAttachmentModify(conn, endpointGroupArn, d.Timeout(schema.TimeoutUpdate), func(endpoints []*globalaccelerator.EndpointConfiguration) ([]*globalaccelerator.EndpointConfiguration, error) {
+		i := globalAcceleratorEndpointConfigurationIndex(endpoints, endpointID)
+
+		if i < 0 {
+			return nil, fmt.Errorf("endpoint (%s) not found in endpoint group (%s)", endpointID, endpointGroupArn)
+		}
+
+		endpoints[i].ClientIPPreservationEnabled = aws.Bool(d.Get("client_ip_preservation_enabled").(bool))
+		endpoints[i].Weight = aws.Int64(int64(d.Get("weight").(int)))
+
+		return endpoints, nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating Global Accelerator endpoint group attachment (%s): %w", d.Id(), err)
+	}
+
+	return resourceAwsGlobalAcceleratorEndpointGroupAttachmentRead(d, meta)
+}
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).globalacceleratorconn
+
+	endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	// The wait for the accelerator to deploy fails once it has been deleted.
+	_, err = finder.EndpointGroupByARN(conn, endpointGroupArn)
+
+	if isGlobalAcceleratorEndpointGroupNotFoundError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Global Accelerator endpoint group (%s): %w", endpointGroupArn, err)
+	}
+
+	err = resourceAwsGlobalAcceleratorEndpointGroupAttachmentModify(conn, endpointGroupArn, d.Timeout(schema.TimeoutDelete), func(endpoints []*globalaccelerator.EndpointConfiguration) ([]*globalaccelerator.EndpointConfiguration, error) {
+		i := globalAcceleratorEndpointConfigurationIndex(endpoints, endpointID)
+
+		if i < 0 {
+			return nil, nil
+		}
+
+		return append(endpoints[:i], endpoints[i+1:]...), nil
+	})
+
+	if isGlobalAcceleratorEndpointGroupNotFoundError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Global Accelerator endpoint group attachment (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsGlobalAcceleratorEndpointGroupAttachmentModify performs a read-modify-write of
+// the endpoints in an endpoint group, as UpdateEndpointGroup replaces all endpoints.
+// The endpoint group is locked so that concurrent attachments do not clobber each other.
+// Changes made outside of this provider, e.g. by another Terraform run, are retried on
+// ConflictException with the endpoints read again once the accelerator is deployed.
+// No update is made if modify returns nil endpoints and no error.
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentModify(conn *globalaccelerator.GlobalAccelerator, endpointGroupArn string, timeout time.Duration, modify func([]*globalaccelerator.EndpointConfiguration) ([]*globalaccelerator.EndpointConfiguration, error)) error {
+	awsMutexKV.Lock(endpointGroupArn)
+	defer awsMutexKV.Unlock(endpointGroupArn)
+
+	acceleratorArn, err := resourceAwsGlobalAcceleratorListenerParseAcceleratorArn(endpointGroupArn)
+
+	if err != nil {
+		return err
+	}
+
+	// Changes to the accelerator or its other listeners and endpoint groups
+	// may still be deploying.
+	if err := resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+		return err
+	}
+
+	update := func() error {
+		endpointGroup, err := finder.EndpointGroupByARN(conn, endpointGroupArn)
+
+		if err != nil {
+			return err
+		}
+
+		if endpointGroup == nil {
+			return fmt.Errorf("endpoint group (%s) not found", endpointGroupArn)
+		}
+
+		endpoints, err := modify(globalAcceleratorEndpointDescriptionsToConfigurations(endpointGroup.EndpointDescriptions))
+
+		if err != nil {
+			return err
+		}
+
+		if endpoints == nil {
+			return nil
+		}
+
+		input := &globalaccelerator.UpdateEndpointGroupInput{
+			EndpointConfigurations: endpoints,
+			EndpointGroupArn:       aws.String(endpointGroupArn),
+		}
+
+		log.Printf("[DEBUG] Update Global Accelerator endpoint group endpoints: %s", input)
+
+		_, err = conn.UpdateEndpointGroup(input)
+
+		return err
+	}
+
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		err := update()
+
+		if isAWSErr(err, globalaccelerator.ErrCodeConflictException, "") {
+			if err := resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		err = update()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, acceleratorArn)
+}
+
+func resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ",", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected ENDPOINT-GROUP-ARN,ENDPOINT-ID", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// globalAcceleratorEndpointDescriptionsToConfigurations returns the configurations
+// that reproduce the specified endpoints in an UpdateEndpointGroup call.
+func globalAcceleratorEndpointDescriptionsToConfigurations(descriptions []*globalaccelerator.EndpointDescription) []*globalaccelerator.EndpointConfiguration {
+	configurations := []*globalaccelerator.EndpointConfiguration{}
+
+	for _, description := range descriptions {
+		if description == nil {
+			continue
+		}
+
+		configurations = append(configurations, &globalaccelerator.EndpointConfiguration{
+			ClientIPPreservationEnabled: description.ClientIPPreservationEnabled,
+			EndpointId:                  description.EndpointId,
+			Weight:                      description.Weight,
+		})
+	}
+
+	return configurations
+}
+
+func globalAcceleratorEndpointConfigurationIndex(configurations []*globalaccelerator.EndpointConfiguration, endpointID string) int {
+	for i, configuration := range configurations {
+		if aws.StringValue(configuration.EndpointId) == endpointID {
+			return i
+		}
+	}
+
+	return -1
+}
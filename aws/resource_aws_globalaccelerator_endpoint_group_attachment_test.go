@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
+)
+
+func TestGlobalAcceleratorEndpointGroupAttachmentParseID(t *testing.T) {
+	testCases := []struct {
+		id                       string
+		expectedEndpointGroupArn string
+		expectedEndpointID       string
+		expectError              bool
+	}{
+		{
+			id:                       "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd/listener/0123vxyz/endpoint-group/098765zyxwvu,eipalloc-12345678",
+			expectedEndpointGroupArn: "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd/listener/0123vxyz/endpoint-group/098765zyxwvu",
+			expectedEndpointID:       "eipalloc-12345678",
+		},
+		{
+			id:                       "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd/listener/0123vxyz/endpoint-group/098765zyxwvu,arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/example/1234567890abcdef",
+			expectedEndpointGroupArn: "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd/listener/0123vxyz/endpoint-group/098765zyxwvu",
+			expectedEndpointID:       "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/example/1234567890abcdef",
+		},
+		{
+			id:          "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd/listener/0123vxyz/endpoint-group/098765zyxwvu",
+			expectError: true,
+		},
+		{
+			id:          ",eipalloc-12345678",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(testCase.id)
+
+		if got := err != nil; got != testCase.expectError {
+			t.Errorf("%s: got error %v, expected error: %t", testCase.id, err, testCase.expectError)
+			continue
+		}
+
+		if endpointGroupArn != testCase.expectedEndpointGroupArn || endpointID != testCase.expectedEndpointID {
+			t.Errorf("%s: got (%s, %s), expected (%s, %s)", testCase.id, endpointGroupArn, endpointID, testCase.expectedEndpointGroupArn, testCase.expectedEndpointID)
+		}
+	}
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroupAttachment_basic(t *testing.T) {
+	resourceName := "aws_globalaccelerator_endpoint_group_attachment.test"
+	endpointGroupResourceName := "aws_globalaccelerator_endpoint_group.test"
+	eipResourceName := "aws_eip.test.0"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupAttachmentConfig(rName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "endpoint_group_arn", endpointGroupResourceName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "endpoint_id", eipResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "weight", "20"),
+					resource.TestCheckResourceAttr(resourceName, "client_ip_preservation_enabled", "false"),
+					testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists("aws_globalaccelerator_endpoint_group_attachment.other"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupAttachmentConfig(rName, 50),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "weight", "50"),
+					testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists("aws_globalaccelerator_endpoint_group_attachment.other"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroupAttachment_disappears(t *testing.T) {
+	resourceName := "aws_globalaccelerator_endpoint_group_attachment.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupAttachmentConfig(rName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists(resourceName),
+					testAccCheckResourceDisappears(testAccProvider, resourceAwsGlobalAcceleratorEndpointGroupAttachment(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupAttachmentExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Global Accelerator endpoint group attachment ID is set")
+		}
+
+		endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		endpointGroup, err := finder.EndpointGroupByARN(conn, endpointGroupArn)
+		if err != nil {
+			return err
+		}
+
+		if endpointGroup != nil {
+			for _, endpoint := range endpointGroup.EndpointDescriptions {
+				if aws.StringValue(endpoint.EndpointId) == endpointID {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("Global Accelerator endpoint group attachment (%s) not found", rs.Primary.ID)
+	}
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupAttachmentDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_globalaccelerator_endpoint_group_attachment" {
+			continue
+		}
+
+		endpointGroupArn, endpointID, err := resourceAwsGlobalAcceleratorEndpointGroupAttachmentParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		endpointGroup, err := finder.EndpointGroupByARN(conn, endpointGroupArn)
+
+		if isAWSErr(err, globalaccelerator.ErrCodeEndpointGroupNotFoundException, "") {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if endpointGroup == nil {
+			continue
+		}
+
+		for _, endpoint := range endpointGroup.EndpointDescriptions {
+			if aws.StringValue(endpoint.EndpointId) == endpointID {
+				return fmt.Errorf("Global Accelerator endpoint group attachment (%s) still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccGlobalAcceleratorEndpointGroupAttachmentConfig(rName string, weight int) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 80
+  }
+}
+
+resource "aws_eip" "test" {
+  count = 2
+
+  vpc = true
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+}
+
+resource "aws_globalaccelerator_endpoint_group_attachment" "test" {
+  endpoint_group_arn = aws_globalaccelerator_endpoint_group.test.id
+  endpoint_id        = aws_eip.test[0].id
+  weight             = %[2]d
+}
+
+resource "aws_globalaccelerator_endpoint_group_attachment" "other" {
+  endpoint_group_arn = aws_globalaccelerator_endpoint_group.test.id
+  endpoint_id        = aws_eip.test[1].id
+}
+`, rName, weight)
+}
@@ -2,10 +2,12 @@ package aws
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/globalaccelerator"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -15,6 +17,333 @@ import (
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/globalaccelerator/finder"
 )
 
+func TestResourceAwsGlobalAcceleratorEndpointGroupForRegion(t *testing.T) {
+	usEast1 := &globalaccelerator.EndpointGroup{
+		EndpointGroupArn:    aws.String("arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz/endpoint-group/098765zyxwvu"),
+		EndpointGroupRegion: aws.String("us-east-1"),
+	}
+	usWest2 := &globalaccelerator.EndpointGroup{
+		EndpointGroupArn:    aws.String("arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz/endpoint-group/123456abcdef"),
+		EndpointGroupRegion: aws.String("us-west-2"),
+	}
+
+	testCases := []struct {
+		Name           string
+		EndpointGroups []*globalaccelerator.EndpointGroup
+		Region         string
+		Expected       *globalaccelerator.EndpointGroup
+	}{
+		{
+			Name:     "no endpoint groups",
+			Region:   "us-east-1",
+			Expected: nil,
+		},
+		{
+			Name:           "nil endpoint group",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{nil, usWest2},
+			Region:         "us-west-2",
+			Expected:       usWest2,
+		},
+		{
+			Name:           "matching region",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{usEast1, usWest2},
+			Region:         "us-east-1",
+			Expected:       usEast1,
+		},
+		{
+			Name:           "no matching region",
+			EndpointGroups: []*globalaccelerator.EndpointGroup{usEast1, usWest2},
+			Region:         "eu-west-1",
+			Expected:       nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := resourceAwsGlobalAcceleratorEndpointGroupForRegion(testCase.EndpointGroups, testCase.Region)
+
+			if got != testCase.Expected {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestResourceAwsGlobalAcceleratorEndpointGroupMatchesCreateInput(t *testing.T) {
+	endpointGroup := &globalaccelerator.EndpointGroup{
+		EndpointDescriptions: []*globalaccelerator.EndpointDescription{
+			{
+				ClientIPPreservationEnabled: aws.Bool(false),
+				EndpointId:                  aws.String("eipalloc-1234567890abcdef0"),
+				HealthState:                 aws.String(globalaccelerator.HealthStateHealthy),
+				Weight:                      aws.Int64(128),
+			},
+		},
+		EndpointGroupRegion:        aws.String("us-west-2"),
+		HealthCheckIntervalSeconds: aws.Int64(30),
+		HealthCheckPort:            aws.Int64(80),
+		HealthCheckProtocol:        aws.String(globalaccelerator.HealthCheckProtocolTcp),
+		PortOverrides: []*globalaccelerator.PortOverride{
+			{
+				EndpointPort: aws.Int64(8080),
+				ListenerPort: aws.Int64(80),
+			},
+		},
+		ThresholdCount:        aws.Int64(3),
+		TrafficDialPercentage: aws.Float64(100),
+	}
+
+	matchingInput := func() *globalaccelerator.CreateEndpointGroupInput {
+		return &globalaccelerator.CreateEndpointGroupInput{
+			EndpointConfigurations: []*globalaccelerator.EndpointConfiguration{
+				{
+					ClientIPPreservationEnabled: aws.Bool(false),
+					EndpointId:                  aws.String("eipalloc-1234567890abcdef0"),
+					Weight:                      aws.Int64(128),
+				},
+			},
+			EndpointGroupRegion: aws.String("us-west-2"),
+			HealthCheckPort:     aws.Int64(80),
+			PortOverrides: []*globalaccelerator.PortOverride{
+				{
+					EndpointPort: aws.Int64(8080),
+					ListenerPort: aws.Int64(80),
+				},
+			},
+			ThresholdCount:        aws.Int64(3),
+			TrafficDialPercentage: aws.Float64(100),
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Input    func() *globalaccelerator.CreateEndpointGroupInput
+		Expected bool
+	}{
+		{
+			Name:     "matching",
+			Input:    matchingInput,
+			Expected: true,
+		},
+		{
+			Name: "different health check port",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.HealthCheckPort = aws.Int64(443)
+				return input
+			},
+		},
+		{
+			Name: "different traffic dial percentage",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.TrafficDialPercentage = aws.Float64(50)
+				return input
+			},
+		},
+		{
+			Name: "different endpoint weight",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.EndpointConfigurations[0].Weight = aws.Int64(0)
+				return input
+			},
+		},
+		{
+			Name: "different endpoint",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.EndpointConfigurations[0].EndpointId = aws.String("eipalloc-abcdef01234567890")
+				return input
+			},
+		},
+		{
+			Name: "no endpoints",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.EndpointConfigurations = nil
+				return input
+			},
+		},
+		{
+			Name: "different port override",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.PortOverrides[0].EndpointPort = aws.Int64(8443)
+				return input
+			},
+		},
+		{
+			Name: "no port overrides",
+			Input: func() *globalaccelerator.CreateEndpointGroupInput {
+				input := matchingInput()
+				input.PortOverrides = nil
+				return input
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := resourceAwsGlobalAcceleratorEndpointGroupMatchesCreateInput(endpointGroup, testCase.Input())
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestGlobalAcceleratorManagedSecurityGroupVpcIDs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		vpcIDs      []string
+		inUseVpcIDs []string
+		expected    []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "not in use",
+			vpcIDs:   []string{"vpc-2", "vpc-1"},
+			expected: []string{"vpc-1", "vpc-2"},
+		},
+		{
+			name:        "in use by another endpoint group",
+			vpcIDs:      []string{"vpc-1", "vpc-2"},
+			inUseVpcIDs: []string{"vpc-2", "vpc-3"},
+			expected:    []string{"vpc-1"},
+		},
+		{
+			name:        "all in use",
+			vpcIDs:      []string{"vpc-1"},
+			inUseVpcIDs: []string{"vpc-1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := globalAcceleratorManagedSecurityGroupVpcIDs(testCase.vpcIDs, testCase.inUseVpcIDs)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestResourceAwsGlobalAcceleratorEndpointConfigurationHash(t *testing.T) {
+	configured := map[string]interface{}{
+		"client_ip_preservation_enabled": true,
+		"endpoint_id":                    "eipalloc-12345678",
+		"health_state":                   "",
+		"weight":                         20,
+	}
+	read := map[string]interface{}{
+		"client_ip_preservation_enabled": true,
+		"endpoint_id":                    "eipalloc-12345678",
+		"health_state":                   globalaccelerator.HealthStateHealthy,
+		"weight":                         int64(20),
+	}
+	reweighted := map[string]interface{}{
+		"client_ip_preservation_enabled": true,
+		"endpoint_id":                    "eipalloc-12345678",
+		"health_state":                   globalaccelerator.HealthStateHealthy,
+		"weight":                         int64(10),
+	}
+
+	if got, expected := resourceAwsGlobalAcceleratorEndpointConfigurationHash(read), resourceAwsGlobalAcceleratorEndpointConfigurationHash(configured); got != expected {
+		t.Errorf("got hash %d, expected %d: health state must not change the hash", got, expected)
+	}
+
+	if resourceAwsGlobalAcceleratorEndpointConfigurationHash(reweighted) == resourceAwsGlobalAcceleratorEndpointConfigurationHash(read) {
+		t.Errorf("expected weight to change the hash")
+	}
+}
+
+func TestValidateGlobalAcceleratorPortOverrides(t *testing.T) {
+	testCases := []struct {
+		name          string
+		portOverrides []interface{}
+		expectError   bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "unique listener ports",
+			portOverrides: []interface{}{
+				map[string]interface{}{"endpoint_port": 8081, "listener_port": 81},
+				map[string]interface{}{"endpoint_port": 8081, "listener_port": 82},
+			},
+		},
+		{
+			name: "duplicate listener ports",
+			portOverrides: []interface{}{
+				map[string]interface{}{"endpoint_port": 8081, "listener_port": 81},
+				map[string]interface{}{"endpoint_port": 8082, "listener_port": 81},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown listener ports",
+			portOverrides: []interface{}{
+				map[string]interface{}{"endpoint_port": 8081, "listener_port": 0},
+				map[string]interface{}{"endpoint_port": 8082, "listener_port": 0},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateGlobalAcceleratorPortOverrides(testCase.portOverrides)
+
+			if testCase.expectError && err == nil {
+				t.Error("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestExpandGlobalAcceleratorPortOverrides(t *testing.T) {
+	got := expandGlobalAcceleratorPortOverrides([]interface{}{
+		map[string]interface{}{"endpoint_port": 9090, "listener_port": 90},
+		map[string]interface{}{"endpoint_port": 8081, "listener_port": 81},
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("got %d port overrides, expected 2", len(got))
+	}
+
+	if aws.Int64Value(got[0].ListenerPort) != 81 || aws.Int64Value(got[1].ListenerPort) != 90 {
+		t.Errorf("expected port overrides ordered by listener port, got %s", got)
+	}
+}
+
+func TestExpandGlobalAcceleratorEndpointConfigurations(t *testing.T) {
+	got := expandGlobalAcceleratorEndpointConfigurations([]interface{}{
+		map[string]interface{}{"endpoint_id": "eipalloc-12345678", "weight": 0, "client_ip_preservation_enabled": false},
+		map[string]interface{}{"endpoint_id": "eipalloc-87654321", "weight": 20, "client_ip_preservation_enabled": false},
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("got %d endpoint configurations, expected 2", len(got))
+	}
+
+	if got[0].Weight == nil || aws.Int64Value(got[0].Weight) != 0 {
+		t.Errorf("expected weight 0 to be sent explicitly, got %v", got[0].Weight)
+	}
+
+	if aws.Int64Value(got[1].Weight) != 20 {
+		t.Errorf("got weight %d, expected 20", aws.Int64Value(got[1].Weight))
+	}
+}
+
 func TestAccAwsGlobalAcceleratorEndpointGroup_basic(t *testing.T) {
 	var v globalaccelerator.EndpointGroup
 	resourceName := "aws_globalaccelerator_endpoint_group.test"
@@ -51,6 +380,175 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccAwsGlobalAcceleratorEndpointGroup_HealthCheckDefaults(t *testing.T) {
+	var v globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "health_check_path", ""),
+					resource.TestCheckResourceAttr(resourceName, "health_check_port", "443"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_protocol", "TCP"),
+				),
+			},
+			{
+				Config:   testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, `
+  health_check_port     = 8443
+  health_check_protocol = "TCP"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "health_check_path", ""),
+					resource.TestCheckResourceAttr(resourceName, "health_check_port", "8443"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_protocol", "TCP"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroup_TrafficDialPercentage(t *testing.T) {
+	var v globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, `
+  traffic_dial_percentage = 0
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "0"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, `
+  traffic_dial_percentage = 0.5
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "0.5"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, `
+  traffic_dial_percentage = 100
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "100"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, `
+  traffic_dial_percentage = 100.0
+`),
+				PlanOnly: true,
+			},
+			{
+				Config:   testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourceAwsGlobalAcceleratorEndpointGroupValidation(t *testing.T) {
+	s := resourceAwsGlobalAcceleratorEndpointGroup().Schema
+	trafficDialPercentage := s["traffic_dial_percentage"].ValidateFunc
+	weight := s["endpoint_configuration"].Elem.(*schema.Resource).Schema["weight"].ValidateFunc
+
+	testCases := []struct {
+		name          string
+		validateFunc  schema.SchemaValidateFunc
+		value         interface{}
+		expectedError bool
+	}{
+		{name: "traffic_dial_percentage", validateFunc: trafficDialPercentage, value: 0.0},
+		{name: "traffic_dial_percentage", validateFunc: trafficDialPercentage, value: 0.5},
+		{name: "traffic_dial_percentage", validateFunc: trafficDialPercentage, value: 100.0},
+		{name: "traffic_dial_percentage", validateFunc: trafficDialPercentage, value: -0.1, expectedError: true},
+		{name: "traffic_dial_percentage", validateFunc: trafficDialPercentage, value: 100.1, expectedError: true},
+		{name: "weight", validateFunc: weight, value: 0},
+		{name: "weight", validateFunc: weight, value: 255},
+		{name: "weight", validateFunc: weight, value: -1, expectedError: true},
+		{name: "weight", validateFunc: weight, value: 256, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := testCase.validateFunc(testCase.value, testCase.name)
+
+		if got := len(errs) > 0; got != testCase.expectedError {
+			t.Errorf("%s = %v: got errors %v, expected error %t", testCase.name, testCase.value, errs, testCase.expectedError)
+		}
+	}
+}
+
+func TestGlobalAcceleratorHealthCheckPathApplies(t *testing.T) {
+	testCases := []struct {
+		protocol string
+		expected bool
+	}{
+		{protocol: ""},
+		{protocol: globalaccelerator.HealthCheckProtocolTcp},
+		{protocol: globalaccelerator.HealthCheckProtocolHttp, expected: true},
+		{protocol: globalaccelerator.HealthCheckProtocolHttps, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		if got := globalAcceleratorHealthCheckPathApplies(testCase.protocol); got != testCase.expected {
+			t.Errorf("%q: got %t, expected %t", testCase.protocol, got, testCase.expected)
+		}
+	}
+}
+
+func TestIsGlobalAcceleratorEndpointGroupNotFoundError(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil},
+		{err: fmt.Errorf("test")},
+		{err: awserr.New(globalaccelerator.ErrCodeInternalServiceErrorException, "test", nil)},
+		{err: awserr.New(globalaccelerator.ErrCodeEndpointGroupNotFoundException, "test", nil), expected: true},
+		{err: awserr.New(globalaccelerator.ErrCodeListenerNotFoundException, "test", nil), expected: true},
+		{err: awserr.New(globalaccelerator.ErrCodeAcceleratorNotFoundException, "test", nil), expected: true},
+	}
+
+	for _, testCase := range testCases {
+		if got := isGlobalAcceleratorEndpointGroupNotFoundError(testCase.err); got != testCase.expected {
+			t.Errorf("%v: got %t, expected %t", testCase.err, got, testCase.expected)
+		}
+	}
+}
+
 func TestAccAwsGlobalAcceleratorEndpointGroup_disappears(t *testing.T) {
 	var v globalaccelerator.EndpointGroup
 	resourceName := "aws_globalaccelerator_endpoint_group.test"
@@ -73,6 +571,44 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_disappears(t *testing.T) {
 	})
 }
 
+func TestAccAwsGlobalAcceleratorEndpointGroup_disappears_Listener(t *testing.T) {
+	var v globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	listenerResourceName := "aws_globalaccelerator_listener.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					testAccCheckGlobalAcceleratorListenerDisappearsWithEndpointGroups(listenerResourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroup_crossAccountListenerArn(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccGlobalAcceleratorEndpointGroupConfigCrossAccountListenerArn(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`does not support cross-account`),
+			},
+		},
+	})
+}
+
 func TestAccAwsGlobalAcceleratorEndpointGroup_ALBEndpoint_ClientIP(t *testing.T) {
 	var v globalaccelerator.EndpointGroup
 	var vpc ec2.Vpc
@@ -146,11 +682,10 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_ALBEndpoint_ClientIP(t *testing.T)
 	})
 }
 
-func TestAccAwsGlobalAcceleratorEndpointGroup_InstanceEndpoint(t *testing.T) {
+func TestAccAwsGlobalAcceleratorEndpointGroup_DeleteManagedSecurityGroup(t *testing.T) {
 	var v globalaccelerator.EndpointGroup
 	var vpc ec2.Vpc
 	resourceName := "aws_globalaccelerator_endpoint_group.test"
-	instanceResourceName := "aws_instance.test"
 	vpcResourceName := "aws_vpc.test"
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
@@ -160,56 +695,125 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_InstanceEndpoint(t *testing.T) {
 		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccGlobalAcceleratorEndpointGroupConfigInstanceEndpoint(rName),
+				Config: testAccGlobalAcceleratorEndpointGroupConfigDeleteManagedSecurityGroup(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
-					testAccMatchResourceAttrGlobalARN(resourceName, "arn", "globalaccelerator", regexp.MustCompile(`accelerator/[^/]+/listener/[^/]+/endpoint-group/[^/]+`)),
-					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "1"),
-					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "endpoint_configuration.*", map[string]string{
-						"client_ip_preservation_enabled": "true",
-						"weight":                         "20",
-					}),
-					resource.TestCheckTypeSetElemAttrPair(resourceName, "endpoint_configuration.*.endpoint_id", instanceResourceName, "id"),
-					resource.TestCheckResourceAttr(resourceName, "endpoint_group_region", testAccGetRegion()),
-					resource.TestCheckResourceAttr(resourceName, "health_check_interval_seconds", "30"),
-					resource.TestCheckResourceAttr(resourceName, "health_check_path", "/"),
-					resource.TestCheckResourceAttr(resourceName, "health_check_port", "80"),
-					resource.TestCheckResourceAttr(resourceName, "health_check_protocol", "HTTP"),
-					testAccMatchResourceAttrGlobalARN(resourceName, "listener_arn", "globalaccelerator", regexp.MustCompile(`accelerator/[^/]+/listener/[^/]+`)),
-					resource.TestCheckResourceAttr(resourceName, "port_override.#", "0"),
-					resource.TestCheckResourceAttr(resourceName, "threshold_count", "3"),
-					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "100"),
+					resource.TestCheckResourceAttr(resourceName, "delete_managed_security_group", "true"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_managed_security_group"},
 			},
 			{
 				Config: testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckVpcExists(vpcResourceName, &vpc),
-					testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup(&vpc),
+					testAccCheckGlobalAcceleratorEndpointGroupManagedSecurityGroupDeleted(&vpc),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAwsGlobalAcceleratorEndpointGroup_MultiRegion(t *testing.T) {
-	var providers []*schema.Provider
+func TestAccAwsGlobalAcceleratorEndpointGroup_WaitForEndpointHealth(t *testing.T) {
 	var v globalaccelerator.EndpointGroup
 	resourceName := "aws_globalaccelerator_endpoint_group.test"
-	eipResourceName := "aws_eip.test"
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccMultipleRegionPreCheck(t, 2); testAccPreCheckGlobalAccelerator(t) },
-		ProviderFactories: testAccProviderFactoriesAlternate(&providers),
-		CheckDestroy:      testAccCheckGlobalAcceleratorEndpointGroupDestroy,
-		Steps: []resource.TestStep{
-			{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigWaitForEndpointHealth(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					testAccCheckGlobalAcceleratorEndpointGroupEndpointsHealthy(&v),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "endpoint_configuration.*", map[string]string{
+						"health_state": globalaccelerator.HealthStateHealthy,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_endpoint_health", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_endpoint_health"},
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroup_InstanceEndpoint(t *testing.T) {
+	var v globalaccelerator.EndpointGroup
+	var vpc ec2.Vpc
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	instanceResourceName := "aws_instance.test"
+	vpcResourceName := "aws_vpc.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigInstanceEndpoint(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
+					testAccMatchResourceAttrGlobalARN(resourceName, "arn", "globalaccelerator", regexp.MustCompile(`accelerator/[^/]+/listener/[^/]+/endpoint-group/[^/]+`)),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_configuration.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "endpoint_configuration.*", map[string]string{
+						"client_ip_preservation_enabled": "true",
+						"weight":                         "20",
+					}),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "endpoint_configuration.*.endpoint_id", instanceResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_group_region", testAccGetRegion()),
+					resource.TestCheckResourceAttr(resourceName, "health_check_interval_seconds", "30"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_path", "/"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_protocol", "HTTP"),
+					testAccMatchResourceAttrGlobalARN(resourceName, "listener_arn", "globalaccelerator", regexp.MustCompile(`accelerator/[^/]+/listener/[^/]+`)),
+					resource.TestCheckResourceAttr(resourceName, "port_override.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "threshold_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "100"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists(vpcResourceName, &vpc),
+					testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup(&vpc),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroup_MultiRegion(t *testing.T) {
+	var providers []*schema.Provider
+	var v globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	eipResourceName := "aws_eip.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t); testAccMultipleRegionPreCheck(t, 2); testAccPreCheckGlobalAccelerator(t) },
+		ProviderFactories: testAccProviderFactoriesAlternate(&providers),
+		CheckDestroy:      testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
 				Config: testAccGlobalAcceleratorEndpointGroupConfigMultiRegion(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v),
@@ -301,6 +905,14 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_PortOverrides(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config:   testAccGlobalAcceleratorEndpointGroupConfigPortOverridesReordered(rName),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccGlobalAcceleratorEndpointGroupConfigPortOverridesDuplicate(rName),
+				ExpectError: regexp.MustCompile(`duplicate listener_port \(81\)`),
+			},
 		},
 	})
 }
@@ -406,6 +1018,143 @@ func TestAccAwsGlobalAcceleratorEndpointGroup_Update(t *testing.T) {
 	})
 }
 
+func TestAccAwsGlobalAcceleratorEndpointGroup_EIPEndpointWeight(t *testing.T) {
+	var v1, v2, v3 globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	eipResourceName := "aws_eip.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigEIPEndpointWeight(rName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v1),
+					testAccCheckGlobalAcceleratorEndpointGroupEndpointWeight(&v1, 20),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "endpoint_configuration.*", map[string]string{
+						"weight": "20",
+					}),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "endpoint_configuration.*.endpoint_id", eipResourceName, "id"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigEIPEndpointWeight(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v2),
+					testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(&v1, &v2),
+					testAccCheckGlobalAcceleratorEndpointGroupEndpointWeight(&v2, 0),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "endpoint_configuration.*", map[string]string{
+						"weight": "0",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigEIPEndpointWeight(rName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v3),
+					testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(&v2, &v3),
+					testAccCheckGlobalAcceleratorEndpointGroupEndpointWeight(&v3, 20),
+				),
+			},
+			{
+				Config:      testAccGlobalAcceleratorEndpointGroupConfigEIPEndpointWeight(rName, 256),
+				ExpectError: regexp.MustCompile(`weight to be in the range \(0 - 255\)`),
+			},
+		},
+	})
+}
+
+func TestAccAwsGlobalAcceleratorEndpointGroup_ConsecutiveUpdates(t *testing.T) {
+	var v1, v2, v3, v4 globalaccelerator.EndpointGroup
+	resourceName := "aws_globalaccelerator_endpoint_group.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckGlobalAccelerator(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGlobalAcceleratorEndpointGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigTrafficDial(rName, 100, 30, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v1),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_group_region", testAccGetRegion()),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "100"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigTrafficDial(rName, 50, 30, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v2),
+					testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(&v1, &v2),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "50"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigTrafficDial(rName, 50, 10, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v3),
+					testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(&v2, &v3),
+					resource.TestCheckResourceAttr(resourceName, "health_check_interval_seconds", "10"),
+				),
+			},
+			{
+				Config: testAccGlobalAcceleratorEndpointGroupConfigTrafficDial(rName, 0, 10, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGlobalAcceleratorEndpointGroupExists(resourceName, &v4),
+					testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(&v3, &v4),
+					resource.TestCheckResourceAttr(resourceName, "threshold_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_dial_percentage", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupNotRecreated(i, j *globalaccelerator.EndpointGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(i.EndpointGroupArn) != aws.StringValue(j.EndpointGroupArn) {
+			return fmt.Errorf("Global Accelerator endpoint group recreated")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupEndpointsHealthy(v *globalaccelerator.EndpointGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, endpoint := range v.EndpointDescriptions {
+			if state := aws.StringValue(endpoint.HealthState); state != globalaccelerator.HealthStateHealthy {
+				return fmt.Errorf("Global Accelerator endpoint (%s) health state is %s, expected %s", aws.StringValue(endpoint.EndpointId), state, globalaccelerator.HealthStateHealthy)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupEndpointWeight(v *globalaccelerator.EndpointGroup, weight int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(v.EndpointDescriptions) != 1 {
+			return fmt.Errorf("expected 1 endpoint, got %d", len(v.EndpointDescriptions))
+		}
+
+		if got := aws.Int64Value(v.EndpointDescriptions[0].Weight); got != weight {
+			return fmt.Errorf("expected endpoint weight %d, got %d", weight, got)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckGlobalAcceleratorEndpointGroupDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
 
@@ -454,48 +1203,330 @@ func testAccCheckGlobalAcceleratorEndpointGroupExists(name string, v *globalacce
 
 		*v = *endpointGroup
 
-		return nil
-	}
+		return nil
+	}
+}
+
+// testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup deletes the security group
+// placed into the VPC when Global Accelerator client IP address preservation is enabled.
+func testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup(vpc *ec2.Vpc) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+		input := &ec2.DescribeSecurityGroupsInput{
+			Filters: buildEC2AttributeFilterList(
+				map[string]string{
+					"group-name": "GlobalAccelerator",
+					"vpc-id":     aws.StringValue(vpc.VpcId),
+				},
+			),
+		}
+
+		output, err := conn.DescribeSecurityGroups(input)
+		if err != nil {
+			return err
+		}
+
+		if len(output.SecurityGroups) == 0 {
+			// Already gone.
+			return nil
+		}
+
+		_, err = conn.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: output.SecurityGroups[0].GroupId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGlobalAcceleratorEndpointGroupManagedSecurityGroupDeleted(vpc *ec2.Vpc) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+		output, err := conn.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+			Filters: buildEC2AttributeFilterList(
+				map[string]string{
+					"group-name": globalAcceleratorManagedSecurityGroupName,
+					"vpc-id":     aws.StringValue(vpc.VpcId),
+				},
+			),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if len(output.SecurityGroups) > 0 {
+			return fmt.Errorf("Global Accelerator managed security group (%s) still exists", aws.StringValue(output.SecurityGroups[0].GroupId))
+		}
+
+		return nil
+	}
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigCrossAccountListenerArn() string {
+	return `
+data "aws_caller_identity" "current" {}
+
+data "aws_partition" "current" {}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  # An account ID that differs from the caller's.
+  listener_arn = "arn:${data.aws_partition.current.partition}:globalaccelerator::${data.aws_caller_identity.current.account_id == "111111111111" ? "222222222222" : "111111111111"}:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz"
+}
+`
+}
+
+// testAccCheckGlobalAcceleratorListenerDisappearsWithEndpointGroups deletes a listener
+// out-of-band. Its endpoint groups are deleted first, as listeners with endpoint groups
+// cannot be deleted.
+func testAccCheckGlobalAcceleratorListenerDisappearsWithEndpointGroups(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).globalacceleratorconn
+
+		endpointGroups, err := finder.EndpointGroupsByListenerARN(conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		for _, endpointGroup := range endpointGroups {
+			_, err := conn.DeleteEndpointGroup(&globalaccelerator.DeleteEndpointGroupInput{
+				EndpointGroupArn: endpointGroup.EndpointGroupArn,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if err := resourceAwsGlobalAcceleratorAcceleratorWaitForDeployedState(conn, rs.Primary.Attributes["accelerator_arn"]); err != nil {
+				return err
+			}
+		}
+
+		return testAccCheckResourceDisappears(testAccProvider, resourceAwsGlobalAcceleratorListener(), resourceName)(s)
+	}
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 80
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+}
+`, rName)
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigHealthCheckDefaults(rName, healthCheck string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 443
+    to_port   = 443
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+%[2]s
+}
+`, rName, healthCheck)
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, rName)
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigALBEndpointClientIP(rName string, clientIP bool) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName),
+		fmt.Sprintf(`
+resource "aws_lb" "test" {
+  name            = %[1]q
+  internal        = false
+  security_groups = [aws_security_group.test.id]
+  subnets         = [aws_subnet.test.*.id[0], aws_subnet.test.*.id[1]]
+
+  idle_timeout               = 30
+  enable_deletion_protection = false
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+variable "subnets" {
+  default = ["10.0.1.0/24", "10.0.2.0/24"]
+  type    = list
+}
+
+resource "aws_subnet" "test" {
+  count             = length(var.subnets)
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = element(var.subnets, count.index)
+  availability_zone = element(data.aws_availability_zones.available.names, count.index)
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_security_group" "test" {
+  name   = %[1]q
+  vpc_id = aws_vpc.test.id
+
+  ingress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 80
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+
+  endpoint_configuration {
+    endpoint_id                    = aws_lb.test.id
+    weight                         = 20
+    client_ip_preservation_enabled = %[2]t
+  }
+
+  health_check_interval_seconds = 30
+  health_check_path             = "/"
+  health_check_port             = 80
+  health_check_protocol         = "HTTP"
+  threshold_count               = 3
+  traffic_dial_percentage       = 100
+}
+`, rName, clientIP))
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigDeleteManagedSecurityGroup(rName string) string {
+	return composeConfig(
+		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
+		testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName),
+		fmt.Sprintf(`
+resource "aws_lb" "test" {
+  name            = %[1]q
+  internal        = false
+  security_groups = [aws_security_group.test.id]
+  subnets         = aws_subnet.test[*].id
+
+  idle_timeout               = 30
+  enable_deletion_protection = false
+
+  tags = {
+    Name = %[1]q
+  }
 }
 
-// testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup deletes the security group
-// placed into the VPC when Global Accelerator client IP address preservation is enabled.
-func testAccCheckGlobalAcceleratorEndpointGroupDeleteGlobalAcceleratorSecurityGroup(vpc *ec2.Vpc) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+resource "aws_subnet" "test" {
+  count = 2
 
-		input := &ec2.DescribeSecurityGroupsInput{
-			Filters: buildEC2AttributeFilterList(
-				map[string]string{
-					"group-name": "GlobalAccelerator",
-					"vpc-id":     aws.StringValue(vpc.VpcId),
-				},
-			),
-		}
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = cidrsubnet(aws_vpc.test.cidr_block, 8, count.index)
+  availability_zone = data.aws_availability_zones.available.names[count.index]
 
-		output, err := conn.DescribeSecurityGroups(input)
-		if err != nil {
-			return err
-		}
+  tags = {
+    Name = %[1]q
+  }
+}
 
-		if len(output.SecurityGroups) == 0 {
-			// Already gone.
-			return nil
-		}
+resource "aws_security_group" "test" {
+  name   = %[1]q
+  vpc_id = aws_vpc.test.id
 
-		_, err = conn.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
-			GroupId: output.SecurityGroups[0].GroupId,
-		})
-		if err != nil {
-			return err
-		}
+  tags = {
+    Name = %[1]q
+  }
+}
 
-		return nil
-	}
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
 }
 
-func testAccGlobalAcceleratorEndpointGroupConfigBasic(rName string) string {
-	return fmt.Sprintf(`
 resource "aws_globalaccelerator_accelerator" "test" {
   name            = %[1]q
   ip_address_type = "IPV4"
@@ -513,66 +1544,71 @@ resource "aws_globalaccelerator_listener" "test" {
 }
 
 resource "aws_globalaccelerator_endpoint_group" "test" {
-  listener_arn = aws_globalaccelerator_listener.test.id
-}
-`, rName)
-}
-
-func testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName string) string {
-	return fmt.Sprintf(`
-resource "aws_vpc" "test" {
-  cidr_block = "10.0.0.0/16"
+  listener_arn                  = aws_globalaccelerator_listener.test.id
+  delete_managed_security_group = true
 
-  tags = {
-    Name = %[1]q
+  endpoint_configuration {
+    endpoint_id                    = aws_lb.test.id
+    weight                         = 20
+    client_ip_preservation_enabled = true
   }
 }
-`, rName)
+`, rName))
 }
 
-func testAccGlobalAcceleratorEndpointGroupConfigALBEndpointClientIP(rName string, clientIP bool) string {
+func testAccGlobalAcceleratorEndpointGroupConfigWaitForEndpointHealth(rName string) string {
 	return composeConfig(
 		testAccAvailableAZsNoOptInDefaultExcludeConfig(),
 		testAccGlobalAcceleratorEndpointGroupConfigBaseVpc(rName),
 		fmt.Sprintf(`
-resource "aws_lb" "test" {
-  name            = %[1]q
-  internal        = false
-  security_groups = [aws_security_group.test.id]
-  subnets         = [aws_subnet.test.*.id[0], aws_subnet.test.*.id[1]]
+resource "aws_subnet" "test" {
+  count = 2
 
-  idle_timeout               = 30
-  enable_deletion_protection = false
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = cidrsubnet(aws_vpc.test.cidr_block, 8, count.index)
+  availability_zone = data.aws_availability_zones.available.names[count.index]
 
   tags = {
     Name = %[1]q
   }
 }
 
-variable "subnets" {
-  default = ["10.0.1.0/24", "10.0.2.0/24"]
-  type    = list
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
 }
 
-resource "aws_subnet" "test" {
-  count             = length(var.subnets)
-  vpc_id            = aws_vpc.test.id
-  cidr_block        = element(var.subnets, count.index)
-  availability_zone = element(data.aws_availability_zones.available.names, count.index)
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block = "0.0.0.0/0"
+    gateway_id = aws_internet_gateway.test.id
+  }
 
   tags = {
     Name = %[1]q
   }
 }
 
+resource "aws_route_table_association" "test" {
+  count = 2
+
+  subnet_id      = aws_subnet.test[count.index].id
+  route_table_id = aws_route_table.test.id
+}
+
 resource "aws_security_group" "test" {
   name   = %[1]q
   vpc_id = aws_vpc.test.id
 
   ingress {
-    from_port   = 0
-    to_port     = 0
-    protocol    = "-1"
+    from_port   = 80
+    to_port     = 80
+    protocol    = "tcp"
     cidr_blocks = ["0.0.0.0/0"]
   }
 
@@ -588,18 +1624,39 @@ resource "aws_security_group" "test" {
   }
 }
 
-resource "aws_internet_gateway" "test" {
-  vpc_id = aws_vpc.test.id
+resource "aws_lb" "test" {
+  name            = %[1]q
+  internal        = false
+  security_groups = [aws_security_group.test.id]
+  subnets         = aws_subnet.test[*].id
 
   tags = {
     Name = %[1]q
   }
+
+  depends_on = [aws_route_table_association.test]
+}
+
+resource "aws_lb_listener" "test" {
+  load_balancer_arn = aws_lb.test.arn
+  port              = 80
+  protocol          = "HTTP"
+
+  default_action {
+    type = "fixed-response"
+
+    fixed_response {
+      content_type = "text/plain"
+      message_body = "OK"
+      status_code  = "200"
+    }
+  }
 }
 
 resource "aws_globalaccelerator_accelerator" "test" {
   name            = %[1]q
   ip_address_type = "IPV4"
-  enabled         = false
+  enabled         = true
 }
 
 resource "aws_globalaccelerator_listener" "test" {
@@ -616,19 +1673,19 @@ resource "aws_globalaccelerator_endpoint_group" "test" {
   listener_arn = aws_globalaccelerator_listener.test.id
 
   endpoint_configuration {
-    endpoint_id                    = aws_lb.test.id
-    weight                         = 20
-    client_ip_preservation_enabled = %[2]t
+    endpoint_id = aws_lb.test.id
+    weight      = 100
   }
 
-  health_check_interval_seconds = 30
-  health_check_path             = "/"
-  health_check_port             = 80
-  health_check_protocol         = "HTTP"
-  threshold_count               = 3
-  traffic_dial_percentage       = 100
+  health_check_path     = "/"
+  health_check_port     = 80
+  health_check_protocol = "HTTP"
+
+  wait_for_endpoint_health = true
+
+  depends_on = [aws_lb_listener.test]
 }
-`, rName, clientIP))
+`, rName))
 }
 
 func testAccGlobalAcceleratorEndpointGroupConfigInstanceEndpoint(rName string) string {
@@ -813,6 +1870,74 @@ resource "aws_globalaccelerator_endpoint_group" "test" {
 `, rName)
 }
 
+func testAccGlobalAcceleratorEndpointGroupConfigPortOverridesReordered(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 90
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+
+  port_override {
+    endpoint_port = 9090
+    listener_port = 90
+  }
+
+  port_override {
+    endpoint_port = 8081
+    listener_port = 81
+  }
+}
+`, rName)
+}
+
+func testAccGlobalAcceleratorEndpointGroupConfigPortOverridesDuplicate(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 90
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+
+  port_override {
+    endpoint_port = 8081
+    listener_port = 81
+  }
+
+  port_override {
+    endpoint_port = 8082
+    listener_port = 81
+  }
+}
+`, rName)
+}
+
 func testAccGlobalAcceleratorEndpointGroupConfigTcpHealthCheckProtocol(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_globalaccelerator_accelerator" "test" {
@@ -859,6 +1984,37 @@ resource "aws_globalaccelerator_endpoint_group" "test" {
 `, rName)
 }
 
+func testAccGlobalAcceleratorEndpointGroupConfigTrafficDial(rName string, trafficDialPercentage, healthCheckIntervalSeconds, thresholdCount int) string {
+	return fmt.Sprintf(`
+data "aws_region" "current" {}
+
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 80
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn          = aws_globalaccelerator_listener.test.id
+  endpoint_group_region = data.aws_region.current.name
+
+  health_check_interval_seconds = %[3]d
+  threshold_count               = %[4]d
+  traffic_dial_percentage       = %[2]d
+}
+`, rName, trafficDialPercentage, healthCheckIntervalSeconds, thresholdCount)
+}
+
 func testAccGlobalAcceleratorEndpointGroupConfigUpdated(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_globalaccelerator_accelerator" "test" {
@@ -902,3 +2058,40 @@ resource "aws_globalaccelerator_endpoint_group" "test" {
 }
 `, rName)
 }
+
+func testAccGlobalAcceleratorEndpointGroupConfigEIPEndpointWeight(rName string, weight int) string {
+	return fmt.Sprintf(`
+resource "aws_globalaccelerator_accelerator" "test" {
+  name            = %[1]q
+  ip_address_type = "IPV4"
+  enabled         = false
+}
+
+resource "aws_globalaccelerator_listener" "test" {
+  accelerator_arn = aws_globalaccelerator_accelerator.test.id
+  protocol        = "TCP"
+
+  port_range {
+    from_port = 80
+    to_port   = 80
+  }
+}
+
+resource "aws_eip" "test" {
+  vpc = true
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_globalaccelerator_endpoint_group" "test" {
+  listener_arn = aws_globalaccelerator_listener.test.id
+
+  endpoint_configuration {
+    endpoint_id = aws_eip.test.id
+    weight      = %[2]d
+  }
+}
+`, rName, weight)
+}
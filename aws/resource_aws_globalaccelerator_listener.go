@@ -1,12 +1,14 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/globalaccelerator"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,15 +23,25 @@ func resourceAwsGlobalAcceleratorListener() *schema.Resource {
 		Delete: resourceAwsGlobalAcceleratorListenerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("allow_cross_account", false)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
+		CustomizeDiff: resourceAwsGlobalAcceleratorArnOwnershipCustomizeDiff("accelerator_arn"),
+
 		Schema: map[string]*schema.Schema{
 			"accelerator_arn": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"allow_cross_account": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"client_affinity": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -71,6 +83,49 @@ func resourceAwsGlobalAcceleratorListener() *schema.Resource {
 	}
 }
 
+// resourceAwsGlobalAcceleratorArnOwnershipCustomizeDiff returns a CustomizeDiffFunc that
+// checks that the Global Accelerator ARN in the specified attribute belongs to the provider's
+// partition and account, unless allow_cross_account is set.
+func resourceAwsGlobalAcceleratorArnOwnershipCustomizeDiff(key string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if !diff.HasChange(key) || !diff.NewValueKnown(key) || diff.Get("allow_cross_account").(bool) {
+			return nil
+		}
+
+		client := meta.(*AWSClient)
+
+		if err := globalAcceleratorArnOwnershipError(diff.Get(key).(string), client.partition, client.accountid); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		return nil
+	}
+}
+
+// globalAcceleratorArnOwnershipError returns an error if the Global Accelerator ARN is not
+// in the specified partition and account. An empty account ID is not checked.
+func globalAcceleratorArnOwnershipError(s, partition, accountID string) error {
+	parsedArn, err := arn.Parse(s)
+
+	if err != nil {
+		return fmt.Errorf("error parsing ARN (%s): %w", s, err)
+	}
+
+	if parsedArn.Service != globalaccelerator.ServiceName {
+		return fmt.Errorf("ARN (%s) is not a Global Accelerator ARN", s)
+	}
+
+	if parsedArn.Partition != partition {
+		return fmt.Errorf("ARN (%s) is in partition %s, but the provider is configured for partition %s", s, parsedArn.Partition, partition)
+	}
+
+	if accountID != "" && parsedArn.AccountID != accountID {
+		return fmt.Errorf("ARN (%s) belongs to account %s, but the provider is configured for account %s; Global Accelerator does not support cross-account listeners and endpoint groups (set allow_cross_account to skip this check)", s, parsedArn.AccountID, accountID)
+	}
+
+	return nil
+}
+
 func resourceAwsGlobalAcceleratorListenerCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).globalacceleratorconn
 
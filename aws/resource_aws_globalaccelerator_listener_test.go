@@ -9,6 +9,82 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestGlobalAcceleratorArnOwnershipError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		arn         string
+		partition   string
+		accountID   string
+		expectError bool
+	}{
+		{
+			name:      "commercial listener",
+			arn:       "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz",
+			partition: "aws",
+			accountID: "123456789012",
+		},
+		{
+			name:      "commercial accelerator",
+			arn:       "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh",
+			partition: "aws",
+			accountID: "123456789012",
+		},
+		{
+			name:      "GovCloud listener",
+			arn:       "arn:aws-us-gov:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz",
+			partition: "aws-us-gov",
+			accountID: "123456789012",
+		},
+		{
+			name:      "account ID not known",
+			arn:       "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz",
+			partition: "aws",
+		},
+		{
+			name:        "other account",
+			arn:         "arn:aws:globalaccelerator::210987654321:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz",
+			partition:   "aws",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name:        "other partition",
+			arn:         "arn:aws-cn:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh/listener/0123vxyz",
+			partition:   "aws",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name:        "other service",
+			arn:         "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/example/1234567890abcdef/1234567890abcdef",
+			partition:   "aws",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name:        "not an ARN",
+			arn:         "accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh",
+			partition:   "aws",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := globalAcceleratorArnOwnershipError(testCase.arn, testCase.partition, testCase.accountID)
+
+			if err == nil && testCase.expectError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.expectError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
+
 func TestAccAwsGlobalAcceleratorListener_basic(t *testing.T) {
 	resourceName := "aws_globalaccelerator_listener.example"
 	rInt := acctest.RandInt()
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -24,10 +29,23 @@ import (
 	iamwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/iam/waiter"
 )
 
-const awsMutexLambdaKey = `aws_lambda_function`
+// lambdaFunctionFileContentMaxInFlightBytes caps the size of the deployment packages read
+// into memory at the same time by all functions in an apply.
+// See https://github.com/hashicorp/terraform/issues/9364
+const lambdaFunctionFileContentMaxInFlightBytes = 256 * 1024 * 1024
+
+var lambdaFunctionFileContentLimiter = newLambdaFileContentLimiter(lambdaFunctionFileContentMaxInFlightBytes)
+
+// Lambda rejects reserved concurrency that would leave fewer than this many
+// unreserved concurrent executions for the account.
+const lambdaFunctionMinimumUnreservedConcurrentExecutions = 100
 
 const LambdaFunctionVersionLatest = "$LATEST"
 
+// lambdaFunctionNetworkInterfacesDeleted is the state of a deleted function's VPC
+// configuration when no Lambda ENIs use it.
+const lambdaFunctionNetworkInterfacesDeleted = "deleted"
+
 func resourceAwsLambdaFunction() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsLambdaFunctionCreate,
@@ -36,11 +54,17 @@ func resourceAwsLambdaFunction() *schema.Resource {
 		Delete: resourceAwsLambdaFunctionDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			// Lambda ENIs can take up to ~35 minutes to be released.
+			Delete: schema.DefaultTimeout(45 * time.Minute),
 		},
 
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("function_name", d.Id())
+				d.Set("detect_code_drift", false)
+				d.Set("wait_for_network_interfaces", false)
+				d.Set("ignore_layer_order", false)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -128,7 +152,7 @@ func resourceAwsLambdaFunction() *schema.Resource {
 						"target_arn": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validateArn,
+							ValidateFunc: validateLambdaFunctionDeadLetterTargetArn,
 						},
 					},
 				},
@@ -164,21 +188,29 @@ func resourceAwsLambdaFunction() *schema.Resource {
 			"handler": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringLenBetween(1, 128),
+				StateFunc:    lambdaFunctionTrimSpaceStateFunc,
+				ValidateFunc: lambdaFunctionTrimSpaceValidateFunc(validation.StringLenBetween(1, 128)),
 			},
 			"layers": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 5,
 				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validateArn,
+					Type:             schema.TypeString,
+					ValidateFunc:     validateLambdaLayerVersionArn,
+					DiffSuppressFunc: suppressLambdaFunctionLayersReorder,
 				},
 			},
-			"memory_size": {
-				Type:     schema.TypeInt,
+			"ignore_layer_order": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  128,
+				Default:  false,
+			},
+			"memory_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      128,
+				ValidateFunc: validation.IntBetween(128, 10240),
 			},
 			"reserved_concurrent_executions": {
 				Type:         schema.TypeInt,
@@ -193,12 +225,14 @@ func resourceAwsLambdaFunction() *schema.Resource {
 			"runtime": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringInSlice(lambda.Runtime_Values(), false),
+				StateFunc:    lambdaFunctionTrimSpaceStateFunc,
+				ValidateFunc: lambdaFunctionTrimSpaceValidateFunc(validation.StringInSlice(lambda.Runtime_Values(), false)),
 			},
 			"timeout": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  3,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntBetween(1, 900),
 			},
 			"publish": {
 				Type:     schema.TypeBool,
@@ -260,23 +294,55 @@ func resourceAwsLambdaFunction() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"qualified_code_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"invoke_arn": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"qualified_invoke_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"last_modified": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"code_updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"source_code_hash": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 			},
+			"code_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"source_code_size": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"detect_code_drift": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_network_interfaces": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// deployed_code_sha256 records the CodeSha256 of the last code deployed
+			// by Terraform so that out-of-band code deployments can be detected.
+			"deployed_code_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"environment": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -288,6 +354,12 @@ func resourceAwsLambdaFunction() *schema.Resource {
 							Optional: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+						"sensitive_variables": {
+							Type:      schema.TypeMap,
+							Optional:  true,
+							Sensitive: true,
+							Elem:      &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -299,12 +371,13 @@ func resourceAwsLambdaFunction() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"mode": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
+							Type:      schema.TypeString,
+							Required:  true,
+							StateFunc: lambdaFunctionTracingModeStateFunc,
+							ValidateFunc: lambdaFunctionTrimSpaceValidateFunc(validation.StringInSlice([]string{
 								lambda.TracingModeActive,
 								lambda.TracingModePassThrough},
-								true),
+								true)),
 						},
 					},
 				},
@@ -318,28 +391,409 @@ func resourceAwsLambdaFunction() *schema.Resource {
 		},
 
 		CustomizeDiff: customdiff.Sequence(
-			checkHandlerRuntimeForZipFunction,
+			checkPackageTypeForLambdaFunction,
+			checkEnvironmentForLambdaFunction,
+			checkCodeSigningConfigForLambdaFunction,
+			checkRuntimeForLambdaFunction,
+			checkCodeDriftForLambdaFunction,
+			checkFilenameForLambdaFunction,
+			checkReservedConcurrencyForLambdaFunction,
 			updateComputedAttributesOnPublish,
 		),
 	}
 }
 
-func checkHandlerRuntimeForZipFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
-	packageType := d.Get("package_type")
-	_, handlerOk := d.GetOk("handler")
-	_, runtimeOk := d.GetOk("runtime")
+// lambdaFunctionPackageTypeAttributes are the attributes that are required (true) or
+// must not be set (false) for each package type.
+var lambdaFunctionPackageTypeAttributes = map[string]map[string]bool{
+	lambda.PackageTypeImage: {
+		"image_uri":         true,
+		"filename":          false,
+		"handler":           false,
+		"layers":            false,
+		"runtime":           false,
+		"s3_bucket":         false,
+		"s3_key":            false,
+		"s3_object_version": false,
+	},
+	lambda.PackageTypeZip: {
+		"handler":      true,
+		"runtime":      true,
+		"image_config": false,
+		"image_uri":    false,
+	},
+}
+
+// checkPackageTypeForLambdaFunction validates the attributes against package_type, so that
+// switching the package type reports every attribute to change before the function is replaced.
+func checkPackageTypeForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	packageType := d.Get("package_type").(string)
+	configured := make(map[string]bool)
+
+	for k := range lambdaFunctionPackageTypeAttributes[packageType] {
+		// A value that is not yet known is still configured.
+		_, ok := d.GetOk(k)
+		configured[k] = ok || !d.NewValueKnown(k)
+	}
+
+	return lambdaFunctionPackageTypeError(packageType, configured)
+}
+
+// lambdaFunctionPackageTypeError returns an error naming the attributes that are missing
+// or must be removed for the package type, given which attributes are configured.
+func lambdaFunctionPackageTypeError(packageType string, configured map[string]bool) error {
+	var missing, conflicting []string
+
+	for k, required := range lambdaFunctionPackageTypeAttributes[packageType] {
+		switch {
+		case required && !configured[k]:
+			missing = append(missing, k)
+		case !required && configured[k]:
+			conflicting = append(conflicting, k)
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(conflicting)
+
+	var errs []string
+
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Sprintf("%s must be set", strings.Join(missing, ", ")))
+	}
+
+	if len(conflicting) > 0 {
+		errs = append(errs, fmt.Sprintf("%s must be removed", strings.Join(conflicting, ", ")))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("when package_type is %s, %s", packageType, strings.Join(errs, " and "))
+}
+
+// checkEnvironmentForLambdaFunction rejects environment variables that are set in both
+// variables and sensitive_variables, as they are merged into a single Lambda environment.
+func checkEnvironmentForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("environment") {
+		return nil
+	}
+
+	return lambdaFunctionEnvironmentError(d.Get("environment").([]interface{}))
+}
+
+// lambdaFunctionEnvironmentError returns an error naming the environment variables
+// that are set in both variables and sensitive_variables.
+func lambdaFunctionEnvironmentError(l []interface{}) error {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	tfMap := l[0].(map[string]interface{})
+	variables, _ := tfMap["variables"].(map[string]interface{})
+	sensitiveVariables, _ := tfMap["sensitive_variables"].(map[string]interface{})
+
+	var duplicates []string
+
+	for k := range sensitiveVariables {
+		if _, ok := variables[k]; ok {
+			duplicates = append(duplicates, k)
+		}
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+
+	return fmt.Errorf("environment variables %s cannot be set in both variables and sensitive_variables", strings.Join(duplicates, ", "))
+}
+
+// checkCodeSigningConfigForLambdaFunction rejects code signing for container images,
+// which Lambda does not support and would otherwise only reject when deploying.
+func checkCodeSigningConfigForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// A code signing config that is not yet created is still configured.
+	if d.Get("code_signing_config_arn").(string) == "" && d.NewValueKnown("code_signing_config_arn") {
+		return nil
+	}
+
+	if d.Get("package_type").(string) == lambda.PackageTypeImage {
+		return fmt.Errorf("code_signing_config_arn cannot be set when package_type is %s, code signing only applies to .zip file archives", lambda.PackageTypeImage)
+	}
+
+	return nil
+}
+
+// lambdaFunctionDeprecatedRuntimes are runtimes that Lambda no longer accepts for
+// new functions. lambda.Runtime_Values() still contains these identifiers.
+var lambdaFunctionDeprecatedRuntimes = map[string]struct{}{
+	lambda.RuntimeDotnetcore10: {},
+	lambda.RuntimeDotnetcore20: {},
+	lambda.RuntimeNodejs:       {},
+	lambda.RuntimeNodejs10X:    {},
+	lambda.RuntimeNodejs43:     {},
+	lambda.RuntimeNodejs43Edge: {},
+	lambda.RuntimeNodejs610:    {},
+	lambda.RuntimeNodejs810:    {},
+	lambda.RuntimePython27:     {},
+}
+
+// lambdaFunctionTrimSpaceStateFunc removes surrounding whitespace, so that cosmetic
+// differences neither update the function nor publish a new version.
+func lambdaFunctionTrimSpaceStateFunc(v interface{}) string {
+	return strings.TrimSpace(v.(string))
+}
+
+// suppressLambdaFunctionLayersReorder suppresses layers diffs that only reorder the
+// layers when ignore_layer_order is set. Layer order is the merge order of their contents.
+func suppressLambdaFunctionLayersReorder(k, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("ignore_layer_order").(bool) {
+		return false
+	}
+
+	o, n := d.GetChange("layers")
+
+	return lambdaFunctionLayersEquivalent(o.([]interface{}), n.([]interface{}))
+}
+
+// lambdaFunctionLayersEquivalent returns whether two layers lists contain the same
+// layer version ARNs, ignoring order.
+func lambdaFunctionLayersEquivalent(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+
+	for _, v := range a {
+		counts[v.(string)]++
+	}
+
+	for _, v := range b {
+		counts[v.(string)]--
+
+		if counts[v.(string)] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lambdaFunctionTrimSpaceValidateFunc(f schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		return f(lambdaFunctionTrimSpaceStateFunc(v), k)
+	}
+}
+
+// lambdaFunctionTracingModeStateFunc returns the tracing mode in the case used by the API.
+func lambdaFunctionTracingModeStateFunc(v interface{}) string {
+	mode := strings.TrimSpace(v.(string))
+
+	for _, m := range []string{lambda.TracingModeActive, lambda.TracingModePassThrough} {
+		if strings.EqualFold(mode, m) {
+			return m
+		}
+	}
+
+	return mode
+}
+
+func isLambdaFunctionDeprecatedRuntime(runtime string) bool {
+	_, ok := lambdaFunctionDeprecatedRuntimes[runtime]
+	return ok
+}
+
+func checkRuntimeForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("runtime") || !d.NewValueKnown("runtime") {
+		return nil
+	}
+
+	runtime := strings.TrimSpace(d.Get("runtime").(string))
+
+	if runtime == "" {
+		return nil
+	}
 
-	if packageType == lambda.PackageTypeZip && !handlerOk && !runtimeOk {
-		return fmt.Errorf("handler and runtime must be set when PackageType is Zip")
+	if d.Get("package_type").(string) == lambda.PackageTypeImage {
+		return fmt.Errorf("runtime cannot be set when package_type is %s", lambda.PackageTypeImage)
 	}
+
+	if !isLambdaFunctionDeprecatedRuntime(runtime) {
+		return nil
+	}
+
+	if d.Id() == "" {
+		return fmt.Errorf("runtime %s is deprecated and cannot be used to create new Lambda Functions", runtime)
+	}
+
+	// Existing functions may legitimately still run a deprecated runtime.
+	log.Printf("[WARN] Lambda Function (%s) runtime %s is deprecated", d.Id(), runtime)
+
+	return nil
+}
+
+// checkFilenameForLambdaFunction rejects a missing deployment package before a
+// new version would be published from it.
+func checkFilenameForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("publish").(bool) || !d.NewValueKnown("filename") {
+		return nil
+	}
+
+	filename := d.Get("filename").(string)
+
+	if filename == "" || (d.Id() != "" && !needsFunctionCodeUpdate(d)) {
+		return nil
+	}
+
+	return lambdaFunctionFilenameError(filename)
+}
+
+// lambdaFunctionFilenameError returns an error if the deployment package file does not exist.
+func lambdaFunctionFilenameError(v string) error {
+	filename, err := homedir.Expand(v)
+
+	if err != nil {
+		return fmt.Errorf("filename (%s): %w", v, err)
+	}
+
+	fi, err := os.Stat(filename)
+
+	if os.IsNotExist(err) {
+		return fmt.Errorf("filename (%s) does not exist; the deployment package must exist before a version can be published", v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("filename (%s): %w", v, err)
+	}
+
+	if fi.IsDir() {
+		return fmt.Errorf("filename (%s) is a directory, expected a deployment package file", v)
+	}
+
 	return nil
 }
 
+func checkReservedConcurrencyForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("reserved_concurrent_executions") || !d.NewValueKnown("reserved_concurrent_executions") {
+		return nil
+	}
+
+	o, n := d.GetChange("reserved_concurrent_executions")
+
+	if n.(int) <= 0 {
+		return nil
+	}
+
+	output, err := meta.(*AWSClient).lambdaconn.GetAccountSettings(&lambda.GetAccountSettingsInput{})
+
+	// The limit is enforced at apply time regardless, so don't fail the plan
+	// if the account settings can't be read, e.g. due to missing permissions.
+	if err != nil {
+		log.Printf("[WARN] Unable to read Lambda account settings to check reserved_concurrent_executions: %s", err)
+		return nil
+	}
+
+	if output == nil || output.AccountLimit == nil || output.AccountLimit.UnreservedConcurrentExecutions == nil {
+		return nil
+	}
+
+	var current int64
+
+	// The function's current reservation is returned to the pool when it changes.
+	if d.Id() != "" && o.(int) > 0 {
+		current = int64(o.(int))
+	}
+
+	return lambdaFunctionReservedConcurrencyError(int64(n.(int)), current, aws.Int64Value(output.AccountLimit.UnreservedConcurrentExecutions))
+}
+
+// lambdaFunctionReservedConcurrencyError returns an error if reserving the specified
+// concurrency would leave the account with fewer unreserved concurrent executions than
+// Lambda allows. current is the function's existing reservation, if any.
+func lambdaFunctionReservedConcurrencyError(reserved, current, unreserved int64) error {
+	available := unreserved + current - lambdaFunctionMinimumUnreservedConcurrentExecutions
+
+	if available < 0 {
+		available = 0
+	}
+
+	if reserved > available {
+		return fmt.Errorf("reserved_concurrent_executions (%d) exceeds the %d concurrent executions available to reserve; the account has %d unreserved concurrent executions and Lambda requires at least %d to remain unreserved", reserved, available, unreserved+current, lambdaFunctionMinimumUnreservedConcurrentExecutions)
+	}
+
+	return nil
+}
+
+// lambdaFunctionHandlerAndRuntime returns the handler and runtime to store in state.
+// Both are always empty for container image functions, so that values planned for a
+// replaced .zip function don't linger in state.
+func lambdaFunctionHandlerAndRuntime(function *lambda.FunctionConfiguration) (string, string) {
+	if aws.StringValue(function.PackageType) == lambda.PackageTypeImage {
+		return "", ""
+	}
+
+	return aws.StringValue(function.Handler), aws.StringValue(function.Runtime)
+}
+
+// lambdaFunctionCodeDrifted returns whether the function's current code differs
+// from the code last deployed by Terraform.
+func lambdaFunctionCodeDrifted(deployedCodeSha256, codeSha256 string) bool {
+	if deployedCodeSha256 == "" || codeSha256 == "" {
+		return false
+	}
+
+	return !lambdaFunctionSourceCodeHashEquivalent(deployedCodeSha256, codeSha256)
+}
+
+// lambdaFunctionSourceCodeHash returns the source_code_hash to store in state. A
+// user-provided hash, e.g. of an S3 object or an image digest, is kept as configured.
+// A hash that was read from the function's previous CodeSha256 follows the code.
+func lambdaFunctionSourceCodeHash(sourceCodeHash, previousCodeSha256, codeSha256 string) string {
+	if sourceCodeHash == "" || sourceCodeHash == previousCodeSha256 {
+		return codeSha256
+	}
+
+	return sourceCodeHash
+}
+
+// lambdaFunctionSourceCodeHashEquivalent returns whether a source_code_hash
+// identifies the same code as a function's CodeSha256. Container image digests
+// may be supplied with or without their "sha256:" prefix.
+func lambdaFunctionSourceCodeHashEquivalent(sourceCodeHash, codeSha256 string) bool {
+	return strings.TrimPrefix(sourceCodeHash, "sha256:") == strings.TrimPrefix(codeSha256, "sha256:")
+}
+
+func checkCodeDriftForLambdaFunction(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("detect_code_drift").(bool) {
+		return nil
+	}
+
+	// Code changes in configuration already redeploy the configured artifact.
+	if needsFunctionCodeUpdate(d) {
+		return nil
+	}
+
+	if !lambdaFunctionCodeDrifted(d.Get("deployed_code_sha256").(string), d.Get("code_sha256").(string)) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Lambda Function (%s) code was deployed outside of Terraform, redeploying configured code", d.Id())
+
+	// Leaves source_code_hash as configured.
+	return d.SetNewComputed("code_sha256")
+}
+
 func updateComputedAttributesOnPublish(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
 	configChanged := hasConfigChanges(d)
 	functionCodeUpdated := needsFunctionCodeUpdate(d)
 	if functionCodeUpdated {
 		d.SetNewComputed("last_modified")
+		d.SetNewComputed("code_updated_at")
+		d.SetNewComputed("code_sha256")
+		d.SetNewComputed("qualified_code_sha256")
 	}
 
 	publish := d.Get("publish").(bool)
@@ -347,6 +801,8 @@ func updateComputedAttributesOnPublish(_ context.Context, d *schema.ResourceDiff
 	if publish && (configChanged || functionCodeUpdated || publishChanged) {
 		d.SetNewComputed("version")
 		d.SetNewComputed("qualified_arn")
+		d.SetNewComputed("qualified_invoke_arn")
+		d.SetNewComputed("qualified_code_sha256")
 	}
 	return nil
 }
@@ -391,15 +847,11 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 
 	var functionCode *lambda.FunctionCode
 	if hasFilename {
-		// Grab an exclusive lock so that we're only reading one function into
-		// memory at a time.
-		// See https://github.com/hashicorp/terraform/issues/9364
-		awsMutexKV.Lock(awsMutexLambdaKey)
-		defer awsMutexKV.Unlock(awsMutexLambdaKey)
-		file, err := loadFileContent(filename.(string))
+		file, release, err := lambdaFunctionFileContentLimiter.Load(filename.(string))
 		if err != nil {
 			return fmt.Errorf("Unable to load %q: %w", filename.(string), err)
 		}
+		defer release()
 		functionCode = &lambda.FunctionCode{
 			ZipFile: file,
 		}
@@ -424,8 +876,8 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 	handler, handlerOk := d.GetOk("handler")
 	runtime, runtimeOk := d.GetOk("runtime")
 
-	if packageType == lambda.PackageTypeZip && !handlerOk && !runtimeOk {
-		return errors.New("handler and runtime must be set when PackageType is Zip")
+	if packageType == lambda.PackageTypeZip && (!handlerOk || !runtimeOk) {
+		return errors.New("handler and runtime must be set when package_type is Zip")
 	}
 
 	params := &lambda.CreateFunctionInput{
@@ -440,8 +892,8 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if packageType == lambda.PackageTypeZip {
-		params.Handler = aws.String(handler.(string))
-		params.Runtime = aws.String(runtime.(string))
+		params.Handler = aws.String(lambdaFunctionTrimSpaceStateFunc(handler))
+		params.Runtime = aws.String(lambdaFunctionTrimSpaceStateFunc(runtime))
 	}
 
 	if v, ok := d.GetOk("code_signing_config_arn"); ok {
@@ -459,10 +911,7 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 			if dlcMaps[0] == nil {
 				return fmt.Errorf("Nil dead_letter_config supplied for function: %s", functionName)
 			}
-			dlcMap := dlcMaps[0].(map[string]interface{})
-			params.DeadLetterConfig = &lambda.DeadLetterConfig{
-				TargetArn: aws.String(dlcMap["target_arn"].(string)),
-			}
+			params.DeadLetterConfig = expandLambdaDeadLetterConfig(dlcMaps)
 		}
 	}
 
@@ -487,24 +936,18 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 		tracingConfig := v.([]interface{})
 		tracing := tracingConfig[0].(map[string]interface{})
 		params.TracingConfig = &lambda.TracingConfig{
-			Mode: aws.String(tracing["mode"].(string)),
+			Mode: aws.String(lambdaFunctionTracingModeStateFunc(tracing["mode"])),
 		}
 	}
 
 	if v, ok := d.GetOk("environment"); ok {
-		environments := v.([]interface{})
-		environment, ok := environments[0].(map[string]interface{})
-		if !ok {
-			return errors.New("At least one field is expected inside environment")
-		}
+		environment, err := expandLambdaEnvironment(v.([]interface{}))
 
-		if environmentVariables, ok := environment["variables"]; ok {
-			variables := readEnvironmentVariables(environmentVariables.(map[string]interface{}))
-
-			params.Environment = &lambda.Environment{
-				Variables: aws.StringMap(variables),
-			}
+		if err != nil {
+			return err
 		}
+
+		params.Environment = environment
 	}
 
 	if v, ok := d.GetOk("kms_key_arn"); ok {
@@ -570,11 +1013,20 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.SetId(d.Get("function_name").(string))
+	d.Set("code_updated_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := waitForLambdaFunctionCreation(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("error waiting for Lambda Function (%s) creation: %w", d.Id(), err)
 	}
 
+	// CreateFunction can return before the code signing policy is in effect,
+	// letting an unsigned artifact through on the first deployment.
+	if v, ok := d.GetOk("code_signing_config_arn"); ok {
+		if err := verifyLambdaFunctionCodeSigning(conn, d.Id(), v.(string)); err != nil {
+			return err
+		}
+	}
+
 	if reservedConcurrentExecutions >= 0 {
 
 		log.Printf("[DEBUG] Setting Concurrency to %d for the Lambda Function %s", reservedConcurrentExecutions, functionName)
@@ -662,7 +1114,9 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting function description for Lambda Function: %s", err)
 	}
 
-	if err := d.Set("handler", function.Handler); err != nil {
+	handler, runtime := lambdaFunctionHandlerAndRuntime(function)
+
+	if err := d.Set("handler", handler); err != nil {
 		return fmt.Errorf("Error setting handler for Lambda Function: %s", err)
 	}
 
@@ -678,7 +1132,7 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting role for Lambda Function: %s", err)
 	}
 
-	if err := d.Set("runtime", function.Runtime); err != nil {
+	if err := d.Set("runtime", runtime); err != nil {
 		return fmt.Errorf("Error setting runtime for Lambda Function: %s", err)
 	}
 
@@ -690,9 +1144,22 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting KMS key arn for Lambda Function: %s", err)
 	}
 
-	if err := d.Set("source_code_hash", function.CodeSha256); err != nil {
+	// The code_sha256 in state, also while it is planned to change on apply.
+	previousCodeSha256, _ := d.GetChange("code_sha256")
+
+	if err := d.Set("source_code_hash", lambdaFunctionSourceCodeHash(d.Get("source_code_hash").(string), previousCodeSha256.(string), aws.StringValue(function.CodeSha256))); err != nil {
 		return fmt.Errorf("Error setting CodeSha256 for Lambda Function: %s", err)
 	}
+	d.Set("code_sha256", function.CodeSha256)
+
+	// Record the deployed code on first read, e.g. after create or import.
+	if d.Get("deployed_code_sha256").(string) == "" {
+		d.Set("deployed_code_sha256", function.CodeSha256)
+	}
+
+	if d.Get("detect_code_drift").(bool) && lambdaFunctionCodeDrifted(d.Get("deployed_code_sha256").(string), aws.StringValue(function.CodeSha256)) {
+		log.Printf("[WARN] Lambda Function (%s) code (%s) differs from code last deployed by Terraform (%s)", d.Id(), aws.StringValue(function.CodeSha256), d.Get("deployed_code_sha256").(string))
+	}
 
 	if err := d.Set("source_code_size", function.CodeSize); err != nil {
 		return fmt.Errorf("Error setting code size for Lambda Function: %s", err)
@@ -733,6 +1200,9 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 
 	layers := flattenLambdaLayers(function.Layers)
 	log.Printf("[INFO] Setting Lambda %s Layers %#v from API", d.Id(), layers)
+	if v, ok := d.GetOk("layers"); ok && !lambdaFunctionLayersEquivalent(v.([]interface{}), layers) {
+		log.Printf("[WARN] Lambda Function (%s) layers changed outside of Terraform from %v to %v", d.Id(), v, layers)
+	}
 	if err := d.Set("layers", layers); err != nil {
 		return fmt.Errorf("Error setting layers for Lambda Function (%s): %w", d.Id(), err)
 	}
@@ -743,20 +1213,14 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting vpc_config for Lambda Function (%s): %w", d.Id(), err)
 	}
 
-	environment := flattenLambdaEnvironment(function.Environment)
-	log.Printf("[INFO] Setting Lambda %s environment %#v from API", d.Id(), environment)
+	// Variable values are not logged as they may be sensitive.
+	environment := flattenLambdaFunctionEnvironment(function.Environment, lambdaFunctionSensitiveEnvironmentKeys(d.Get("environment").([]interface{})))
 	if err := d.Set("environment", environment); err != nil {
 		log.Printf("[ERR] Error setting environment for Lambda Function (%s): %s", d.Id(), err)
 	}
 
-	if function.DeadLetterConfig != nil && function.DeadLetterConfig.TargetArn != nil {
-		d.Set("dead_letter_config", []interface{}{
-			map[string]interface{}{
-				"target_arn": *function.DeadLetterConfig.TargetArn,
-			},
-		})
-	} else {
-		d.Set("dead_letter_config", []interface{}{})
+	if err := d.Set("dead_letter_config", flattenLambdaDeadLetterConfig(function.DeadLetterConfig)); err != nil {
+		return fmt.Errorf("error setting dead_letter_config for Lambda Function (%s): %w", d.Id(), err)
 	}
 
 	// Assume `PassThrough` on partitions that don't support tracing config
@@ -770,35 +1234,27 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 		},
 	})
 
-	// Get latest version and ARN unless qualifier is specified via data source
-	if qualifierExistance {
-		d.Set("version", function.Version)
-		d.Set("qualified_arn", function.FunctionArn)
-	} else {
-
-		// List is sorted from oldest to latest
-		// so this may get costly over time :'(
-		var lastVersion, lastQualifiedArn string
-		err = listVersionsByFunctionPages(conn, &lambda.ListVersionsByFunctionInput{
-			FunctionName: function.FunctionName,
-			MaxItems:     aws.Int64(10000),
-		}, func(p *lambda.ListVersionsByFunctionOutput, lastPage bool) bool {
-			if lastPage {
-				last := p.Versions[len(p.Versions)-1]
-				lastVersion = *last.Version
-				lastQualifiedArn = *last.FunctionArn
-				return false
-			}
-			return true
-		})
+	// Get latest version and ARN unless qualifier is specified via data source.
+	// All version attributes are derived from a single function configuration
+	// so that they never refer to different versions.
+	versionConfig := function
+	if !qualifierExistance {
+		versionConfig, err = lambdaFunctionLatestVersion(conn, aws.StringValue(function.FunctionName))
 		if err != nil {
 			return err
 		}
+	}
 
-		d.Set("version", lastVersion)
-		d.Set("qualified_arn", lastQualifiedArn)
+	version, qualifiedArn, err := lambdaFunctionVersionAttributes(versionConfig, !qualifierExistance)
+	if err != nil {
+		return fmt.Errorf("error reading Lambda Function (%s) version: %w", d.Id(), err)
 	}
 
+	d.Set("version", version)
+	d.Set("qualified_arn", qualifiedArn)
+	d.Set("qualified_invoke_arn", lambdaFunctionInvokeArn(qualifiedArn, meta))
+	d.Set("qualified_code_sha256", versionConfig.CodeSha256)
+
 	invokeArn := lambdaFunctionInvokeArn(*function.FunctionArn, meta)
 	d.Set("invoke_arn", invokeArn)
 
@@ -816,15 +1272,13 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 	// Code Signing is only supported on zip packaged lambda functions.
 	if *function.PackageType == lambda.PackageTypeZip {
 		getCodeSigningConfigOutput, err := conn.GetFunctionCodeSigningConfig(codeSigningConfigInput)
+		codeSigningConfigArn, err := lambdaFunctionCodeSigningConfigArn(d.Id(), getCodeSigningConfigOutput, err, d.Get("code_signing_config_arn").(string))
+
 		if err != nil {
 			return fmt.Errorf("error getting Lambda Function (%s) code signing config %w", d.Id(), err)
 		}
 
-		if getCodeSigningConfigOutput == nil || getCodeSigningConfigOutput.CodeSigningConfigArn == nil {
-			d.Set("code_signing_config_arn", "")
-		} else {
-			d.Set("code_signing_config_arn", getCodeSigningConfigOutput.CodeSigningConfigArn)
-		}
+		d.Set("code_signing_config_arn", codeSigningConfigArn)
 	} else {
 		d.Set("code_signing_config_arn", "")
 	}
@@ -832,14 +1286,52 @@ func resourceAwsLambdaFunctionRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func listVersionsByFunctionPages(c *lambda.Lambda, input *lambda.ListVersionsByFunctionInput,
-	fn func(p *lambda.ListVersionsByFunctionOutput, lastPage bool) bool) error {
-	for {
-		page, err := c.ListVersionsByFunction(input)
-		if err != nil {
-			return err
-		}
-		lastPage := page.NextMarker == nil
+// lambdaFunctionVersionAttributes returns the version and qualified ARN of a function
+// configuration. When checkArn is set, the qualified ARN must end in the version,
+// as it does for configurations returned by ListVersionsByFunction.
+func lambdaFunctionVersionAttributes(function *lambda.FunctionConfiguration, checkArn bool) (string, string, error) {
+	if function == nil {
+		return "", "", fmt.Errorf("no function version found")
+	}
+
+	version := aws.StringValue(function.Version)
+	qualifiedArn := aws.StringValue(function.FunctionArn)
+
+	if checkArn && !strings.HasSuffix(qualifiedArn, ":"+version) {
+		return "", "", fmt.Errorf("version (%s) does not match qualified ARN (%s)", version, qualifiedArn)
+	}
+
+	return version, qualifiedArn, nil
+}
+
+// lambdaFunctionLatestVersion returns the configuration of the latest published
+// version of a function, or of $LATEST if no version has been published.
+// Versions can be deleted, so a missing version number does not mean that no later
+// version exists, and all versions are listed.
+func lambdaFunctionLatestVersion(conn *lambda.Lambda, functionName string) (*lambda.FunctionConfiguration, error) {
+	// List is sorted from oldest to latest.
+	var latest *lambda.FunctionConfiguration
+	err := listVersionsByFunctionPages(conn, &lambda.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+		MaxItems:     aws.Int64(10000),
+	}, func(p *lambda.ListVersionsByFunctionOutput, lastPage bool) bool {
+		if n := len(p.Versions); n > 0 {
+			latest = p.Versions[n-1]
+		}
+		return !lastPage
+	})
+
+	return latest, err
+}
+
+func listVersionsByFunctionPages(c *lambda.Lambda, input *lambda.ListVersionsByFunctionInput,
+	fn func(p *lambda.ListVersionsByFunctionOutput, lastPage bool) bool) error {
+	for {
+		page, err := c.ListVersionsByFunction(input)
+		if err != nil {
+			return err
+		}
+		lastPage := page.NextMarker == nil
 
 		shouldContinue := fn(page, lastPage)
 		if !shouldContinue || lastPage {
@@ -871,17 +1363,110 @@ func resourceAwsLambdaFunctionDelete(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("error deleting Lambda Function (%s): %w", d.Id(), err)
 	}
 
+	if !d.Get("wait_for_network_interfaces").(bool) {
+		return nil
+	}
+
+	securityGroupIDs := expandStringSet(d.Get("vpc_config.0.security_group_ids").(*schema.Set))
+	subnetIDs := expandStringSet(d.Get("vpc_config.0.subnet_ids").(*schema.Set))
+
+	if len(securityGroupIDs) == 0 || len(subnetIDs) == 0 {
+		return nil
+	}
+
+	err = waitForLambdaFunctionNetworkInterfacesDeleted(meta.(*AWSClient).ec2conn, d.Get("function_name").(string), securityGroupIDs, subnetIDs, d.Timeout(schema.TimeoutDelete))
+
+	if err != nil {
+		return fmt.Errorf("error waiting for Lambda Function (%s) network interfaces to be deleted: %w", d.Id(), err)
+	}
+
 	return nil
 }
 
+// waitForLambdaFunctionNetworkInterfacesDeleted waits until the Lambda ENIs created for a
+// deleted function no longer use the security groups and subnets of its VPC configuration.
+// Lambda names the ENI after the function that it was created for.
+func waitForLambdaFunctionNetworkInterfacesDeleted(conn *ec2.EC2, functionName string, securityGroupIDs, subnetIDs []*string, timeout time.Duration) error {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"attachment.instance-owner-id": "amazon-aws",
+			"description":                  "AWS Lambda VPC ENI-" + functionName,
+		}),
+	}
+
+	input.Filters = append(input.Filters, &ec2.Filter{Name: aws.String("subnet-id"), Values: subnetIDs})
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.NetworkInterfaceStatusInUse},
+		Target:  []string{lambdaFunctionNetworkInterfacesDeleted},
+		Refresh: func() (interface{}, string, error) {
+			output, err := conn.DescribeNetworkInterfaces(input)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			if networkInterfaces := lambdaFunctionNetworkInterfaces(output.NetworkInterfaces, securityGroupIDs); len(networkInterfaces) > 0 {
+				return networkInterfaces, ec2.NetworkInterfaceStatusInUse, nil
+			}
+
+			return output, lambdaFunctionNetworkInterfacesDeleted, nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+
+	return err
+}
+
+// lambdaFunctionNetworkInterfaces returns the network interfaces that use all of a
+// function's security groups. Each filter of DescribeNetworkInterfaces matches any
+// of its values, so it cannot express this.
+func lambdaFunctionNetworkInterfaces(networkInterfaces []*ec2.NetworkInterface, securityGroupIDs []*string) []*ec2.NetworkInterface {
+	var result []*ec2.NetworkInterface
+
+	for _, networkInterface := range networkInterfaces {
+		if networkInterface == nil {
+			continue
+		}
+
+		groups := make(map[string]bool, len(networkInterface.Groups))
+
+		for _, group := range networkInterface.Groups {
+			groups[aws.StringValue(group.GroupId)] = true
+		}
+
+		usesAll := true
+
+		for _, securityGroupID := range securityGroupIDs {
+			if !groups[aws.StringValue(securityGroupID)] {
+				usesAll = false
+				break
+			}
+		}
+
+		if usesAll {
+			result = append(result, networkInterface)
+		}
+	}
+
+	return result
+}
+
+// needsFunctionCodeUpdate returns whether the function's code must be redeployed.
+// A source_code_hash change alone redeploys S3 objects and image tags whose
+// content changed under the same s3_key or image_uri.
 func needsFunctionCodeUpdate(d resourceDiffer) bool {
 	return d.HasChange("filename") ||
 		d.HasChange("source_code_hash") ||
 		d.HasChange("s3_bucket") ||
 		d.HasChange("s3_key") ||
 		d.HasChange("s3_object_version") ||
-		d.HasChange("image_uri")
-
+		d.HasChange("image_uri") ||
+		d.HasChange("code_sha256")
 }
 
 // resourceAwsLambdaFunctionUpdate maps to:
@@ -889,8 +1474,10 @@ func needsFunctionCodeUpdate(d resourceDiffer) bool {
 func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).lambdaconn
 
-	// If Code Signing Config is updated, calls PutFunctionCodeSigningConfig
-	// If removed, calls DeleteFunctionCodeSigningConfig
+	// If Code Signing Config is updated, calls PutFunctionCodeSigningConfig before
+	// any code update, so that the new configuration applies to the new code.
+	// If removed, calls DeleteFunctionCodeSigningConfig after any code update, so
+	// that the new code is still deployed under the current configuration.
 	if d.HasChange("code_signing_config_arn") {
 		if v, ok := d.GetOk("code_signing_config_arn"); ok {
 			configUpdateInput := &lambda.PutFunctionCodeSigningConfigInput{
@@ -903,16 +1490,6 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 			if err != nil {
 				return fmt.Errorf("error updating code signing config arn (Function: %s): %s", d.Id(), err)
 			}
-		} else {
-			configDeleteInput := &lambda.DeleteFunctionCodeSigningConfigInput{
-				FunctionName: aws.String(d.Id()),
-			}
-
-			_, err := conn.DeleteFunctionCodeSigningConfig(configDeleteInput)
-
-			if err != nil {
-				return fmt.Errorf("error deleting code signing config arn (Function: %s): %s", d.Id(), err)
-			}
 		}
 	}
 
@@ -934,7 +1511,7 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("handler") {
-		configReq.Handler = aws.String(d.Get("handler").(string))
+		configReq.Handler = aws.String(lambdaFunctionTrimSpaceStateFunc(d.Get("handler")))
 	}
 	if d.HasChange("file_system_config") {
 		configReq.FileSystemConfigs = make([]*lambda.FileSystemConfig, 0)
@@ -943,10 +1520,8 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 	if d.HasChange("image_config") {
-		configReq.ImageConfig = &lambda.ImageConfig{}
-		if v, ok := d.GetOk("image_config"); ok && len(v.([]interface{})) > 0 {
-			configReq.ImageConfig = expandLambdaImageConfigs(v.([]interface{}))
-		}
+		o, n := d.GetChange("image_config")
+		configReq.ImageConfig = expandLambdaImageConfigsForUpdate(o.([]interface{}), n.([]interface{}))
 	}
 	if d.HasChange("memory_size") {
 		configReq.MemorySize = aws.Int64(int64(d.Get("memory_size").(int)))
@@ -965,21 +1540,14 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 		configReq.Layers = expandStringList(layers)
 	}
 	if d.HasChange("dead_letter_config") {
-		dlcMaps := d.Get("dead_letter_config").([]interface{})
-		configReq.DeadLetterConfig = &lambda.DeadLetterConfig{
-			TargetArn: aws.String(""),
-		}
-		if len(dlcMaps) == 1 { // Schema guarantees either 0 or 1
-			dlcMap := dlcMaps[0].(map[string]interface{})
-			configReq.DeadLetterConfig.TargetArn = aws.String(dlcMap["target_arn"].(string))
-		}
+		configReq.DeadLetterConfig = expandLambdaDeadLetterConfig(d.Get("dead_letter_config").([]interface{}))
 	}
 	if d.HasChange("tracing_config") {
 		tracingConfig := d.Get("tracing_config").([]interface{})
 		if len(tracingConfig) == 1 { // Schema guarantees either 0 or 1
 			config := tracingConfig[0].(map[string]interface{})
 			configReq.TracingConfig = &lambda.TracingConfig{
-				Mode: aws.String(config["mode"].(string)),
+				Mode: aws.String(lambdaFunctionTracingModeStateFunc(config["mode"])),
 			}
 		}
 	}
@@ -996,31 +1564,26 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("runtime") {
-		configReq.Runtime = aws.String(d.Get("runtime").(string))
+		configReq.Runtime = aws.String(lambdaFunctionTrimSpaceStateFunc(d.Get("runtime")))
 	}
 	if d.HasChange("environment") {
 		if v, ok := d.GetOk("environment"); ok {
-			environments := v.([]interface{})
-			environment, ok := environments[0].(map[string]interface{})
-			if !ok {
-				return errors.New("At least one field is expected inside environment")
-			}
-
-			if environmentVariables, ok := environment["variables"]; ok {
-				variables := readEnvironmentVariables(environmentVariables.(map[string]interface{}))
+			environment, err := expandLambdaEnvironment(v.([]interface{}))
 
-				configReq.Environment = &lambda.Environment{
-					Variables: aws.StringMap(variables),
-				}
+			if err != nil {
+				return err
 			}
+
+			configReq.Environment = environment
 		} else {
 			configReq.Environment = &lambda.Environment{
 				Variables: aws.StringMap(map[string]string{}),
 			}
 		}
 	}
-	configUpdate := hasConfigChanges(d)
-	if configUpdate {
+	plan := planLambdaFunctionUpdate(hasConfigChanges(d), needsFunctionCodeUpdate(d), d.Get("publish").(bool), d.HasChange("publish"))
+
+	if plan.updateConfiguration {
 		log.Printf("[DEBUG] Send Update Lambda Function Configuration request: %#v", configReq)
 
 		// IAM changes can take 1 minute to propagate in AWS
@@ -1045,6 +1608,13 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 					log.Printf("[DEBUG] Received %s, retrying CreateFunction", err)
 					return resource.RetryableError(err)
 				}
+				if lambdaFunctionConflictFromError(err) == lambdaFunctionConflictUpdateInProgress {
+					log.Printf("[DEBUG] Received %s, waiting for in-progress update before retrying UpdateFunctionConfiguration", err)
+					if err := waitForLambdaFunctionUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+						return resource.NonRetryableError(err)
+					}
+					return resource.RetryableError(err)
+				}
 
 				return resource.NonRetryableError(err)
 			}
@@ -1052,7 +1622,7 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 		})
 		if err != nil {
 			if !isAWSErr(err, "InvalidParameterValueException", "Your request has been throttled by EC2, please make sure you have enough API rate limit.") {
-				return fmt.Errorf("Error modifying Lambda Function (%s) configuration : %w", d.Id(), err)
+				return fmt.Errorf("Error modifying Lambda Function (%s) configuration : %w", d.Id(), lambdaFunctionConflictError(err))
 			}
 			// Allow 9 more minutes for EC2 throttling
 			err := resource.Retry(9*time.Minute, func() *resource.RetryError {
@@ -1072,31 +1642,23 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 				_, err = conn.UpdateFunctionConfiguration(configReq)
 			}
 			if err != nil {
-				return fmt.Errorf("Error modifying Lambda Function Configuration %s: %w", d.Id(), err)
+				return fmt.Errorf("Error modifying Lambda Function Configuration %s: %w", d.Id(), lambdaFunctionConflictError(err))
 			}
 		}
-
-		if err := waitForLambdaFunctionUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
-			return fmt.Errorf("error waiting for Lambda Function (%s) update: %w", d.Id(), err)
-		}
 	}
 
-	codeUpdate := needsFunctionCodeUpdate(d)
-	if codeUpdate {
+	if plan.updateCode {
 		codeReq := &lambda.UpdateFunctionCodeInput{
 			FunctionName: aws.String(d.Id()),
+			Publish:      aws.Bool(plan.publishWithCode),
 		}
 
 		if v, ok := d.GetOk("filename"); ok {
-			// Grab an exclusive lock so that we're only reading one function into
-			// memory at a time.
-			// See https://github.com/hashicorp/terraform/issues/9364
-			awsMutexKV.Lock(awsMutexLambdaKey)
-			defer awsMutexKV.Unlock(awsMutexLambdaKey)
-			file, err := loadFileContent(v.(string))
+			file, release, err := lambdaFunctionFileContentLimiter.Load(v.(string))
 			if err != nil {
 				return fmt.Errorf("Unable to load %q: %w", v.(string), err)
 			}
+			defer release()
 			codeReq.ZipFile = file
 		} else if v, ok := d.GetOk("image_uri"); ok {
 			codeReq.ImageUri = aws.String(v.(string))
@@ -1114,9 +1676,62 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 
 		log.Printf("[DEBUG] Send Update Lambda Function Code request: %#v", codeReq)
 
-		_, err := conn.UpdateFunctionCode(codeReq)
+		var output *lambda.FunctionConfiguration
+		err := resource.Retry(lambdaFunctionUpdateConflictTimeout, func() *resource.RetryError {
+			var err error
+			output, err = conn.UpdateFunctionCode(codeReq)
+
+			if lambdaFunctionConflictFromError(err) == lambdaFunctionConflictUpdateInProgress {
+				log.Printf("[DEBUG] Received %s, waiting for in-progress update before retrying UpdateFunctionCode", err)
+				if err := waitForLambdaFunctionUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+					return resource.NonRetryableError(err)
+				}
+				return resource.RetryableError(err)
+			}
+
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			return nil
+		})
+		if isResourceTimeoutError(err) {
+			output, err = conn.UpdateFunctionCode(codeReq)
+		}
 		if err != nil {
-			return fmt.Errorf("error modifying Lambda Function (%s) Code: %w", d.Id(), err)
+			return fmt.Errorf("error modifying Lambda Function (%s) Code: %w", d.Id(), lambdaFunctionConflictError(err))
+		}
+
+		if output != nil {
+			d.Set("deployed_code_sha256", output.CodeSha256)
+
+			if plan.publishWithCode {
+				d.Set("version", output.Version)
+			}
+		}
+
+		// Only set here, never during Read, so that this only reflects code
+		// pushed by Terraform and not configuration updates or refreshes.
+		d.Set("code_updated_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	if plan.waitForUpdate {
+		if err := waitForLambdaFunctionUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for Lambda Function (%s) update: %w", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("code_signing_config_arn") {
+		if _, ok := d.GetOk("code_signing_config_arn"); !ok {
+			configDeleteInput := &lambda.DeleteFunctionCodeSigningConfigInput{
+				FunctionName: aws.String(d.Id()),
+			}
+
+			_, err := conn.DeleteFunctionCodeSigningConfig(configDeleteInput)
+
+			if err != nil {
+				return fmt.Errorf("error deleting code signing config arn (Function: %s): %s", d.Id(), err)
+			}
 		}
 	}
 
@@ -1148,21 +1763,79 @@ func resourceAwsLambdaFunctionUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	publish := d.Get("publish").(bool)
-	if publish && (codeUpdate || configUpdate || d.HasChange("publish")) {
+	if plan.publishVersion {
 		versionReq := &lambda.PublishVersionInput{
 			FunctionName: aws.String(d.Id()),
 		}
 
-		_, err := conn.PublishVersion(versionReq)
+		var output *lambda.FunctionConfiguration
+		err := resource.Retry(lambdaFunctionUpdateConflictTimeout, func() *resource.RetryError {
+			var err error
+			output, err = conn.PublishVersion(versionReq)
+
+			if conflict := lambdaFunctionConflictFromError(err); conflict == lambdaFunctionConflictUpdateInProgress || conflict == lambdaFunctionConflictPublishInProgress {
+				log.Printf("[DEBUG] Received %s, waiting for in-progress update before retrying PublishVersion", err)
+				if err := waitForLambdaFunctionUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+					return resource.NonRetryableError(err)
+				}
+				return resource.RetryableError(err)
+			}
+
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			return nil
+		})
+		if isResourceTimeoutError(err) {
+			output, err = conn.PublishVersion(versionReq)
+		}
 		if err != nil {
-			return fmt.Errorf("Error publishing Lambda Function (%s) version: %w", d.Id(), err)
+			return fmt.Errorf("Error publishing Lambda Function (%s) version: %w", d.Id(), lambdaFunctionConflictError(err))
 		}
+
+		d.Set("version", output.Version)
 	}
 
 	return resourceAwsLambdaFunctionRead(d, meta)
 }
 
+// lambdaFunctionUpdatePlan describes the mutating calls, and the waits
+// between them, needed to apply a Lambda Function update.
+type lambdaFunctionUpdatePlan struct {
+	updateConfiguration bool
+	updateCode          bool
+	// publishWithCode publishes the new version as part of UpdateFunctionCode.
+	publishWithCode bool
+	// waitForUpdate waits once, after the last configuration or code update.
+	waitForUpdate  bool
+	publishVersion bool
+}
+
+// planLambdaFunctionUpdate returns the update plan for the given changes.
+// UpdateFunctionCode retries on conflict until a preceding configuration update
+// has completed, so a single wait after the last update is sufficient, and a
+// version that includes both changes can be published by UpdateFunctionCode itself.
+func planLambdaFunctionUpdate(configUpdate, codeUpdate, publish, publishChange bool) lambdaFunctionUpdatePlan {
+	plan := lambdaFunctionUpdatePlan{
+		updateConfiguration: configUpdate,
+		updateCode:          codeUpdate,
+		waitForUpdate:       configUpdate || codeUpdate,
+	}
+
+	if !publish || !(configUpdate || codeUpdate || publishChange) {
+		return plan
+	}
+
+	if codeUpdate {
+		plan.publishWithCode = true
+	} else {
+		plan.publishVersion = true
+	}
+
+	return plan
+}
+
 // loadFileContent returns contents of a file in a given path
 func loadFileContent(v string) ([]byte, error) {
 	filename, err := homedir.Expand(v)
@@ -1176,6 +1849,72 @@ func loadFileContent(v string) ([]byte, error) {
 	return fileContent, nil
 }
 
+// lambdaFileContentLimiter bounds the total size of the files loaded by Load that are
+// in use at the same time. Files of different functions are loaded concurrently while
+// they fit in the limit. A file larger than the limit is loaded once nothing else is.
+type lambdaFileContentLimiter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	limit    int64
+	inFlight int64
+}
+
+func newLambdaFileContentLimiter(limit int64) *lambdaFileContentLimiter {
+	l := &lambdaFileContentLimiter{limit: limit}
+	l.cond = sync.NewCond(&l.mu)
+
+	return l
+}
+
+func (l *lambdaFileContentLimiter) acquire(size int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.inFlight > 0 && l.inFlight+size > l.limit {
+		l.cond.Wait()
+	}
+
+	l.inFlight += size
+}
+
+func (l *lambdaFileContentLimiter) release(size int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight -= size
+	l.cond.Broadcast()
+}
+
+// Load reads a file once its size fits in the limit. The returned function must be called
+// when the content is no longer used.
+func (l *lambdaFileContentLimiter) Load(v string) ([]byte, func(), error) {
+	filename, err := homedir.Expand(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	size := info.Size()
+	l.acquire(size)
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { l.release(size) })
+	}
+
+	fileContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	return fileContent, release, nil
+}
+
 func readEnvironmentVariables(ev map[string]interface{}) map[string]string {
 	variables := make(map[string]string)
 	for k, v := range ev {
@@ -1185,6 +1924,83 @@ func readEnvironmentVariables(ev map[string]interface{}) map[string]string {
 	return variables
 }
 
+// expandLambdaEnvironment merges variables and sensitive_variables into a single
+// Lambda environment.
+func expandLambdaEnvironment(l []interface{}) (*lambda.Environment, error) {
+	tfMap, ok := l[0].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("At least one field is expected inside environment")
+	}
+
+	if err := lambdaFunctionEnvironmentError(l); err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string)
+
+	for _, k := range []string{"variables", "sensitive_variables"} {
+		if v, ok := tfMap[k].(map[string]interface{}); ok {
+			for name, value := range readEnvironmentVariables(v) {
+				variables[name] = value
+			}
+		}
+	}
+
+	return &lambda.Environment{
+		Variables: aws.StringMap(variables),
+	}, nil
+}
+
+// lambdaFunctionSensitiveEnvironmentKeys returns the names of the variables
+// set in sensitive_variables.
+func lambdaFunctionSensitiveEnvironmentKeys(l []interface{}) map[string]bool {
+	keys := make(map[string]bool)
+
+	if len(l) == 0 || l[0] == nil {
+		return keys
+	}
+
+	if v, ok := l[0].(map[string]interface{})["sensitive_variables"].(map[string]interface{}); ok {
+		for k := range v {
+			keys[k] = true
+		}
+	}
+
+	return keys
+}
+
+// flattenLambdaFunctionEnvironment splits the Lambda environment into variables and
+// sensitive_variables, so that each variable is read back into the attribute it was
+// configured in. Variables not known to be sensitive, e.g. on import, are read into variables.
+func flattenLambdaFunctionEnvironment(lambdaEnv *lambda.EnvironmentResponse, sensitiveKeys map[string]bool) []interface{} {
+	if lambdaEnv == nil {
+		return nil
+	}
+
+	variables := make(map[string]string)
+	sensitiveVariables := make(map[string]string)
+
+	for k, v := range lambdaEnv.Variables {
+		if sensitiveKeys[k] {
+			sensitiveVariables[k] = aws.StringValue(v)
+		} else {
+			variables[k] = aws.StringValue(v)
+		}
+	}
+
+	tfMap := make(map[string]interface{})
+
+	if len(variables) > 0 {
+		tfMap["variables"] = variables
+	}
+
+	if len(sensitiveVariables) > 0 {
+		tfMap["sensitive_variables"] = sensitiveVariables
+	}
+
+	return []interface{}{tfMap}
+}
+
 func lambdaFunctionInvokeArn(functionArn string, meta interface{}) string {
 	return arn.ARN{
 		Partition: meta.(*AWSClient).partition,
@@ -1195,6 +2011,63 @@ func lambdaFunctionInvokeArn(functionArn string, meta interface{}) string {
 	}.String()
 }
 
+// Maximum amount of time to keep retrying an operation that conflicts with an
+// in-progress update of the same function, e.g. one started by another Terraform
+// configuration managing the same function name.
+const lambdaFunctionUpdateConflictTimeout = 5 * time.Minute
+
+type lambdaFunctionConflict string
+
+const (
+	lambdaFunctionConflictNone              lambdaFunctionConflict = ""
+	lambdaFunctionConflictUnknown           lambdaFunctionConflict = "unknown operation in progress"
+	lambdaFunctionConflictUpdateInProgress  lambdaFunctionConflict = "update in progress"
+	lambdaFunctionConflictDeleteInProgress  lambdaFunctionConflict = "function is being deleted"
+	lambdaFunctionConflictPublishInProgress lambdaFunctionConflict = "version publish in progress"
+)
+
+// classifyLambdaFunctionConflict returns the kind of operation that caused a
+// ResourceConflictException, based on the exception message.
+func classifyLambdaFunctionConflict(message string) lambdaFunctionConflict {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "an update is in progress"):
+		return lambdaFunctionConflictUpdateInProgress
+	case strings.Contains(message, "being deleted"), strings.Contains(message, "deletion is in progress"):
+		return lambdaFunctionConflictDeleteInProgress
+	case strings.Contains(message, "publish"):
+		return lambdaFunctionConflictPublishInProgress
+	}
+
+	return lambdaFunctionConflictUnknown
+}
+
+// lambdaFunctionConflictFromError returns the kind of conflict reported by err,
+// or lambdaFunctionConflictNone if err is not a ResourceConflictException.
+func lambdaFunctionConflictFromError(err error) lambdaFunctionConflict {
+	var awsErr awserr.Error
+
+	if !errors.As(err, &awsErr) || awsErr.Code() != lambda.ErrCodeResourceConflictException {
+		return lambdaFunctionConflictNone
+	}
+
+	return classifyLambdaFunctionConflict(awsErr.Message())
+}
+
+// lambdaFunctionConflictError annotates a ResourceConflictException with the
+// conflicting operation so that concurrent management of the same function is
+// easy to diagnose. Other errors are returned unchanged.
+func lambdaFunctionConflictError(err error) error {
+	conflict := lambdaFunctionConflictFromError(err)
+
+	if conflict == lambdaFunctionConflictNone {
+		return err
+	}
+
+	return fmt.Errorf("function is being modified by another operation (%s); check that it is not managed by more than one Terraform configuration or external process: %w", conflict, err)
+}
+
 func refreshLambdaFunctionLastUpdateStatus(conn *lambda.Lambda, functionName string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		input := &lambda.GetFunctionInput{
@@ -1275,6 +2148,184 @@ func waitForLambdaFunctionUpdate(conn *lambda.Lambda, functionName string, timeo
 	return err
 }
 
+// Maximum amount of time to wait for a code signing config to be attached to a new function.
+const lambdaFunctionCodeSigningConfigAttachedTimeout = 1 * time.Minute
+
+// verifyLambdaFunctionCodeSigning waits for the code signing config to be attached
+// to a newly created function and returns an error if the deployed code is unsigned
+// while the config enforces signed code.
+func verifyLambdaFunctionCodeSigning(conn *lambda.Lambda, functionName, codeSigningConfigArn string) error {
+	if err := waitForLambdaFunctionCodeSigningConfigAttached(conn, functionName, codeSigningConfigArn); err != nil {
+		return fmt.Errorf("error waiting for Lambda Function (%s) code signing config (%s) attachment: %w", functionName, codeSigningConfigArn, err)
+	}
+
+	configOutput, err := conn.GetCodeSigningConfig(&lambda.GetCodeSigningConfigInput{
+		CodeSigningConfigArn: aws.String(codeSigningConfigArn),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error getting Lambda Code Signing Config (%s): %w", codeSigningConfigArn, err)
+	}
+
+	functionOutput, err := conn.GetFunction(&lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error getting Lambda Function (%s): %w", functionName, err)
+	}
+
+	if configOutput == nil || functionOutput == nil {
+		return nil
+	}
+
+	return lambdaFunctionUnsignedCodeError(functionName, functionOutput.Configuration, configOutput.CodeSigningConfig)
+}
+
+func waitForLambdaFunctionCodeSigningConfigAttached(conn *lambda.Lambda, functionName, codeSigningConfigArn string) error {
+	input := &lambda.GetFunctionCodeSigningConfigInput{
+		FunctionName: aws.String(functionName),
+	}
+	notAttachedErr := fmt.Errorf("code signing config not attached")
+
+	err := resource.Retry(lambdaFunctionCodeSigningConfigAttachedTimeout, func() *resource.RetryError {
+		output, err := conn.GetFunctionCodeSigningConfig(input)
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if !lambdaFunctionCodeSigningConfigAttached(output, codeSigningConfigArn) {
+			return resource.RetryableError(notAttachedErr)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		var output *lambda.GetFunctionCodeSigningConfigOutput
+		output, err = conn.GetFunctionCodeSigningConfig(input)
+
+		if err == nil && !lambdaFunctionCodeSigningConfigAttached(output, codeSigningConfigArn) {
+			err = notAttachedErr
+		}
+	}
+
+	return err
+}
+
+// isLambdaFunctionCodeSigningUnavailableError returns whether a GetFunctionCodeSigningConfig
+// error means code signing cannot be used, e.g. in regions without code signing or when
+// the call is denied by a service control policy.
+func isLambdaFunctionCodeSigningUnavailableError(err error) bool {
+	return isAWSErr(err, "AccessDeniedException", "") || isAWSErr(err, "UnsupportedOperation", "")
+}
+
+// lambdaFunctionCodeSigningConfigArn returns the code signing config ARN from a
+// GetFunctionCodeSigningConfig response. If code signing is unavailable and no code signing
+// config is expected, the function is treated as having none.
+func lambdaFunctionCodeSigningConfigArn(functionName string, output *lambda.GetFunctionCodeSigningConfigOutput, err error, expected string) (string, error) {
+	if isLambdaFunctionCodeSigningUnavailableError(err) && expected == "" {
+		log.Printf("[WARN] Unable to read Lambda Function (%s) code signing config, assuming none: %s", functionName, err)
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if output == nil {
+		return "", nil
+	}
+
+	return aws.StringValue(output.CodeSigningConfigArn), nil
+}
+
+// lambdaFunctionCodeSigningConfigAttached returns whether the given code signing config
+// is attached according to a GetFunctionCodeSigningConfig response.
+func lambdaFunctionCodeSigningConfigAttached(output *lambda.GetFunctionCodeSigningConfigOutput, codeSigningConfigArn string) bool {
+	if output == nil {
+		return false
+	}
+
+	return aws.StringValue(output.CodeSigningConfigArn) == codeSigningConfigArn
+}
+
+// lambdaFunctionUnsignedCodeError returns an error if the function's code carries no
+// signing metadata while the code signing config enforces signed code.
+func lambdaFunctionUnsignedCodeError(functionName string, function *lambda.FunctionConfiguration, codeSigningConfig *lambda.CodeSigningConfig) error {
+	if function == nil || codeSigningConfig == nil || codeSigningConfig.CodeSigningPolicies == nil {
+		return nil
+	}
+
+	if aws.StringValue(codeSigningConfig.CodeSigningPolicies.UntrustedArtifactOnDeployment) != lambda.CodeSigningPolicyEnforce {
+		return nil
+	}
+
+	if aws.StringValue(function.SigningProfileVersionArn) != "" || aws.StringValue(function.SigningJobArn) != "" {
+		return nil
+	}
+
+	return fmt.Errorf("Lambda Function (%s) was deployed with unsigned code although code signing config (%s) enforces signed code; sign the deployment package and apply again to replace the function", functionName, aws.StringValue(codeSigningConfig.CodeSigningConfigArn))
+}
+
+// expandLambdaDeadLetterConfig returns the dead letter configuration of a dead_letter_config block.
+// Without a block, the target is cleared.
+func expandLambdaDeadLetterConfig(l []interface{}) *lambda.DeadLetterConfig {
+	config := &lambda.DeadLetterConfig{
+		TargetArn: aws.String(""),
+	}
+
+	if len(l) == 0 || l[0] == nil {
+		return config
+	}
+
+	m := l[0].(map[string]interface{})
+
+	if v, ok := m["target_arn"].(string); ok {
+		config.TargetArn = aws.String(v)
+	}
+
+	return config
+}
+
+// flattenLambdaDeadLetterConfig returns a dead_letter_config block, or none if no target is set,
+// e.g. after the queue or topic was removed from the function outside of Terraform.
+func flattenLambdaDeadLetterConfig(config *lambda.DeadLetterConfig) []interface{} {
+	if config == nil || aws.StringValue(config.TargetArn) == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"target_arn": aws.StringValue(config.TargetArn),
+		},
+	}
+}
+
+// validateLambdaFunctionDeadLetterTargetArn checks that a dead letter target is an SQS queue
+// or SNS topic ARN, the only targets Lambda accepts.
+func validateLambdaFunctionDeadLetterTargetArn(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if value == "" {
+		return ws, errors
+	}
+
+	parsedARN, err := arn.Parse(value)
+
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid ARN: %s", k, value, err))
+		return ws, errors
+	}
+
+	if parsedARN.Service != "sqs" && parsedARN.Service != "sns" {
+		errors = append(errors, fmt.Errorf("%q (%s) must be an SQS queue or SNS topic ARN", k, value))
+	}
+
+	return ws, errors
+}
+
 func flattenLambdaFileSystemConfigs(fscList []*lambda.FileSystemConfig) []map[string]interface{} {
 	results := make([]map[string]interface{}, 0, len(fscList))
 	for _, fsc := range fscList {
@@ -1302,7 +2353,12 @@ func expandLambdaFileSystemConfigs(fscMaps []interface{}) []*lambda.FileSystemCo
 func flattenLambdaImageConfig(response *lambda.ImageConfigResponse) []map[string]interface{} {
 	settings := make(map[string]interface{})
 
-	if response == nil || response.Error != nil {
+	if response == nil || response.Error != nil || response.ImageConfig == nil {
+		return nil
+	}
+
+	// Reset overrides are returned as empty values.
+	if len(response.ImageConfig.Command) == 0 && len(response.ImageConfig.EntryPoint) == 0 && aws.StringValue(response.ImageConfig.WorkingDirectory) == "" {
 		return nil
 	}
 
@@ -1313,18 +2369,53 @@ func flattenLambdaImageConfig(response *lambda.ImageConfigResponse) []map[string
 	return []map[string]interface{}{settings}
 }
 
+// expandLambdaImageConfigs returns the image configuration overrides in an image_config block.
+// Only configured overrides are set, so that the image's own ENTRYPOINT, CMD and WORKDIR
+// apply otherwise. Without a block, all overrides are reset.
 func expandLambdaImageConfigs(imageConfigMaps []interface{}) *lambda.ImageConfig {
-	imageConfig := &lambda.ImageConfig{}
 	// only one image_config block is allowed
-	if len(imageConfigMaps) == 1 && imageConfigMaps[0] != nil {
-		config := imageConfigMaps[0].(map[string]interface{})
-		if len(config["entry_point"].([]interface{})) > 0 {
-			imageConfig.EntryPoint = expandStringList(config["entry_point"].([]interface{}))
-		}
-		if len(config["command"].([]interface{})) > 0 {
-			imageConfig.Command = expandStringList(config["command"].([]interface{}))
+	if len(imageConfigMaps) == 0 || imageConfigMaps[0] == nil {
+		return &lambda.ImageConfig{
+			Command:          []*string{},
+			EntryPoint:       []*string{},
+			WorkingDirectory: aws.String(""),
 		}
-		imageConfig.WorkingDirectory = aws.String(config["working_directory"].(string))
 	}
+
+	imageConfig := &lambda.ImageConfig{}
+	config := imageConfigMaps[0].(map[string]interface{})
+	if v, ok := config["entry_point"].([]interface{}); ok && len(v) > 0 {
+		imageConfig.EntryPoint = expandStringList(v)
+	}
+	if v, ok := config["command"].([]interface{}); ok && len(v) > 0 {
+		imageConfig.Command = expandStringList(v)
+	}
+	if v, ok := config["working_directory"].(string); ok && v != "" {
+		imageConfig.WorkingDirectory = aws.String(v)
+	}
+	return imageConfig
+}
+
+// expandLambdaImageConfigsForUpdate returns the image configuration to send when image_config
+// changes. Lambda keeps overrides that are omitted, so overrides removed from the block are reset.
+func expandLambdaImageConfigsForUpdate(o, n []interface{}) *lambda.ImageConfig {
+	imageConfig := expandLambdaImageConfigs(n)
+
+	if len(n) == 0 || n[0] == nil || len(o) == 0 || o[0] == nil {
+		return imageConfig
+	}
+
+	old := expandLambdaImageConfigs(o)
+
+	if imageConfig.EntryPoint == nil && old.EntryPoint != nil {
+		imageConfig.EntryPoint = []*string{}
+	}
+	if imageConfig.Command == nil && old.Command != nil {
+		imageConfig.Command = []*string{}
+	}
+	if imageConfig.WorkingDirectory == nil && old.WorkingDirectory != nil {
+		imageConfig.WorkingDirectory = aws.String("")
+	}
+
 	return imageConfig
 }
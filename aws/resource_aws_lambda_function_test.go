@@ -7,15 +7,22 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -61,6 +68,1603 @@ func testSweepLambdaFunctions(region string) error {
 	return nil
 }
 
+func TestClassifyLambdaFunctionConflict(t *testing.T) {
+	testCases := []struct {
+		name     string
+		message  string
+		expected lambdaFunctionConflict
+	}{
+		{
+			name:     "update in progress",
+			message:  "The operation cannot be performed at this time. An update is in progress for resource: arn:aws:lambda:us-west-2:123456789012:function:example",
+			expected: lambdaFunctionConflictUpdateInProgress,
+		},
+		{
+			name:     "function being deleted",
+			message:  "The operation cannot be performed at this time. The function arn:aws:lambda:us-west-2:123456789012:function:example is being deleted.",
+			expected: lambdaFunctionConflictDeleteInProgress,
+		},
+		{
+			name:     "publish in progress",
+			message:  "The operation cannot be performed at this time. A PublishVersion operation is in progress for resource: arn:aws:lambda:us-west-2:123456789012:function:example",
+			expected: lambdaFunctionConflictPublishInProgress,
+		},
+		{
+			name:     "function pending",
+			message:  "The operation cannot be performed at this time. The function is currently in the following state: Pending",
+			expected: lambdaFunctionConflictUnknown,
+		},
+		{
+			name:     "empty",
+			message:  "",
+			expected: lambdaFunctionConflictUnknown,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := classifyLambdaFunctionConflict(testCase.message)
+
+			if got != testCase.expected {
+				t.Errorf("got %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionConflictFromError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected lambdaFunctionConflict
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: lambdaFunctionConflictNone,
+		},
+		{
+			name:     "other error code",
+			err:      awserr.New(lambda.ErrCodeResourceNotFoundException, "Function not found: arn:aws:lambda:us-west-2:123456789012:function:example", nil),
+			expected: lambdaFunctionConflictNone,
+		},
+		{
+			name:     "update in progress",
+			err:      awserr.New(lambda.ErrCodeResourceConflictException, "The operation cannot be performed at this time. An update is in progress for resource: arn:aws:lambda:us-west-2:123456789012:function:example", nil),
+			expected: lambdaFunctionConflictUpdateInProgress,
+		},
+		{
+			name:     "wrapped update in progress",
+			err:      fmt.Errorf("wrapped: %w", awserr.New(lambda.ErrCodeResourceConflictException, "The operation cannot be performed at this time. An update is in progress for resource: arn:aws:lambda:us-west-2:123456789012:function:example", nil)),
+			expected: lambdaFunctionConflictUpdateInProgress,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lambdaFunctionConflictFromError(testCase.err)
+
+			if got != testCase.expected {
+				t.Errorf("got %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionDeprecatedRuntimes(t *testing.T) {
+	// Changes to the deprecated runtimes set must be deliberate.
+	expected := []string{
+		lambda.RuntimeDotnetcore10,
+		lambda.RuntimeDotnetcore20,
+		lambda.RuntimeNodejs,
+		lambda.RuntimeNodejs10X,
+		lambda.RuntimeNodejs43,
+		lambda.RuntimeNodejs43Edge,
+		lambda.RuntimeNodejs610,
+		lambda.RuntimeNodejs810,
+		lambda.RuntimePython27,
+	}
+
+	if got, want := len(lambdaFunctionDeprecatedRuntimes), len(expected); got != want {
+		t.Errorf("got %d deprecated runtimes, expected %d", got, want)
+	}
+
+	for _, runtime := range expected {
+		if !isLambdaFunctionDeprecatedRuntime(runtime) {
+			t.Errorf("expected runtime %s to be deprecated", runtime)
+		}
+	}
+
+	runtimes := make(map[string]struct{})
+	for _, runtime := range lambda.Runtime_Values() {
+		runtimes[runtime] = struct{}{}
+	}
+
+	for runtime := range lambdaFunctionDeprecatedRuntimes {
+		if _, ok := runtimes[runtime]; !ok {
+			t.Errorf("deprecated runtime %s is not a known runtime", runtime)
+		}
+	}
+
+	for _, runtime := range []string{"", lambda.RuntimeNodejs12X, lambda.RuntimePython38, lambda.RuntimeProvidedAl2} {
+		if isLambdaFunctionDeprecatedRuntime(runtime) {
+			t.Errorf("expected runtime %q not to be deprecated", runtime)
+		}
+	}
+}
+
+func TestLambdaFunctionHandlerAndRuntime(t *testing.T) {
+	testCases := []struct {
+		name            string
+		function        *lambda.FunctionConfiguration
+		expectedHandler string
+		expectedRuntime string
+	}{
+		{
+			name: "zip",
+			function: &lambda.FunctionConfiguration{
+				Handler:     aws.String("exports.example"),
+				PackageType: aws.String(lambda.PackageTypeZip),
+				Runtime:     aws.String(lambda.RuntimeNodejs12X),
+			},
+			expectedHandler: "exports.example",
+			expectedRuntime: lambda.RuntimeNodejs12X,
+		},
+		{
+			name: "no package type",
+			function: &lambda.FunctionConfiguration{
+				Handler: aws.String("exports.example"),
+				Runtime: aws.String(lambda.RuntimeNodejs12X),
+			},
+			expectedHandler: "exports.example",
+			expectedRuntime: lambda.RuntimeNodejs12X,
+		},
+		{
+			name: "image",
+			function: &lambda.FunctionConfiguration{
+				PackageType: aws.String(lambda.PackageTypeImage),
+			},
+		},
+		{
+			name: "image with handler and runtime",
+			function: &lambda.FunctionConfiguration{
+				Handler:     aws.String("exports.example"),
+				PackageType: aws.String(lambda.PackageTypeImage),
+				Runtime:     aws.String(lambda.RuntimeNodejs12X),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, runtime := lambdaFunctionHandlerAndRuntime(testCase.function)
+
+			if handler != testCase.expectedHandler || runtime != testCase.expectedRuntime {
+				t.Errorf("got (%q, %q), expected (%q, %q)", handler, runtime, testCase.expectedHandler, testCase.expectedRuntime)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionCodeDrifted(t *testing.T) {
+	testCases := []struct {
+		name               string
+		deployedCodeSha256 string
+		codeSha256         string
+		expected           bool
+	}{
+		{
+			name:       "nothing deployed",
+			codeSha256: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:   false,
+		},
+		{
+			name:               "no current code",
+			deployedCodeSha256: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:           false,
+		},
+		{
+			name:               "unchanged",
+			deployedCodeSha256: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			codeSha256:         "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:           false,
+		},
+		{
+			name:               "deployed out of band",
+			deployedCodeSha256: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			codeSha256:         "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg=",
+			expected:           true,
+		},
+		{
+			name:               "image digest prefix",
+			deployedCodeSha256: "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			codeSha256:         "5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			expected:           false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lambdaFunctionCodeDrifted(testCase.deployedCodeSha256, testCase.codeSha256)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionSourceCodeHashEquivalent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		sourceCodeHash string
+		codeSha256     string
+		expected       bool
+	}{
+		{
+			name:           "zip hash",
+			sourceCodeHash: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			codeSha256:     "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:       true,
+		},
+		{
+			name:           "zip hash changed",
+			sourceCodeHash: "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg=",
+			codeSha256:     "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:       false,
+		},
+		{
+			name:           "image digest with prefix",
+			sourceCodeHash: "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			codeSha256:     "5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			expected:       true,
+		},
+		{
+			name:           "image digest changed",
+			sourceCodeHash: "sha256:9b2a4d1e0f7c3b6a5d8e1f4c7b0a3d6e9f2c5b8a1d4e7f0c3b6a9d2e5f8c1b4a",
+			codeSha256:     "5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			expected:       false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lambdaFunctionSourceCodeHashEquivalent(testCase.sourceCodeHash, testCase.codeSha256)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionSourceCodeHash(t *testing.T) {
+	testCases := []struct {
+		name               string
+		sourceCodeHash     string
+		previousCodeSha256 string
+		codeSha256         string
+		expected           string
+	}{
+		{
+			name:       "not set",
+			codeSha256: "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:   "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+		},
+		{
+			name:               "read from previous code",
+			sourceCodeHash:     "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg=",
+			previousCodeSha256: "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg=",
+			codeSha256:         "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:           "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+		},
+		{
+			name:               "user-provided S3 object hash",
+			sourceCodeHash:     "d41d8cd98f00b204e9800998ecf8427e",
+			previousCodeSha256: "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg=",
+			codeSha256:         "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:           "d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			name:           "user-provided hash before code_sha256 was read",
+			sourceCodeHash: "d41d8cd98f00b204e9800998ecf8427e",
+			codeSha256:     "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY=",
+			expected:       "d41d8cd98f00b204e9800998ecf8427e",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lambdaFunctionSourceCodeHash(testCase.sourceCodeHash, testCase.previousCodeSha256, testCase.codeSha256)
+
+			if got != testCase.expected {
+				t.Errorf("got %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionLayersEquivalent(t *testing.T) {
+	layer1 := "arn:aws:lambda:us-west-2:123456789012:layer:one:1"   //lintignore:AWSAT003,AWSAT005
+	layer2 := "arn:aws:lambda:us-west-2:123456789012:layer:two:4"   //lintignore:AWSAT003,AWSAT005
+	layer2v5 := "arn:aws:lambda:us-west-2:123456789012:layer:two:5" //lintignore:AWSAT003,AWSAT005
+
+	testCases := []struct {
+		name     string
+		a        []interface{}
+		b        []interface{}
+		expected bool
+	}{
+		{
+			name:     "empty",
+			expected: true,
+		},
+		{
+			name:     "same order",
+			a:        []interface{}{layer1, layer2},
+			b:        []interface{}{layer1, layer2},
+			expected: true,
+		},
+		{
+			name:     "reordered",
+			a:        []interface{}{layer1, layer2},
+			b:        []interface{}{layer2, layer1},
+			expected: true,
+		},
+		{
+			name:     "version changed",
+			a:        []interface{}{layer1, layer2},
+			b:        []interface{}{layer2v5, layer1},
+			expected: false,
+		},
+		{
+			name:     "layer added",
+			a:        []interface{}{layer1},
+			b:        []interface{}{layer1, layer2},
+			expected: false,
+		},
+		{
+			name:     "duplicate",
+			a:        []interface{}{layer1, layer1},
+			b:        []interface{}{layer1, layer2},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := lambdaFunctionLayersEquivalent(testCase.a, testCase.b); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func testLambdaFileContentLimiterFile(t *testing.T, dir, name string, size int) string {
+	filename := filepath.Join(dir, name)
+
+	if err := ioutil.WriteFile(filename, []byte(strings.Repeat(name[:1], size)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return filename
+}
+
+func TestLambdaFileContentLimiterDistinctFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-lambda-file-content")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	limiter := newLambdaFileContentLimiter(100)
+
+	// Files that fit in the limit are loaded concurrently.
+	_, release1, err := limiter.Load(testLambdaFileContentLimiterFile(t, dir, "a.zip", 40))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, release2, err := limiter.Load(testLambdaFileContentLimiterFile(t, dir, "b.zip", 40))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A file that does not fit waits for a release.
+	filename := testLambdaFileContentLimiterFile(t, dir, "c.zip", 40)
+	loaded := make(chan error)
+	go func() {
+		_, release, err := limiter.Load(filename)
+		if err == nil {
+			release()
+		}
+		loaded <- err
+	}()
+
+	select {
+	case <-loaded:
+		t.Fatal("expected load over the limit to wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release1()
+	// Releasing more than once has no effect.
+	release1()
+
+	select {
+	case err := <-loaded:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected load to continue after release")
+	}
+
+	release2()
+
+	// A file larger than the limit is loaded when nothing else is.
+	content, release, err := limiter.Load(testLambdaFileContentLimiterFile(t, dir, "d.zip", 200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	if len(content) != 200 {
+		t.Errorf("got %d bytes, expected 200", len(content))
+	}
+
+	if limiter.inFlight != 0 {
+		t.Errorf("got %d bytes in flight, expected 0", limiter.inFlight)
+	}
+
+	if _, _, err := limiter.Load(filepath.Join(dir, "missing.zip")); err == nil {
+		t.Error("expected error loading missing file")
+	}
+}
+
+func TestLambdaFileContentLimiterSameFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-lambda-file-content")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := testLambdaFileContentLimiterFile(t, dir, "a.zip", 64)
+	expected := strings.Repeat("a", 64)
+
+	for _, limit := range []int64{64, 1024} {
+		limiter := newLambdaFileContentLimiter(limit)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				content, release, err := limiter.Load(filename)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer release()
+
+				if string(content) != expected {
+					errs <- fmt.Errorf("got %q, expected %q", content, expected)
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Errorf("limit %d: %s", limit, err)
+		}
+
+		if limiter.inFlight != 0 {
+			t.Errorf("limit %d: got %d bytes in flight, expected 0", limit, limiter.inFlight)
+		}
+	}
+}
+
+// testLambdaFunctionDiffer reports the attributes in the set as changed.
+type testLambdaFunctionDiffer map[string]bool
+
+func (d testLambdaFunctionDiffer) HasChange(key string) bool {
+	return d[key]
+}
+
+func TestNeedsFunctionCodeUpdate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		changed  []string
+		expected bool
+	}{
+		{
+			name:     "no changes",
+			expected: false,
+		},
+		{
+			name:     "configuration only",
+			changed:  []string{"handler", "memory_size"},
+			expected: false,
+		},
+		{
+			name:     "filename",
+			changed:  []string{"filename"},
+			expected: true,
+		},
+		{
+			name:     "s3 key",
+			changed:  []string{"s3_key", "source_code_hash"},
+			expected: true,
+		},
+		{
+			name:     "s3 object version",
+			changed:  []string{"s3_object_version"},
+			expected: true,
+		},
+		{
+			name:     "source code hash only",
+			changed:  []string{"source_code_hash"},
+			expected: true,
+		},
+		{
+			name:     "image uri",
+			changed:  []string{"image_uri"},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := testLambdaFunctionDiffer{}
+			for _, k := range testCase.changed {
+				d[k] = true
+			}
+
+			if got := needsFunctionCodeUpdate(d); got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionCodeSigningConfigAttached(t *testing.T) {
+	codeSigningConfigArn := "arn:aws:lambda:us-west-2:123456789012:code-signing-config:csc-0f6b4f5d7f7c3c1a2"
+
+	testCases := []struct {
+		name     string
+		output   *lambda.GetFunctionCodeSigningConfigOutput
+		expected bool
+	}{
+		{
+			name:     "no response",
+			expected: false,
+		},
+		{
+			name: "not yet attached",
+			output: &lambda.GetFunctionCodeSigningConfigOutput{
+				FunctionName: aws.String("test"),
+			},
+			expected: false,
+		},
+		{
+			name: "other config attached",
+			output: &lambda.GetFunctionCodeSigningConfigOutput{
+				CodeSigningConfigArn: aws.String("arn:aws:lambda:us-west-2:123456789012:code-signing-config:csc-0a1b2c3d4e5f6a7b8"),
+				FunctionName:         aws.String("test"),
+			},
+			expected: false,
+		},
+		{
+			name: "attached",
+			output: &lambda.GetFunctionCodeSigningConfigOutput{
+				CodeSigningConfigArn: aws.String(codeSigningConfigArn),
+				FunctionName:         aws.String("test"),
+			},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lambdaFunctionCodeSigningConfigAttached(testCase.output, codeSigningConfigArn)
+
+			if got != testCase.expected {
+				t.Errorf("got %t, expected %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionCodeSigningConfigArn(t *testing.T) {
+	codeSigningConfigArn := "arn:aws:lambda:us-west-2:123456789012:code-signing-config:csc-0f6b4f5d7f7c3c1a2"
+
+	testCases := []struct {
+		name          string
+		output        *lambda.GetFunctionCodeSigningConfigOutput
+		err           error
+		expected      string
+		expectedArn   string
+		expectedError bool
+	}{
+		{
+			name: "no response",
+		},
+		{
+			name: "none attached",
+			output: &lambda.GetFunctionCodeSigningConfigOutput{
+				FunctionName: aws.String("test"),
+			},
+		},
+		{
+			name: "attached",
+			output: &lambda.GetFunctionCodeSigningConfigOutput{
+				CodeSigningConfigArn: aws.String(codeSigningConfigArn),
+				FunctionName:         aws.String("test"),
+			},
+			expected:    codeSigningConfigArn,
+			expectedArn: codeSigningConfigArn,
+		},
+		{
+			name: "access denied",
+			err:  awserr.New("AccessDeniedException", "test", nil),
+		},
+		{
+			name: "unsupported operation",
+			err:  awserr.New("UnsupportedOperation", "test", nil),
+		},
+		{
+			name:          "access denied with config expected",
+			err:           awserr.New("AccessDeniedException", "test", nil),
+			expected:      codeSigningConfigArn,
+			expectedError: true,
+		},
+		{
+			name:          "other error",
+			err:           awserr.New(lambda.ErrCodeServiceException, "test", nil),
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := lambdaFunctionCodeSigningConfigArn("test", testCase.output, testCase.err, testCase.expected)
+
+			if err == nil && testCase.expectedError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.expectedError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if got != testCase.expectedArn {
+				t.Errorf("got %q, expected %q", got, testCase.expectedArn)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionUnsignedCodeError(t *testing.T) {
+	codeSigningConfig := func(policy string) *lambda.CodeSigningConfig {
+		return &lambda.CodeSigningConfig{
+			CodeSigningConfigArn: aws.String("arn:aws:lambda:us-west-2:123456789012:code-signing-config:csc-0f6b4f5d7f7c3c1a2"),
+			CodeSigningPolicies: &lambda.CodeSigningPolicies{
+				UntrustedArtifactOnDeployment: aws.String(policy),
+			},
+		}
+	}
+	signed := &lambda.FunctionConfiguration{
+		SigningJobArn:            aws.String("arn:aws:signer:us-west-2:123456789012:/signing-jobs/6d5a2c1e-3b4f-4a7d-9e8c-1f2a3b4c5d6e"),
+		SigningProfileVersionArn: aws.String("arn:aws:signer:us-west-2:123456789012:/signing-profiles/test/abcd1234"),
+	}
+	unsigned := &lambda.FunctionConfiguration{}
+
+	testCases := []struct {
+		name              string
+		function          *lambda.FunctionConfiguration
+		codeSigningConfig *lambda.CodeSigningConfig
+		expectedError     bool
+	}{
+		{
+			name:              "signed, Enforce",
+			function:          signed,
+			codeSigningConfig: codeSigningConfig(lambda.CodeSigningPolicyEnforce),
+		},
+		{
+			name:              "unsigned, Warn",
+			function:          unsigned,
+			codeSigningConfig: codeSigningConfig(lambda.CodeSigningPolicyWarn),
+		},
+		{
+			name:              "unsigned, Enforce",
+			function:          unsigned,
+			codeSigningConfig: codeSigningConfig(lambda.CodeSigningPolicyEnforce),
+			expectedError:     true,
+		},
+		{
+			name:              "unsigned, no policies",
+			function:          unsigned,
+			codeSigningConfig: &lambda.CodeSigningConfig{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := lambdaFunctionUnsignedCodeError("test", testCase.function, testCase.codeSigningConfig)
+
+			if err == nil && testCase.expectedError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.expectedError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionMemorySizeAndTimeoutValidation(t *testing.T) {
+	s := resourceAwsLambdaFunction().Schema
+
+	testCases := []struct {
+		key         string
+		value       int
+		expectError bool
+	}{
+		{key: "memory_size", value: 128},
+		{key: "memory_size", value: 10240},
+		{key: "memory_size", value: 100, expectError: true},
+		{key: "memory_size", value: 10241, expectError: true},
+		{key: "timeout", value: 1},
+		{key: "timeout", value: 900},
+		{key: "timeout", value: 0, expectError: true},
+		{key: "timeout", value: 1000, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := s[testCase.key].ValidateFunc(testCase.value, testCase.key)
+
+		if got := len(errs) > 0; got != testCase.expectError {
+			t.Errorf("%s = %d: got errors %v, expected error: %t", testCase.key, testCase.value, errs, testCase.expectError)
+		}
+
+		for _, err := range errs {
+			if !strings.Contains(err.Error(), testCase.key) {
+				t.Errorf("%s = %d: error %q does not name the attribute", testCase.key, testCase.value, err)
+			}
+		}
+	}
+}
+
+func TestLambdaFunctionFilenameError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-acc-lambda-filename")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "lambda.zip")
+	if err := ioutil.WriteFile(filename, []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		filename    string
+		expectError bool
+	}{
+		{
+			name:     "exists",
+			filename: filename,
+		},
+		{
+			name:        "missing",
+			filename:    filepath.Join(dir, "missing.zip"),
+			expectError: true,
+		},
+		{
+			name:        "directory",
+			filename:    dir,
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := lambdaFunctionFilenameError(testCase.filename)
+
+			if got := err != nil; got != testCase.expectError {
+				t.Fatalf("got error %v, expected error: %t", err, testCase.expectError)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "filename") {
+				t.Errorf("error %q does not name the attribute", err)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionPackageTypeError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		packageType string
+		configured  map[string]bool
+		expected    string
+	}{
+		{
+			name:        "zip valid",
+			packageType: lambda.PackageTypeZip,
+			configured:  map[string]bool{"filename": true, "handler": true, "runtime": true},
+		},
+		{
+			name:        "zip missing runtime",
+			packageType: lambda.PackageTypeZip,
+			configured:  map[string]bool{"filename": true, "handler": true},
+			expected:    "when package_type is Zip, runtime must be set",
+		},
+		{
+			name:        "zip with image attributes",
+			packageType: lambda.PackageTypeZip,
+			configured:  map[string]bool{"image_config": true, "image_uri": true},
+			expected:    "when package_type is Zip, handler, runtime must be set and image_config, image_uri must be removed",
+		},
+		{
+			name:        "image valid",
+			packageType: lambda.PackageTypeImage,
+			configured:  map[string]bool{"image_config": true, "image_uri": true},
+		},
+		{
+			name:        "image missing image_uri",
+			packageType: lambda.PackageTypeImage,
+			configured:  map[string]bool{},
+			expected:    "when package_type is Image, image_uri must be set",
+		},
+		{
+			name:        "image with zip attributes",
+			packageType: lambda.PackageTypeImage,
+			configured:  map[string]bool{"handler": true, "image_uri": true, "layers": true, "runtime": true},
+			expected:    "when package_type is Image, handler, layers, runtime must be removed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := lambdaFunctionPackageTypeError(testCase.packageType, testCase.configured)
+
+			if testCase.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", testCase.expected)
+			}
+
+			if got := err.Error(); got != testCase.expected {
+				t.Errorf("got error %q, expected %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionNetworkInterfaces(t *testing.T) {
+	functionENI := &ec2.NetworkInterface{
+		Groups: []*ec2.GroupIdentifier{
+			{GroupId: aws.String("sg-1")},
+			{GroupId: aws.String("sg-2")},
+		},
+		NetworkInterfaceId: aws.String("eni-1"),
+	}
+	sharedENI := &ec2.NetworkInterface{
+		Groups: []*ec2.GroupIdentifier{
+			{GroupId: aws.String("sg-1")},
+		},
+		NetworkInterfaceId: aws.String("eni-2"),
+	}
+
+	got := lambdaFunctionNetworkInterfaces([]*ec2.NetworkInterface{functionENI, nil, sharedENI}, aws.StringSlice([]string{"sg-1", "sg-2"}))
+
+	if !reflect.DeepEqual(got, []*ec2.NetworkInterface{functionENI}) {
+		t.Errorf("got %v, expected only %s", got, aws.StringValue(functionENI.NetworkInterfaceId))
+	}
+}
+
+func TestExpandLambdaDeadLetterConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []interface{}
+		expected string
+	}{
+		{
+			name: "no block",
+		},
+		{
+			name:  "nil block",
+			input: []interface{}{nil},
+		},
+		{
+			name: "target",
+			input: []interface{}{
+				map[string]interface{}{"target_arn": "arn:aws:sqs:us-west-2:123456789012:test"},
+			},
+			expected: "arn:aws:sqs:us-west-2:123456789012:test",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := expandLambdaDeadLetterConfig(testCase.input)
+
+			if got == nil || got.TargetArn == nil {
+				t.Fatalf("expected target ARN %q, got %v", testCase.expected, got)
+			}
+
+			if v := aws.StringValue(got.TargetArn); v != testCase.expected {
+				t.Errorf("got target ARN %q, expected %q", v, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenLambdaDeadLetterConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    *lambda.DeadLetterConfig
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			expected: []interface{}{},
+		},
+		{
+			name:     "removed target",
+			input:    &lambda.DeadLetterConfig{},
+			expected: []interface{}{},
+		},
+		{
+			name:     "empty target",
+			input:    &lambda.DeadLetterConfig{TargetArn: aws.String("")},
+			expected: []interface{}{},
+		},
+		{
+			name:  "target",
+			input: &lambda.DeadLetterConfig{TargetArn: aws.String("arn:aws:sns:us-west-2:123456789012:test")},
+			expected: []interface{}{
+				map[string]interface{}{"target_arn": "arn:aws:sns:us-west-2:123456789012:test"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := flattenLambdaDeadLetterConfig(testCase.input)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestValidateLambdaFunctionDeadLetterTargetArn(t *testing.T) {
+	testCases := []struct {
+		value       string
+		expectError bool
+	}{
+		{value: ""},
+		{value: "arn:aws:sqs:us-west-2:123456789012:test"},
+		{value: "arn:aws:sns:us-west-2:123456789012:test"},
+		{value: "arn:aws-us-gov:sns:us-gov-west-1:123456789012:test"},
+		{value: "arn:aws:iam::123456789012:role/test", expectError: true},
+		{value: "arn:aws:lambda:us-west-2:123456789012:function:test", expectError: true},
+		{value: "test", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errors := validateLambdaFunctionDeadLetterTargetArn(testCase.value, "target_arn")
+
+		if got := len(errors) > 0; got != testCase.expectError {
+			t.Errorf("%q: got errors %v, expected error: %t", testCase.value, errors, testCase.expectError)
+		}
+	}
+}
+
+func TestLambdaFunctionEnvironmentError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         []interface{}
+		expectedError string
+	}{
+		{
+			name: "no block",
+		},
+		{
+			name:  "nil block",
+			input: []interface{}{nil},
+		},
+		{
+			name: "distinct keys",
+			input: []interface{}{
+				map[string]interface{}{
+					"variables":           map[string]interface{}{"foo": "bar"},
+					"sensitive_variables": map[string]interface{}{"secret": "value"},
+				},
+			},
+		},
+		{
+			name: "duplicate keys",
+			input: []interface{}{
+				map[string]interface{}{
+					"variables":           map[string]interface{}{"foo": "bar", "secret": "value", "token": "value"},
+					"sensitive_variables": map[string]interface{}{"token": "value", "secret": "value"},
+				},
+			},
+			expectedError: "environment variables secret, token cannot be set in both",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := lambdaFunctionEnvironmentError(testCase.input)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", testCase.expectedError)
+			}
+
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("got error %q, expected it to contain %q", err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestExpandLambdaEnvironment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       []interface{}
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:        "nil block",
+			input:       []interface{}{nil},
+			expectError: true,
+		},
+		{
+			name:     "empty block",
+			input:    []interface{}{map[string]interface{}{}},
+			expected: map[string]string{},
+		},
+		{
+			name: "variables",
+			input: []interface{}{
+				map[string]interface{}{"variables": map[string]interface{}{"foo": "bar"}},
+			},
+			expected: map[string]string{"foo": "bar"},
+		},
+		{
+			name: "variables and sensitive variables",
+			input: []interface{}{
+				map[string]interface{}{
+					"variables":           map[string]interface{}{"foo": "bar"},
+					"sensitive_variables": map[string]interface{}{"secret": "value"},
+				},
+			},
+			expected: map[string]string{"foo": "bar", "secret": "value"},
+		},
+		{
+			name: "duplicate keys",
+			input: []interface{}{
+				map[string]interface{}{
+					"variables":           map[string]interface{}{"secret": "bar"},
+					"sensitive_variables": map[string]interface{}{"secret": "value"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := expandLambdaEnvironment(testCase.input)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if v := aws.StringValueMap(got.Variables); !reflect.DeepEqual(v, testCase.expected) {
+				t.Errorf("got variables %v, expected %v", v, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenLambdaFunctionEnvironment(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         *lambda.EnvironmentResponse
+		sensitiveKeys map[string]bool
+		expected      []interface{}
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:     "no variables",
+			input:    &lambda.EnvironmentResponse{},
+			expected: []interface{}{map[string]interface{}{}},
+		},
+		{
+			name: "variables",
+			input: &lambda.EnvironmentResponse{
+				Variables: aws.StringMap(map[string]string{"foo": "bar", "secret": "value"}),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"variables": map[string]string{"foo": "bar", "secret": "value"},
+				},
+			},
+		},
+		{
+			name: "variables and sensitive variables",
+			input: &lambda.EnvironmentResponse{
+				Variables: aws.StringMap(map[string]string{"foo": "bar", "secret": "value"}),
+			},
+			sensitiveKeys: map[string]bool{"secret": true, "removed": true},
+			expected: []interface{}{
+				map[string]interface{}{
+					"variables":           map[string]string{"foo": "bar"},
+					"sensitive_variables": map[string]string{"secret": "value"},
+				},
+			},
+		},
+		{
+			name: "sensitive variables",
+			input: &lambda.EnvironmentResponse{
+				Variables: aws.StringMap(map[string]string{"secret": "value"}),
+			},
+			sensitiveKeys: map[string]bool{"secret": true},
+			expected: []interface{}{
+				map[string]interface{}{
+					"sensitive_variables": map[string]string{"secret": "value"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := flattenLambdaFunctionEnvironment(testCase.input, testCase.sensitiveKeys)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionSensitiveVariablesSchema(t *testing.T) {
+	environment := resourceAwsLambdaFunction().Schema["environment"].Elem.(*schema.Resource)
+
+	if environment.Schema["variables"].Sensitive {
+		t.Error("expected environment.0.variables not to be sensitive")
+	}
+
+	if !environment.Schema["sensitive_variables"].Sensitive {
+		t.Error("expected environment.0.sensitive_variables to be sensitive")
+	}
+}
+
+func TestLambdaFunctionReservedConcurrencyError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reserved    int64
+		current     int64
+		unreserved  int64
+		expectError bool
+	}{
+		{
+			name:       "within pool",
+			reserved:   100,
+			unreserved: 1000,
+		},
+		{
+			name:       "leaves minimum unreserved",
+			reserved:   900,
+			unreserved: 1000,
+		},
+		{
+			name:        "below minimum unreserved",
+			reserved:    901,
+			unreserved:  1000,
+			expectError: true,
+		},
+		{
+			name:        "pool already at minimum",
+			reserved:    1,
+			unreserved:  100,
+			expectError: true,
+		},
+		{
+			name:       "current reservation returned to pool",
+			reserved:   150,
+			current:    100,
+			unreserved: 150,
+		},
+		{
+			name:        "current reservation not enough",
+			reserved:    151,
+			current:     100,
+			unreserved:  150,
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := lambdaFunctionReservedConcurrencyError(testCase.reserved, testCase.current, testCase.unreserved)
+
+			if got := err != nil; got != testCase.expectError {
+				t.Fatalf("got error %v, expected error: %t", err, testCase.expectError)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "reserved_concurrent_executions") {
+				t.Errorf("error %q does not name the attribute", err)
+			}
+		})
+	}
+}
+
+func TestExpandLambdaImageConfigs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      []interface{}
+		new      []interface{}
+		expected *lambda.ImageConfig
+	}{
+		{
+			name: "no block",
+			expected: &lambda.ImageConfig{
+				Command:          []*string{},
+				EntryPoint:       []*string{},
+				WorkingDirectory: aws.String(""),
+			},
+		},
+		{
+			name: "nil block",
+			new:  []interface{}{nil},
+			expected: &lambda.ImageConfig{
+				Command:          []*string{},
+				EntryPoint:       []*string{},
+				WorkingDirectory: aws.String(""),
+			},
+		},
+		{
+			name: "empty block",
+			new: []interface{}{map[string]interface{}{
+				"command":           []interface{}{},
+				"entry_point":       []interface{}{},
+				"working_directory": "",
+			}},
+			expected: &lambda.ImageConfig{},
+		},
+		{
+			name: "working_directory only",
+			new: []interface{}{map[string]interface{}{
+				"command":           []interface{}{},
+				"entry_point":       []interface{}{},
+				"working_directory": "/var/task",
+			}},
+			expected: &lambda.ImageConfig{
+				WorkingDirectory: aws.String("/var/task"),
+			},
+		},
+		{
+			name: "all overrides",
+			new: []interface{}{map[string]interface{}{
+				"command":           []interface{}{"app.handler"},
+				"entry_point":       []interface{}{"/bootstrap"},
+				"working_directory": "/var/task",
+			}},
+			expected: &lambda.ImageConfig{
+				Command:          aws.StringSlice([]string{"app.handler"}),
+				EntryPoint:       aws.StringSlice([]string{"/bootstrap"}),
+				WorkingDirectory: aws.String("/var/task"),
+			},
+		},
+		{
+			name: "overrides removed from block",
+			old: []interface{}{map[string]interface{}{
+				"command":           []interface{}{"app.handler"},
+				"entry_point":       []interface{}{"/bootstrap"},
+				"working_directory": "/var/task",
+			}},
+			new: []interface{}{map[string]interface{}{
+				"command":           []interface{}{"app.other_handler"},
+				"entry_point":       []interface{}{},
+				"working_directory": "",
+			}},
+			expected: &lambda.ImageConfig{
+				Command:          aws.StringSlice([]string{"app.other_handler"}),
+				EntryPoint:       []*string{},
+				WorkingDirectory: aws.String(""),
+			},
+		},
+		{
+			name: "block removed",
+			old: []interface{}{map[string]interface{}{
+				"command":           []interface{}{"app.handler"},
+				"entry_point":       []interface{}{},
+				"working_directory": "",
+			}},
+			expected: &lambda.ImageConfig{
+				Command:          []*string{},
+				EntryPoint:       []*string{},
+				WorkingDirectory: aws.String(""),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := expandLambdaImageConfigsForUpdate(testCase.old, testCase.new)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("got %s, expected %s", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionTrimSpaceStateFunc(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "python3.8", expected: "python3.8"},
+		{value: "python3.8 ", expected: "python3.8"},
+		{value: " index.handler\n", expected: "index.handler"},
+		{value: "Index.Handler", expected: "Index.Handler"},
+	}
+
+	for _, testCase := range testCases {
+		if got := lambdaFunctionTrimSpaceStateFunc(testCase.value); got != testCase.expected {
+			t.Errorf("%q: got %q, expected %q", testCase.value, got, testCase.expected)
+		}
+	}
+}
+
+func TestLambdaFunctionTrimSpaceValidateFunc(t *testing.T) {
+	validateFunc := lambdaFunctionTrimSpaceValidateFunc(validation.StringInSlice(lambda.Runtime_Values(), false))
+
+	testCases := []struct {
+		value       string
+		expectError bool
+	}{
+		{value: lambda.RuntimePython38},
+		{value: lambda.RuntimePython38 + " "},
+		{value: "Python3.8", expectError: true},
+		{value: "python9.9", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validateFunc(testCase.value, "runtime")
+
+		if got := len(errs) > 0; got != testCase.expectError {
+			t.Errorf("%q: got errors %v, expected error: %t", testCase.value, errs, testCase.expectError)
+		}
+	}
+}
+
+func TestLambdaFunctionTracingModeStateFunc(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "Active", expected: lambda.TracingModeActive},
+		{value: "active", expected: lambda.TracingModeActive},
+		{value: "ACTIVE ", expected: lambda.TracingModeActive},
+		{value: "passthrough", expected: lambda.TracingModePassThrough},
+		{value: "PassThrough", expected: lambda.TracingModePassThrough},
+	}
+
+	for _, testCase := range testCases {
+		if got := lambdaFunctionTracingModeStateFunc(testCase.value); got != testCase.expected {
+			t.Errorf("%q: got %q, expected %q", testCase.value, got, testCase.expected)
+		}
+	}
+}
+
+func TestPlanLambdaFunctionUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configUpdate  bool
+		codeUpdate    bool
+		publish       bool
+		publishChange bool
+		expected      lambdaFunctionUpdatePlan
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name:         "configuration only",
+			configUpdate: true,
+			expected:     lambdaFunctionUpdatePlan{updateConfiguration: true, waitForUpdate: true},
+		},
+		{
+			name:         "configuration only with publish",
+			configUpdate: true,
+			publish:      true,
+			expected:     lambdaFunctionUpdatePlan{updateConfiguration: true, waitForUpdate: true, publishVersion: true},
+		},
+		{
+			name:       "code only",
+			codeUpdate: true,
+			expected:   lambdaFunctionUpdatePlan{updateCode: true, waitForUpdate: true},
+		},
+		{
+			name:       "code only with publish",
+			codeUpdate: true,
+			publish:    true,
+			expected:   lambdaFunctionUpdatePlan{updateCode: true, publishWithCode: true, waitForUpdate: true},
+		},
+		{
+			name:         "configuration and code",
+			configUpdate: true,
+			codeUpdate:   true,
+			expected:     lambdaFunctionUpdatePlan{updateConfiguration: true, updateCode: true, waitForUpdate: true},
+		},
+		{
+			name:         "configuration and code with publish",
+			configUpdate: true,
+			codeUpdate:   true,
+			publish:      true,
+			expected:     lambdaFunctionUpdatePlan{updateConfiguration: true, updateCode: true, publishWithCode: true, waitForUpdate: true},
+		},
+		{
+			name:          "publish enabled",
+			publish:       true,
+			publishChange: true,
+			expected:      lambdaFunctionUpdatePlan{publishVersion: true},
+		},
+		{
+			name:          "publish disabled",
+			publishChange: true,
+		},
+		{
+			name:    "publish without changes",
+			publish: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := planLambdaFunctionUpdate(testCase.configUpdate, testCase.codeUpdate, testCase.publish, testCase.publishChange)
+
+			if got != testCase.expected {
+				t.Errorf("got %+v, expected %+v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionVersionAttributes(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		function             *lambda.FunctionConfiguration
+		checkArn             bool
+		expectedVersion      string
+		expectedQualifiedArn string
+		expectError          bool
+	}{
+		{
+			name:        "no version",
+			checkArn:    true,
+			expectError: true,
+		},
+		{
+			name: "latest",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test:$LATEST"),
+				Version:     aws.String(LambdaFunctionVersionLatest),
+			},
+			checkArn:             true,
+			expectedVersion:      LambdaFunctionVersionLatest,
+			expectedQualifiedArn: "arn:aws:lambda:us-west-2:123456789012:function:test:$LATEST",
+		},
+		{
+			name: "published version",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test:12"),
+				Version:     aws.String("12"),
+			},
+			checkArn:             true,
+			expectedVersion:      "12",
+			expectedQualifiedArn: "arn:aws:lambda:us-west-2:123456789012:function:test:12",
+		},
+		{
+			name: "version ahead of ARN",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test:1"),
+				Version:     aws.String("2"),
+			},
+			checkArn:    true,
+			expectError: true,
+		},
+		{
+			name: "version is a suffix of ARN qualifier",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test:12"),
+				Version:     aws.String("2"),
+			},
+			checkArn:    true,
+			expectError: true,
+		},
+		{
+			name: "unqualified ARN",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test"),
+				Version:     aws.String("3"),
+			},
+			checkArn:    true,
+			expectError: true,
+		},
+		{
+			name: "alias qualifier without check",
+			function: &lambda.FunctionConfiguration{
+				FunctionArn: aws.String("arn:aws:lambda:us-west-2:123456789012:function:test:live"),
+				Version:     aws.String("3"),
+			},
+			expectedVersion:      "3",
+			expectedQualifiedArn: "arn:aws:lambda:us-west-2:123456789012:function:test:live",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			version, qualifiedArn, err := lambdaFunctionVersionAttributes(testCase.function, testCase.checkArn)
+
+			if err == nil && testCase.expectError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil && !testCase.expectError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if version != testCase.expectedVersion {
+				t.Errorf("got version %q, expected %q", version, testCase.expectedVersion)
+			}
+
+			if qualifiedArn != testCase.expectedQualifiedArn {
+				t.Errorf("got qualified ARN %q, expected %q", qualifiedArn, testCase.expectedQualifiedArn)
+			}
+		})
+	}
+}
+
 func TestAccAWSLambdaFunction_basic(t *testing.T) {
 	var conf lambda.GetFunctionOutput
 	resourceName := "aws_lambda_function.test"
@@ -87,13 +1691,14 @@ func TestAccAWSLambdaFunction_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "version", LambdaFunctionVersionLatest),
 					resource.TestCheckResourceAttr(resourceName, "package_type", lambda.PackageTypeZip),
 					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, LambdaFunctionVersionLatest)),
+					testAccCheckAwsLambdaFunctionQualifiedInvokeArn(resourceName, &conf, LambdaFunctionVersionLatest),
 				),
 			},
 			{
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -152,7 +1757,7 @@ func TestAccAWSLambdaFunction_UnpublishedCodeUpdate(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -209,7 +1814,7 @@ func TestAccAWSLambdaFunction_codeSigningConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigCSCUpdate(roleName, funcName),
@@ -224,7 +1829,7 @@ func TestAccAWSLambdaFunction_codeSigningConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigCSCDelete(roleName, funcName),
@@ -267,7 +1872,7 @@ func TestAccAWSLambdaFunction_concurrency(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigConcurrencyUpdate(funcName, policyName, roleName, sgName),
@@ -310,7 +1915,7 @@ func TestAccAWSLambdaFunction_concurrencyCycle(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigConcurrencyUpdate(funcName, policyName, roleName, sgName),
@@ -382,7 +1987,7 @@ func TestAccAWSLambdaFunction_envVariables(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigEnvVariables(funcName, policyName, roleName, sgName),
@@ -416,6 +2021,58 @@ func TestAccAWSLambdaFunction_envVariables(t *testing.T) {
 	})
 }
 
+func TestAccAWSLambdaFunction_sensitiveEnvVariables(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	rString := acctest.RandString(8)
+	keyDesc := fmt.Sprintf("tf_acc_key_lambda_func_sensitive_env_%s", rString)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_sensitive_env_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_sensitive_env_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_sensitive_env_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_sensitive_env_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigSensitiveEnvVariables(keyDesc, funcName, policyName, roleName, sgName, "secret1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					testAccCheckAwsLambdaFunctionEnvironmentVariables(&conf, map[string]string{"foo": "bar", "token": "secret1"}),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.variables.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.variables.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.sensitive_variables.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.sensitive_variables.token", "secret1"),
+					testAccMatchResourceAttrRegionalARN(resourceName, "kms_key_arn", "kms", regexp.MustCompile(`key/.+`)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Import cannot tell which variables were configured as sensitive.
+				ImportStateVerifyIgnore: []string{"code_updated_at", "environment", "filename", "publish"},
+			},
+			{
+				Config: testAccAWSLambdaConfigSensitiveEnvVariables(keyDesc, funcName, policyName, roleName, sgName, "secret2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					testAccCheckAwsLambdaFunctionEnvironmentVariables(&conf, map[string]string{"foo": "bar", "token": "secret2"}),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.variables.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "environment.0.sensitive_variables.token", "secret2"),
+				),
+			},
+			{
+				Config:      testAccAWSLambdaConfigSensitiveEnvVariablesDuplicateKey(funcName, policyName, roleName, sgName),
+				ExpectError: regexp.MustCompile(`environment variables token cannot be set in both variables and sensitive_variables`),
+			},
+		},
+	})
+}
+
 func TestAccAWSLambdaFunction_encryptedEnvVariables(t *testing.T) {
 	var conf lambda.GetFunctionOutput
 
@@ -446,7 +2103,7 @@ func TestAccAWSLambdaFunction_encryptedEnvVariables(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigEncryptedEnvVariablesModified(keyDesc, funcName, policyName, roleName, sgName),
@@ -462,6 +2119,47 @@ func TestAccAWSLambdaFunction_encryptedEnvVariables(t *testing.T) {
 	})
 }
 
+func TestAccAWSLambdaFunction_versionedWhitespaceChange(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_ws_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_ws_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_ws_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_ws_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigPublishableWithSettings(funcName, policyName, roleName, sgName, "exports.example", "nodejs12.x", "Active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				Config:   testAccAWSLambdaConfigPublishableWithSettings(funcName, policyName, roleName, sgName, " exports.example ", "nodejs12.x ", "active"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAWSLambdaConfigPublishableWithSettings(funcName, policyName, roleName, sgName, " exports.example ", "nodejs12.x ", "active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "handler", "exports.example"),
+					resource.TestCheckResourceAttr(resourceName, "runtime", lambda.RuntimeNodejs12X),
+					resource.TestCheckResourceAttr(resourceName, "tracing_config.0.mode", lambda.TracingModeActive),
+					testAccCheckAwsLambdaFunctionVersionCount(funcName, 1),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSLambdaFunction_versioned(t *testing.T) {
 	var conf lambda.GetFunctionOutput
 
@@ -487,13 +2185,14 @@ func TestAccAWSLambdaFunction_versioned(t *testing.T) {
 					testAccCheckResourceAttrRegionalARN(resourceName, "arn", "lambda", fmt.Sprintf("function:%s", funcName)),
 					resource.TestCheckResourceAttr(resourceName, "version", version),
 					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, version)),
+					testAccCheckAwsLambdaFunctionQualifiedInvokeArn(resourceName, &conf, version),
 				),
 			},
 			{
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -530,6 +2229,7 @@ func TestAccAWSLambdaFunction_versionedUpdate(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "version", "1"),
 					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, "1")),
+					resource.TestCheckResourceAttrPair(resourceName, "qualified_code_sha256", resourceName, "source_code_hash"),
 				),
 			},
 			{
@@ -547,6 +2247,7 @@ func TestAccAWSLambdaFunction_versionedUpdate(t *testing.T) {
 					testAccCheckResourceAttrRegionalARN(resourceName, "arn", "lambda", fmt.Sprintf("function:%s", funcName)),
 					resource.TestCheckResourceAttr(resourceName, "version", version),
 					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, version)),
+					resource.TestCheckResourceAttrPair(resourceName, "qualified_code_sha256", resourceName, "source_code_hash"),
 					func(s *terraform.State) error {
 						return testAccCheckAttributeIsDateAfter(s, resourceName, "last_modified", timeBeforeUpdate)
 					},
@@ -557,14 +2258,15 @@ func TestAccAWSLambdaFunction_versionedUpdate(t *testing.T) {
 				PreConfig: func() {
 					timeBeforeUpdate = time.Now()
 				},
-				Config: testAccAWSLambdaConfigVersionedNodeJs10xRuntime(path, funcName, policyName, roleName, sgName),
+				Config: testAccAWSLambdaConfigVersionedPython38Runtime(path, funcName, policyName, roleName, sgName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
 					testAccCheckAwsLambdaFunctionName(&conf, funcName),
 					testAccCheckResourceAttrRegionalARN(resourceName, "arn", "lambda", fmt.Sprintf("function:%s", funcName)),
 					resource.TestCheckResourceAttr(resourceName, "version", versionUpdated),
 					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, versionUpdated)),
-					resource.TestCheckResourceAttr(resourceName, "runtime", lambda.RuntimeNodejs10X),
+					testAccCheckAwsLambdaFunctionQualifiedInvokeArn(resourceName, &conf, versionUpdated),
+					resource.TestCheckResourceAttr(resourceName, "runtime", lambda.RuntimePython38),
 					func(s *terraform.State) error {
 						return testAccCheckAttributeIsDateAfter(s, resourceName, "last_modified", timeBeforeUpdate)
 					},
@@ -574,7 +2276,82 @@ func TestAccAWSLambdaFunction_versionedUpdate(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_versionedLatestVersion(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	path, zipFile, err := createTempFile("lambda_localUpdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_versioned_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_versioned_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_versioned_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_versioned_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigPublishable("test-fixtures/lambdatest.zip", funcName, policyName, roleName, sgName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, "1")),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccCreateZipFromFiles(map[string]string{"test-fixtures/lambda_func_modified.js": "lambda.js"}, zipFile); err != nil {
+						t.Fatalf("error creating zip from files: %s", err)
+					}
+				},
+				Config: testAccAWSLambdaConfigPublishable(path, funcName, policyName, roleName, sgName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, "2")),
+				),
+			},
+			{
+				// Deleting the latest version makes the previous version the latest
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).lambdaconn
+
+					_, err := conn.DeleteFunction(&lambda.DeleteFunctionInput{
+						FunctionName: aws.String(funcName),
+						Qualifier:    aws.String("2"),
+					})
+
+					if err != nil {
+						t.Fatalf("error deleting Lambda Function (%s) version 2: %s", funcName, err)
+					}
+				},
+				Config: testAccAWSLambdaConfigPublishable(path, funcName, policyName, roleName, sgName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, "1")),
+				),
+			},
+			{
+				// Version numbers are not reused after deletion
+				Config: testAccAWSLambdaConfigVersionedPython38Runtime(path, funcName, policyName, roleName, sgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "version", "3"),
+					testAccCheckResourceAttrRegionalARN(resourceName, "qualified_arn", "lambda", fmt.Sprintf("function:%s:%s", funcName, "3")),
+					testAccCheckAwsLambdaFunctionQualifiedInvokeArn(resourceName, &conf, "3"),
+				),
 			},
 		},
 	})
@@ -626,7 +2403,7 @@ func TestAccAWSLambdaFunction_enablePublish(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				// No changes, `publish` is true. This should not publish a new version.
@@ -689,7 +2466,7 @@ func TestAccAWSLambdaFunction_disablePublish(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -732,15 +2509,45 @@ func TestAccAWSLambdaFunction_DeadLetterConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
+			},
+			// Ensure a dead letter target removed outside of Terraform is detected
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).lambdaconn
+
+					_, err := conn.UpdateFunctionConfiguration(&lambda.UpdateFunctionConfigurationInput{
+						FunctionName:     aws.String(funcName),
+						DeadLetterConfig: &lambda.DeadLetterConfig{TargetArn: aws.String("")},
+					})
+
+					if err != nil {
+						t.Fatalf("error removing Lambda Function (%s) dead letter config: %s", funcName, err)
+					}
+				},
+				Config:             testAccAWSLambdaConfigWithDeadLetterConfig(funcName, topicName, policyName, roleName, sgName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSLambdaConfigWithDeadLetterConfig(funcName, topicName, policyName, roleName, sgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttrPair(resourceName, "dead_letter_config.0.target_arn", "aws_sns_topic.test", "arn"),
+				),
 			},
 			// Ensure configuration can be removed
 			{
 				Config: testAccAWSLambdaConfigBasic(funcName, policyName, roleName, sgName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "dead_letter_config.#", "0"),
 				),
 			},
+			{
+				Config:      testAccAWSLambdaConfigWithDeadLetterConfigRole(funcName, policyName, roleName, sgName),
+				ExpectError: regexp.MustCompile(`must be an SQS queue or SNS topic ARN`),
+			},
 		},
 	})
 }
@@ -783,7 +2590,7 @@ func TestAccAWSLambdaFunction_DeadLetterConfigUpdated(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -842,7 +2649,7 @@ func TestAccAWSLambdaFunction_FileSystemConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			// Ensure lambda file system configuration can be updated
 			{
@@ -865,6 +2672,72 @@ func TestAccAWSLambdaFunction_FileSystemConfig(t *testing.T) {
 	})
 }
 
+// testAccAwsLambdaImageRepository returns the repository URI and name of an image URI,
+// e.g. 123456789012.dkr.ecr.us-west-2.amazonaws.com/repo:tag.
+func testAccAwsLambdaImageRepository(imageID string) (string, string) {
+	repositoryURI := imageID
+
+	if i := strings.LastIndex(repositoryURI, ":"); i > strings.Index(repositoryURI, "/") {
+		repositoryURI = repositoryURI[:i]
+	}
+
+	return repositoryURI, repositoryURI[strings.Index(repositoryURI, "/")+1:]
+}
+
+// testAccAwsLambdaFunctionImageTag points tag at the image identified by imageID,
+// which must be in the same repository.
+func testAccAwsLambdaFunctionImageTag(t *testing.T, imageID, tag string) {
+	conn := testAccProvider.Meta().(*AWSClient).ecrconn
+	_, repositoryName := testAccAwsLambdaImageRepository(imageID)
+
+	output, err := conn.BatchGetImage(&ecr.BatchGetImageInput{
+		ImageIds:       []*ecr.ImageIdentifier{{ImageTag: aws.String(imageID[strings.LastIndex(imageID, ":")+1:])}},
+		RepositoryName: aws.String(repositoryName),
+	})
+
+	if err != nil {
+		t.Fatalf("error getting ECR image (%s): %s", imageID, err)
+	}
+
+	if len(output.Images) == 0 {
+		t.Fatalf("ECR image (%s) not found", imageID)
+	}
+
+	_, err = conn.PutImage(&ecr.PutImageInput{
+		ImageManifest:          output.Images[0].ImageManifest,
+		ImageManifestMediaType: output.Images[0].ImageManifestMediaType,
+		ImageTag:               aws.String(tag),
+		RepositoryName:         aws.String(repositoryName),
+	})
+
+	if err != nil && !isAWSErr(err, ecr.ErrCodeImageAlreadyExistsException, "") {
+		t.Fatalf("error tagging ECR image (%s) with (%s): %s", imageID, tag, err)
+	}
+}
+
+func testAccCheckAwsLambdaFunctionImageTagDestroy(imageID, tag string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if err := testAccCheckLambdaFunctionDestroy(s); err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ecrconn
+		_, repositoryName := testAccAwsLambdaImageRepository(imageID)
+
+		// Deleting by tag only removes the tag while other tags refer to the image.
+		_, err := conn.BatchDeleteImage(&ecr.BatchDeleteImageInput{
+			ImageIds:       []*ecr.ImageIdentifier{{ImageTag: aws.String(tag)}},
+			RepositoryName: aws.String(repositoryName),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error deleting ECR image tag (%s): %w", tag, err)
+		}
+
+		return nil
+	}
+}
+
 func testAccLambdaImagePreCheck(t *testing.T) {
 	if (os.Getenv("AWS_LAMBDA_IMAGE_LATEST_ID") == "") || (os.Getenv("AWS_LAMBDA_IMAGE_V1_ID") == "") || (os.Getenv("AWS_LAMBDA_IMAGE_V2_ID") == "") {
 		t.Skip("AWS_LAMBDA_IMAGE_LATEST_ID, AWS_LAMBDA_IMAGE_V1_ID and AWS_LAMBDA_IMAGE_V2_ID env vars must be set for Lambda Container Image Support acceptance tests. ")
@@ -913,23 +2786,230 @@ func TestAccAWSLambdaFunction_imageConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			// Ensure lambda image code can be updated
 			{
-				Config: testAccAWSLambdaImageConfigUpdateCode(funcName, policyName, roleName, sgName, imageV1ID),
+				Config: testAccAWSLambdaImageConfigUpdateCode(funcName, policyName, roleName, sgName, imageV1ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV1ID),
+				),
+			},
+			// Ensure lambda image config can be updated
+			{
+				Config: testAccAWSLambdaImageConfigUpdateConfig(funcName, policyName, roleName, sgName, imageV2ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV2ID),
+					resource.TestCheckResourceAttr(resourceName, "image_config.0.command.0", "app.another_handler"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_imageConfigRemoved(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+	resourceName := "aws_lambda_function.test"
+
+	imageLatestID := os.Getenv("AWS_LAMBDA_IMAGE_LATEST_ID")
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_basic_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_basic_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_basic_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_basic_%s", rString)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccLambdaImagePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaImageConfig(funcName, policyName, roleName, sgName, imageLatestID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_config.#", "1"),
+				),
+			},
+			{
+				Config: testAccAWSLambdaImageConfigUpdateCode(funcName, policyName, roleName, sgName, imageLatestID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_config.#", "0"),
+					testAccCheckAwsLambdaFunctionNoImageConfigOverrides(&conf),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsLambdaFunctionNoImageConfigOverrides(function *lambda.GetFunctionOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		response := function.Configuration.ImageConfigResponse
+
+		if response == nil || response.ImageConfig == nil {
+			return nil
+		}
+
+		if imageConfig := response.ImageConfig; len(imageConfig.EntryPoint) > 0 || len(imageConfig.Command) > 0 || aws.StringValue(imageConfig.WorkingDirectory) != "" {
+			return fmt.Errorf("expected no image configuration overrides, got %s", imageConfig)
+		}
+
+		return nil
+	}
+}
+
+func TestAccAWSLambdaFunction_packageTypeZipToImage(t *testing.T) {
+	var before, after lambda.GetFunctionOutput
+	resourceName := "aws_lambda_function.test"
+
+	imageLatestID := os.Getenv("AWS_LAMBDA_IMAGE_LATEST_ID")
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_basic_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_basic_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_basic_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_basic_%s", rString)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccLambdaImagePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigBasic(funcName, policyName, roleName, sgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &before),
+					resource.TestCheckResourceAttr(resourceName, "package_type", lambda.PackageTypeZip),
+					resource.TestCheckResourceAttr(resourceName, "handler", "exports.example"),
+					resource.TestCheckResourceAttr(resourceName, "runtime", lambda.RuntimeNodejs12X),
+				),
+			},
+			{
+				Config: testAccAWSLambdaImageConfigUpdateCode(funcName, policyName, roleName, sgName, imageLatestID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &after),
+					resource.TestCheckResourceAttr(resourceName, "package_type", lambda.PackageTypeImage),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageLatestID),
+					resource.TestCheckResourceAttr(resourceName, "handler", ""),
+					resource.TestCheckResourceAttr(resourceName, "runtime", ""),
+				),
+			},
+			{
+				Config:   testAccAWSLambdaImageConfigUpdateCode(funcName, policyName, roleName, sgName, imageLatestID),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_imagePublish(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+	resourceName := "aws_lambda_function.test"
+
+	imageLatestID := os.Getenv("AWS_LAMBDA_IMAGE_LATEST_ID")
+	imageV1ID := os.Getenv("AWS_LAMBDA_IMAGE_V1_ID")
+	imageV2ID := os.Getenv("AWS_LAMBDA_IMAGE_V2_ID")
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_image_pub_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_image_pub_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_image_pub_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_image_pub_%s", rString)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccLambdaImagePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaImageConfigPublish(funcName, policyName, roleName, sgName, imageLatestID, "app.lambda_handler"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageLatestID),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			// Image update alone publishes with the code update
+			{
+				Config: testAccAWSLambdaImageConfigPublish(funcName, policyName, roleName, sgName, imageV1ID, "app.lambda_handler"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV1ID),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			// Image and configuration update must wait for the function to settle before publishing
+			{
+				Config: testAccAWSLambdaImageConfigPublish(funcName, policyName, roleName, sgName, imageV2ID, "app.another_handler"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV2ID),
+					resource.TestCheckResourceAttr(resourceName, "image_config.0.command.0", "app.another_handler"),
+					resource.TestCheckResourceAttr(resourceName, "version", "3"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSLambdaFunction_imagePublishSourceCodeHash pushes a new image to the
+// same tag, as a CI pipeline would, by moving a test tag between the v1 and v2 images.
+func TestAccAWSLambdaFunction_imagePublishSourceCodeHash(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+	resourceName := "aws_lambda_function.test"
+	dataSourceName := "data.aws_ecr_image.test"
+
+	imageV1ID := os.Getenv("AWS_LAMBDA_IMAGE_V1_ID")
+	imageV2ID := os.Getenv("AWS_LAMBDA_IMAGE_V2_ID")
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_image_hash_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_image_hash_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_image_hash_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_image_hash_%s", rString)
+	tag := fmt.Sprintf("tf-acc-test-%s", rString)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccLambdaImagePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsLambdaFunctionImageTagDestroy(imageV1ID, tag),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccAwsLambdaFunctionImageTag(t, imageV1ID, tag)
+				},
+				Config: testAccAWSLambdaImageConfigPublishSourceCodeHash(funcName, policyName, roleName, sgName, imageV1ID, tag),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV1ID),
+					resource.TestCheckResourceAttrPair(resourceName, "source_code_hash", dataSourceName, "image_digest"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
 				),
 			},
-			// Ensure lambda image config can be updated
+			// The image_uri is unchanged, only the digest of the tag changes
 			{
-				Config: testAccAWSLambdaImageConfigUpdateConfig(funcName, policyName, roleName, sgName, imageV2ID),
+				PreConfig: func() {
+					testAccAwsLambdaFunctionImageTag(t, imageV2ID, tag)
+				},
+				Config: testAccAWSLambdaImageConfigPublishSourceCodeHash(funcName, policyName, roleName, sgName, imageV1ID, tag),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "image_uri", imageV2ID),
-					resource.TestCheckResourceAttr(resourceName, "image_config.0.command.0", "app.another_handler"),
+					resource.TestCheckResourceAttrPair(resourceName, "source_code_hash", dataSourceName, "image_digest"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
 				),
 			},
 		},
@@ -968,7 +3048,7 @@ func TestAccAWSLambdaFunction_tracingConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigWithTracingConfigUpdated(funcName, policyName, roleName, sgName),
@@ -1010,7 +3090,7 @@ func TestAccAWSLambdaFunction_KmsKeyArn_NoEnvironmentVariables(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -1046,7 +3126,7 @@ func TestAccAWSLambdaFunction_Layers(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -1083,7 +3163,7 @@ func TestAccAWSLambdaFunction_LayersUpdate(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigWithLayersUpdated(funcName, layerName, layer2Name, policyName, roleName, sgName),
@@ -1099,6 +3179,57 @@ func TestAccAWSLambdaFunction_LayersUpdate(t *testing.T) {
 	})
 }
 
+func TestAccAWSLambdaFunction_LayersIgnoreOrder(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_layer_%s", rString)
+	layerName := fmt.Sprintf("tf_acc_lambda_layer_%s", rString)
+	layer2Name := fmt.Sprintf("tf_acc_lambda_layer2_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_vpc_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_vpc_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_vpc_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigWithLayersOrder(funcName, layerName, layer2Name, policyName, roleName, sgName, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "ignore_layer_order", "true"),
+					resource.TestCheckResourceAttr(resourceName, "layers.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "layers.0", "aws_lambda_layer_version.test", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "layers.1", "aws_lambda_layer_version.test_2", "arn"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "ignore_layer_order", "publish"},
+			},
+			{
+				Config:   testAccAWSLambdaConfigWithLayersOrder(funcName, layerName, layer2Name, policyName, roleName, sgName, true, true),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAWSLambdaConfigWithLayersOrder(funcName, layerName, layer2Name, policyName, roleName, sgName, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "ignore_layer_order", "false"),
+					resource.TestCheckResourceAttr(resourceName, "layers.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "layers.0", "aws_lambda_layer_version.test_2", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "layers.1", "aws_lambda_layer_version.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSLambdaFunction_VPC(t *testing.T) {
 	var conf lambda.GetFunctionOutput
 
@@ -1131,7 +3262,42 @@ func TestAccAWSLambdaFunction_VPC(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_VPC_WaitForNetworkInterfaces(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_vpc_eni_%s", rString)
+	policyName := fmt.Sprintf("tf_acc_policy_lambda_func_vpc_eni_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_vpc_eni_%s", rString)
+	sgName := fmt.Sprintf("tf_acc_sg_lambda_func_vpc_eni_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	// The security group and subnet are destroyed with the function, which
+	// fails with DependencyViolation if its Lambda ENIs are still in use.
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigWithVPCWaitForNetworkInterfaces(funcName, policyName, roleName, sgName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "vpc_config.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_network_interfaces", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish", "wait_for_network_interfaces"},
 			},
 		},
 	})
@@ -1159,7 +3325,7 @@ func TestAccAWSLambdaFunction_VPCRemoval(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigBasic(rName, rName, rName, rName),
@@ -1204,7 +3370,7 @@ func TestAccAWSLambdaFunction_VPCUpdate(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigWithVPCUpdated(funcName, policyName, roleName, sgName, sgName2),
@@ -1250,7 +3416,7 @@ func TestAccAWSLambdaFunction_VPC_withInvocation(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -1309,7 +3475,7 @@ func TestAccAWSLambdaFunction_EmptyVpcConfig(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 		},
 	})
@@ -1342,7 +3508,7 @@ func TestAccAWSLambdaFunction_s3(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"s3_bucket", "s3_key", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "s3_bucket", "s3_key", "publish"},
 			},
 		},
 	})
@@ -1387,7 +3553,7 @@ func TestAccAWSLambdaFunction_localUpdate(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				PreConfig: func() {
@@ -1411,6 +3577,134 @@ func TestAccAWSLambdaFunction_localUpdate(t *testing.T) {
 	})
 }
 
+func TestAccAWSLambdaFunction_codeUpdatedAt(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	path, zipFile, err := createTempFile("lambda_codeUpdatedAt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_code_upd_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_code_upd_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	var codeUpdatedAt string
+	var timeBeforeUpdate time.Time
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccCreateZipFromFiles(map[string]string{"test-fixtures/lambda_func.js": "lambda.js"}, zipFile); err != nil {
+						t.Fatalf("error creating zip from files: %s", err)
+					}
+				},
+				Config: genAWSLambdaFunctionConfig_localDescription(path, roleName, funcName, "description1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					testAccCheckAwsLambdaFunctionCodeUpdatedAt(resourceName, &codeUpdatedAt, true),
+				),
+			},
+			{
+				Config: genAWSLambdaFunctionConfig_localDescription(path, roleName, funcName, "description2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "description", "description2"),
+					testAccCheckAwsLambdaFunctionCodeUpdatedAt(resourceName, &codeUpdatedAt, false),
+				),
+			},
+			{
+				// Re-applying unchanged configuration refreshes state without updates.
+				Config: genAWSLambdaFunctionConfig_localDescription(path, roleName, funcName, "description2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionCodeUpdatedAt(resourceName, &codeUpdatedAt, false),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccCreateZipFromFiles(map[string]string{"test-fixtures/lambda_func_modified.js": "lambda.js"}, zipFile); err != nil {
+						t.Fatalf("error creating zip from files: %s", err)
+					}
+					timeBeforeUpdate = time.Now().Add(-1 * time.Second)
+				},
+				Config: genAWSLambdaFunctionConfig_localDescription(path, roleName, funcName, "description2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					testAccCheckAwsLambdaSourceCodeHash(&conf, "0tdaP9H9hsk9c2CycSwOG/sa/x5JyAmSYunA/ce99Pg="),
+					func(s *terraform.State) error {
+						return testAccCheckAttributeIsDateAfter(s, resourceName, "code_updated_at", timeBeforeUpdate)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_detectCodeDrift(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+
+	rString := acctest.RandString(8)
+	funcName := fmt.Sprintf("tf_acc_lambda_func_code_drift_%s", rString)
+	roleName := fmt.Sprintf("tf_acc_role_lambda_func_code_drift_%s", rString)
+	resourceName := "aws_lambda_function.test"
+
+	path, zipFile, err := createTempFile("lambda_codeDrift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	outOfBandPath, outOfBandZipFile, err := createTempFile("lambda_codeDrift_outOfBand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(outOfBandPath)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccCreateZipFromFiles(map[string]string{"test-fixtures/lambda_func.js": "lambda.js"}, zipFile); err != nil {
+						t.Fatalf("error creating zip from files: %s", err)
+					}
+					if err := testAccCreateZipFromFiles(map[string]string{"test-fixtures/lambda_func_modified.js": "lambda.js"}, outOfBandZipFile); err != nil {
+						t.Fatalf("error creating zip from files: %s", err)
+					}
+				},
+				Config: genAWSLambdaFunctionConfig_detectCodeDrift(path, roleName, funcName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "detect_code_drift", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "deployed_code_sha256", resourceName, "source_code_hash"),
+					testAccAwsLambdaFunctionUpdateCodeOutOfBand(funcName, outOfBandPath),
+				),
+			},
+			{
+				Config:             genAWSLambdaFunctionConfig_detectCodeDrift(path, roleName, funcName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: genAWSLambdaFunctionConfig_detectCodeDrift(path, roleName, funcName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, funcName, &conf),
+					resource.TestCheckResourceAttrPair(resourceName, "deployed_code_sha256", resourceName, "source_code_hash"),
+					testAccCheckAwsLambdaSourceCodeHash(&conf, "8DPiX+G1l2LQ8hjBkwRchQFf1TSCEvPrYGRKlM9UoyY="),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSLambdaFunction_localUpdate_nameOnly(t *testing.T) {
 	var conf lambda.GetFunctionOutput
 
@@ -1454,7 +3748,7 @@ func TestAccAWSLambdaFunction_localUpdate_nameOnly(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				PreConfig: func() {
@@ -1515,7 +3809,7 @@ func TestAccAWSLambdaFunction_s3Update_basic(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish", "s3_bucket", "s3_key", "s3_object_version"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish", "s3_bucket", "s3_key", "s3_object_version"},
 			},
 			{
 				PreConfig: func() {
@@ -1577,7 +3871,7 @@ func TestAccAWSLambdaFunction_s3Update_unversioned(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish", "s3_bucket", "s3_key"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish", "s3_bucket", "s3_key"},
 			},
 			{
 				PreConfig: func() {
@@ -1626,7 +3920,7 @@ func TestAccAWSLambdaFunction_tags(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filename", "publish"},
+				ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 			},
 			{
 				Config: testAccAWSLambdaConfigTags(funcName, policyName, roleName, sgName),
@@ -1655,6 +3949,63 @@ func TestAccAWSLambdaFunction_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSLambdaFunction_deprecatedRuntime(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSLambdaConfigRuntime(rName, lambda.RuntimePython27),
+				ExpectError: regexp.MustCompile(`runtime python2.7 is deprecated`),
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_codeSigningConfigImage(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSLambdaConfigCodeSigningConfigImage(rName),
+				ExpectError: regexp.MustCompile(`code_signing_config_arn cannot be set when package_type is Image`),
+			},
+		},
+	})
+}
+
+func TestAccAWSLambdaFunction_packageTypeSwitch(t *testing.T) {
+	var conf lambda.GetFunctionOutput
+	resourceName := "aws_lambda_function.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLambdaFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLambdaConfigRuntime(rName, lambda.RuntimeNodejs12X),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsLambdaFunctionExists(resourceName, rName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "package_type", lambda.PackageTypeZip),
+				),
+			},
+			{
+				Config:      testAccAWSLambdaConfigPackageTypeSwitch(rName),
+				ExpectError: regexp.MustCompile(`when package_type is Image, handler, runtime must be removed`),
+			},
+		},
+	})
+}
+
 func TestAccAWSLambdaFunction_runtimes(t *testing.T) {
 	var v lambda.GetFunctionOutput
 	resourceName := "aws_lambda_function.test"
@@ -1668,21 +4019,7 @@ func TestAccAWSLambdaFunction_runtimes(t *testing.T) {
 		},
 	}
 	for _, runtime := range lambda.Runtime_Values() {
-		// EOL runtimes.
-		switch runtime {
-		case lambda.RuntimeNodejs43Edge:
-			fallthrough
-		case lambda.RuntimeDotnetcore20:
-			fallthrough
-		case lambda.RuntimeDotnetcore10:
-			fallthrough
-		case lambda.RuntimeNodejs810:
-			fallthrough
-		case lambda.RuntimeNodejs610:
-			fallthrough
-		case lambda.RuntimeNodejs43:
-			fallthrough
-		case lambda.RuntimeNodejs:
+		if isLambdaFunctionDeprecatedRuntime(runtime) {
 			continue
 		}
 
@@ -1698,7 +4035,7 @@ func TestAccAWSLambdaFunction_runtimes(t *testing.T) {
 		ResourceName:            resourceName,
 		ImportState:             true,
 		ImportStateVerify:       true,
-		ImportStateVerifyIgnore: []string{"filename", "publish"},
+		ImportStateVerifyIgnore: []string{"code_updated_at", "filename", "publish"},
 	})
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -1774,13 +4111,49 @@ func testAccCheckAwsLambdaFunctionExists(res, funcName string, function *lambda.
 	}
 }
 
-func testAccCheckAwsLambdaFunctionInvokeArn(name string, function *lambda.GetFunctionOutput) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		arn := aws.StringValue(function.Configuration.FunctionArn)
-		return testAccCheckResourceAttrRegionalARNAccountID(name, "invoke_arn", "apigateway", "lambda", fmt.Sprintf("path/2015-03-31/functions/%s/invocations", arn))(s)
-	}
-}
-
+func testAccCheckAwsLambdaFunctionInvokeArn(name string, function *lambda.GetFunctionOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		arn := aws.StringValue(function.Configuration.FunctionArn)
+		return testAccCheckResourceAttrRegionalARNAccountID(name, "invoke_arn", "apigateway", "lambda", fmt.Sprintf("path/2015-03-31/functions/%s/invocations", arn))(s)
+	}
+}
+
+func testAccCheckAwsLambdaFunctionQualifiedInvokeArn(name string, function *lambda.GetFunctionOutput, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		arn := fmt.Sprintf("%s:%s", aws.StringValue(function.Configuration.FunctionArn), version)
+		return testAccCheckResourceAttrRegionalARNAccountID(name, "qualified_invoke_arn", "apigateway", "lambda", fmt.Sprintf("path/2015-03-31/functions/%s/invocations", arn))(s)
+	}
+}
+
+// testAccCheckAwsLambdaFunctionVersionCount checks the number of published versions, excluding $LATEST.
+func testAccCheckAwsLambdaFunctionVersionCount(funcName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).lambdaconn
+
+		count := 0
+		err := listVersionsByFunctionPages(conn, &lambda.ListVersionsByFunctionInput{
+			FunctionName: aws.String(funcName),
+		}, func(p *lambda.ListVersionsByFunctionOutput, lastPage bool) bool {
+			for _, v := range p.Versions {
+				if aws.StringValue(v.Version) != LambdaFunctionVersionLatest {
+					count++
+				}
+			}
+			return !lastPage
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if count != expected {
+			return fmt.Errorf("expected %d published versions of Lambda Function (%s), got %d", expected, funcName, count)
+		}
+
+		return nil
+	}
+}
+
 func testAccAwsInvokeLambdaFunction(function *lambda.GetFunctionOutput) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		f := function.Configuration
@@ -1806,6 +4179,21 @@ func testAccCheckAwsLambdaFunctionName(function *lambda.GetFunctionOutput, expec
 	}
 }
 
+func testAccCheckAwsLambdaFunctionEnvironmentVariables(function *lambda.GetFunctionOutput, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := function.Configuration
+		if c.Environment == nil {
+			return fmt.Errorf("Expected environment variables %v, got none", expected)
+		}
+
+		if got := aws.StringValueMap(c.Environment.Variables); !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("Expected environment variables %v, got %v", expected, got)
+		}
+
+		return nil
+	}
+}
+
 // Rename to correctly identify as using API values
 func testAccCheckAWSLambdaFunctionVersion(function *lambda.GetFunctionOutput, expectedVersion string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -1828,6 +4216,33 @@ func testAccCheckAwsLambdaSourceCodeHash(function *lambda.GetFunctionOutput, exp
 	}
 }
 
+// testAccCheckAwsLambdaFunctionCodeUpdatedAt checks that code_updated_at is set,
+// recording its value if record is true and otherwise checking that it is unchanged.
+func testAccCheckAwsLambdaFunctionCodeUpdatedAt(name string, codeUpdatedAt *string, record bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", name)
+		}
+
+		v := rs.Primary.Attributes["code_updated_at"]
+		if v == "" {
+			return fmt.Errorf("%s: Attribute 'code_updated_at' not set", name)
+		}
+
+		if record {
+			*codeUpdatedAt = v
+			return nil
+		}
+
+		if v != *codeUpdatedAt {
+			return fmt.Errorf("%s: Attribute 'code_updated_at' changed from %s to %s", name, *codeUpdatedAt, v)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAttributeIsDateAfter(s *terraform.State, name string, key string, before time.Time) error {
 	rs, ok := s.RootModule().Resources[name]
 	if !ok {
@@ -1887,6 +4302,30 @@ func testAccCreateZipFromFiles(files map[string]string, zipFile *os.File) error
 	return w.Flush()
 }
 
+// testAccAwsLambdaFunctionUpdateCodeOutOfBand deploys the zip file at the
+// specified path to the function directly, as a console upload or CI job would.
+func testAccAwsLambdaFunctionUpdateCodeOutOfBand(funcName, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).lambdaconn
+
+		zipFile, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.UpdateFunctionCode(&lambda.UpdateFunctionCodeInput{
+			FunctionName: aws.String(funcName),
+			ZipFile:      zipFile,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error updating Lambda Function (%s) code: %w", funcName, err)
+		}
+
+		return waitForLambdaFunctionUpdate(conn, funcName, 5*time.Minute)
+	}
+}
+
 func createTempFile(prefix string) (string, *os.File, error) {
 	f, err := ioutil.TempFile(os.TempDir(), prefix)
 	if err != nil {
@@ -2241,6 +4680,56 @@ resource "aws_lambda_function" "test" {
 `, funcName)
 }
 
+func testAccAWSLambdaConfigSensitiveEnvVariables(keyDesc, funcName, policyName, roleName, sgName, token string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
+resource "aws_lambda_function" "test" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = %[2]q
+  role          = aws_iam_role.iam_for_lambda.arn
+  handler       = "exports.example"
+  kms_key_arn   = aws_kms_key.test.arn
+  runtime       = "nodejs12.x"
+
+  environment {
+    variables = {
+      foo = "bar"
+    }
+
+    sensitive_variables = {
+      token = %[3]q
+    }
+  }
+}
+`, keyDesc, funcName, token)
+}
+
+func testAccAWSLambdaConfigSensitiveEnvVariablesDuplicateKey(funcName, policyName, roleName, sgName string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_function" "test" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = %[1]q
+  role          = aws_iam_role.iam_for_lambda.arn
+  handler       = "exports.example"
+  runtime       = "nodejs12.x"
+
+  environment {
+    variables = {
+      token = "bar"
+    }
+
+    sensitive_variables = {
+      token = "secret"
+    }
+  }
+}
+`, funcName)
+}
+
 func testAccAWSLambdaConfigEncryptedEnvVariables(keyDesc, funcName, policyName, roleName, sgName string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_kms_key" "foo" {
@@ -2348,6 +4837,23 @@ resource "aws_lambda_function" "test" {
 `, fileName, funcName, publish)
 }
 
+func testAccAWSLambdaConfigPublishableWithSettings(funcName, policyName, roleName, sgName, handler, runtime, tracingMode string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_function" "test" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = %[1]q
+  publish       = true
+  role          = aws_iam_role.iam_for_lambda.arn
+  handler       = %[2]q
+  runtime       = %[3]q
+
+  tracing_config {
+    mode = %[4]q
+  }
+}
+`, funcName, handler, runtime, tracingMode)
+}
+
 func testAccAWSLambdaFileSystemConfig(funcName, policyName, roleName, sgName string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_efs_file_system" "efs_for_lambda" {
@@ -2499,7 +5005,42 @@ resource "aws_lambda_function" "test" {
 `, imageID, funcName)
 }
 
-func testAccAWSLambdaConfigVersionedNodeJs10xRuntime(fileName, funcName, policyName, roleName, sgName string) string {
+func testAccAWSLambdaImageConfigPublish(funcName, policyName, roleName, sgName, imageID, command string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_function" "test" {
+  image_uri     = "%s"
+  function_name = "%s"
+  role          = aws_iam_role.iam_for_lambda.arn
+  package_type  = "Image"
+  publish       = true
+  image_config {
+    command = ["%s"]
+  }
+}
+`, imageID, funcName, command)
+}
+
+func testAccAWSLambdaImageConfigPublishSourceCodeHash(funcName, policyName, roleName, sgName, imageID, tag string) string {
+	repositoryURI, repositoryName := testAccAwsLambdaImageRepository(imageID)
+
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+data "aws_ecr_image" "test" {
+  repository_name = %[3]q
+  image_tag       = %[4]q
+}
+
+resource "aws_lambda_function" "test" {
+  image_uri        = "%[2]s:%[4]s"
+  source_code_hash = data.aws_ecr_image.test.image_digest
+  function_name    = %[1]q
+  role             = aws_iam_role.iam_for_lambda.arn
+  package_type     = "Image"
+  publish          = true
+}
+`, funcName, repositoryURI, repositoryName, tag)
+}
+
+func testAccAWSLambdaConfigVersionedPython38Runtime(fileName, funcName, policyName, roleName, sgName string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_lambda_function" "test" {
   filename      = "%s"
@@ -2507,7 +5048,7 @@ resource "aws_lambda_function" "test" {
   publish       = true
   role          = aws_iam_role.iam_for_lambda.arn
   handler       = "exports.example"
-  runtime       = "nodejs10.x"
+  runtime       = "python3.8"
 }
 `, fileName, funcName)
 }
@@ -2666,6 +5207,22 @@ resource "aws_sns_topic" "test_2" {
 `, funcName, topic1Name, topic2Name)
 }
 
+func testAccAWSLambdaConfigWithDeadLetterConfigRole(funcName, policyName, roleName, sgName string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_function" "test" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = "%s"
+  role          = aws_iam_role.iam_for_lambda.arn
+  handler       = "exports.example"
+  runtime       = "nodejs12.x"
+
+  dead_letter_config {
+    target_arn = aws_iam_role.iam_for_lambda.arn
+  }
+}
+`, funcName)
+}
+
 func testAccAWSLambdaConfigWithNilDeadLetterConfig(funcName, policyName, roleName, sgName string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_lambda_function" "test" {
@@ -2765,6 +5322,38 @@ resource "aws_lambda_function" "test" {
 `, layerName, layer2Name, funcName)
 }
 
+func testAccAWSLambdaConfigWithLayersOrder(funcName, layerName, layer2Name, policyName, roleName, sgName string, reversed, ignoreOrder bool) string {
+	layers := "aws_lambda_layer_version.test.arn, aws_lambda_layer_version.test_2.arn"
+
+	if reversed {
+		layers = "aws_lambda_layer_version.test_2.arn, aws_lambda_layer_version.test.arn"
+	}
+
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_layer_version" "test" {
+  filename            = "test-fixtures/lambdatest.zip"
+  layer_name          = "%[1]s"
+  compatible_runtimes = ["nodejs12.x"]
+}
+
+resource "aws_lambda_layer_version" "test_2" {
+  filename            = "test-fixtures/lambdatest_modified.zip"
+  layer_name          = "%[2]s"
+  compatible_runtimes = ["nodejs12.x"]
+}
+
+resource "aws_lambda_function" "test" {
+  filename           = "test-fixtures/lambdatest.zip"
+  function_name      = "%[3]s"
+  role               = aws_iam_role.iam_for_lambda.arn
+  handler            = "exports.example"
+  runtime            = "nodejs12.x"
+  layers             = [%[4]s]
+  ignore_layer_order = %[5]t
+}
+`, layerName, layer2Name, funcName, layers, ignoreOrder)
+}
+
 func testAccAWSLambdaConfigWithVPC(funcName, policyName, roleName, sgName string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_lambda_function" "test" {
@@ -2782,6 +5371,24 @@ resource "aws_lambda_function" "test" {
 `, funcName)
 }
 
+func testAccAWSLambdaConfigWithVPCWaitForNetworkInterfaces(funcName, policyName, roleName, sgName string) string {
+	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
+resource "aws_lambda_function" "test" {
+  filename                    = "test-fixtures/lambdatest.zip"
+  function_name               = "%s"
+  role                        = aws_iam_role.iam_for_lambda.arn
+  handler                     = "exports.example"
+  runtime                     = "nodejs12.x"
+  wait_for_network_interfaces = true
+
+  vpc_config {
+    subnet_ids         = [aws_subnet.subnet_for_lambda.id]
+    security_group_ids = [aws_security_group.sg_for_lambda.id]
+  }
+}
+`, funcName)
+}
+
 func testAccAWSLambdaConfigWithVPCUpdated(funcName, policyName, roleName, sgName, sgName2 string) string {
 	return fmt.Sprintf(baseAccAWSLambdaConfig(policyName, roleName, sgName)+`
 resource "aws_lambda_function" "test" {
@@ -2914,6 +5521,40 @@ resource "aws_lambda_function" "test" {
 `, funcName)
 }
 
+func genAWSLambdaFunctionConfig_localDescription(filePath, roleName, funcName, description string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "iam_for_lambda" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_lambda_function" "test" {
+  filename         = %[2]q
+  source_code_hash = filebase64sha256(%[2]q)
+  function_name    = %[3]q
+  description      = %[4]q
+  role             = aws_iam_role.iam_for_lambda.arn
+  handler          = "exports.example"
+  runtime          = "nodejs12.x"
+}
+`, roleName, filePath, funcName, description)
+}
+
 func genAWSLambdaFunctionConfig_local(filePath, roleName, funcName string) string {
 	return fmt.Sprintf(`
 resource "aws_iam_role" "iam_for_lambda" {
@@ -2983,6 +5624,39 @@ resource "aws_lambda_function" "test" {
 `, roleName, filePath, funcName)
 }
 
+func genAWSLambdaFunctionConfig_detectCodeDrift(filePath, roleName, funcName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "iam_for_lambda" {
+  name = "%s"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_lambda_function" "test" {
+  filename          = "%s"
+  function_name     = "%s"
+  role              = aws_iam_role.iam_for_lambda.arn
+  handler           = "exports.example"
+  runtime           = "nodejs12.x"
+  detect_code_drift = true
+}
+`, roleName, filePath, funcName)
+}
+
 func genAWSLambdaFunctionConfig_s3(bucketName, key, path, roleName, funcName string) string {
 	return fmt.Sprintf(`
 resource "aws_s3_bucket" "artifacts" {
@@ -3080,6 +5754,53 @@ resource "aws_lambda_function" "test" {
 `, bucketName, key, path, path, roleName, funcName)
 }
 
+func testAccAWSLambdaConfigCodeSigningConfigImage(rName string) string {
+	return composeConfig(
+		baseAccAWSLambdaConfig(rName, rName, rName),
+		fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+data "aws_region" "current" {}
+
+resource "aws_signer_signing_profile" "test" {
+  platform_id = "AWSLambda-SHA384-ECDSA"
+}
+
+resource "aws_lambda_code_signing_config" "test" {
+  allowed_publishers {
+    signing_profile_version_arns = [aws_signer_signing_profile.test.version_arn]
+  }
+}
+
+resource "aws_lambda_function" "test" {
+  image_uri               = "${data.aws_caller_identity.current.account_id}.dkr.ecr.${data.aws_region.current.name}.amazonaws.com/%[1]s:latest"
+  function_name           = %[1]q
+  role                    = aws_iam_role.iam_for_lambda.arn
+  package_type            = "Image"
+  code_signing_config_arn = aws_lambda_code_signing_config.test.arn
+}
+`, rName))
+}
+
+func testAccAWSLambdaConfigPackageTypeSwitch(rName string) string {
+	return composeConfig(
+		baseAccAWSLambdaConfig(rName, rName, rName),
+		fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+data "aws_region" "current" {}
+
+resource "aws_lambda_function" "test" {
+  image_uri     = "${data.aws_caller_identity.current.account_id}.dkr.ecr.${data.aws_region.current.name}.amazonaws.com/%[1]s:latest"
+  function_name = %[1]q
+  role          = aws_iam_role.iam_for_lambda.arn
+  package_type  = "Image"
+  handler       = "exports.example"
+  runtime       = "nodejs12.x"
+}
+`, rName))
+}
+
 func testAccAWSLambdaConfigRuntime(rName, runtime string) string {
 	return composeConfig(
 		baseAccAWSLambdaConfig(rName, rName, rName),
@@ -646,6 +646,33 @@ func validateLambdaPermissionEventSourceToken(v interface{}, k string) (ws []str
 	return
 }
 
+// lambdaLayerVersionArnResourceRegexp matches the resource of a Lambda layer version ARN.
+var lambdaLayerVersionArnResourceRegexp = regexp.MustCompile(`^layer:[a-zA-Z0-9_-]+:[0-9]+$`)
+
+// validateLambdaLayerVersionArn checks that a value is a Lambda layer version ARN.
+// The API rejects layer ARNs without a version only when the function is saved.
+func validateLambdaLayerVersionArn(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validateArn(v, k)
+
+	if len(errors) > 0 {
+		return ws, errors
+	}
+
+	value := v.(string)
+
+	if value == "" {
+		return ws, errors
+	}
+
+	parsedARN, _ := arn.Parse(value)
+
+	if parsedARN.Service != "lambda" || !lambdaLayerVersionArnResourceRegexp.MatchString(parsedARN.Resource) {
+		errors = append(errors, fmt.Errorf("%q (%s) must be a Lambda layer version ARN (arn:PARTITION:lambda:REGION:ACCOUNT:layer:NAME:VERSION)", k, value))
+	}
+
+	return ws, errors
+}
+
 func validateAwsAccountId(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 
@@ -200,6 +200,37 @@ func TestValidateLambdaFunctionName(t *testing.T) {
 	}
 }
 
+func TestValidateLambdaLayerVersionArn(t *testing.T) {
+	validArns := []string{
+		"arn:aws:lambda:us-west-2:123456789012:layer:example:1",             //lintignore:AWSAT003,AWSAT005
+		"arn:aws:lambda:us-west-2:123456789012:layer:example_layer-name:42", //lintignore:AWSAT003,AWSAT005
+		"arn:aws-us-gov:lambda:us-gov-west-1:123456789012:layer:example:1",  //lintignore:AWSAT003,AWSAT005
+		"arn:aws-cn:lambda:cn-north-1:123456789012:layer:example:1",         //lintignore:AWSAT003,AWSAT005
+	}
+	for _, v := range validArns {
+		_, errors := validateLambdaLayerVersionArn(v, "layers")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Lambda layer version ARN: %q", v, errors)
+		}
+	}
+
+	invalidArns := []string{
+		"example",
+		// No version
+		"arn:aws:lambda:us-west-2:123456789012:layer:example", //lintignore:AWSAT003,AWSAT005
+		// Not a layer
+		"arn:aws:lambda:us-west-2:123456789012:function:example:1", //lintignore:AWSAT003,AWSAT005
+		// Not Lambda
+		"arn:aws:s3:::example", //lintignore:AWSAT005
+	}
+	for _, v := range invalidArns {
+		_, errors := validateLambdaLayerVersionArn(v, "layers")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Lambda layer version ARN", v)
+		}
+	}
+}
+
 func TestValidateLambdaQualifier(t *testing.T) {
 	validNames := []string{
 		"123",